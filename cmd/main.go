@@ -3,13 +3,19 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"fleet-monitor/internal/config"
 	"fleet-monitor/internal/delivery/http"
 	"fleet-monitor/internal/delivery/http/middleware"
 	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/notify"
+	"fleet-monitor/internal/realtime"
 	"fleet-monitor/internal/repository"
+	"fleet-monitor/internal/storage"
+	"fleet-monitor/internal/tracker"
 	"fleet-monitor/internal/usecase"
+	"fleet-monitor/internal/webhook"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -17,6 +23,13 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/recover"
 )
 
+// documentCheckInterval is how often expiring documents are looked up for
+// notifications; the outbox itself is drained more often.
+const documentCheckInterval = time.Hour
+
+// webhookTimeout bounds a single webhook delivery, including reading the response.
+const webhookTimeout = 10 * time.Second
+
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
@@ -27,6 +40,7 @@ func main() {
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
 		ErrorHandler: helper.GlobalErrorHandler,
+		BodyLimit:    cfg.UploadMaxMB * 1024 * 1024,
 	})
 
 	// Middlewares
@@ -34,24 +48,86 @@ func main() {
 	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-API-Key",
 		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
 	}))
 
+	// Transaction manager
+	txManager := helper.NewTxManager(db)
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	carRepo := repository.NewCarRepository(db)
+	carLocationRepo := repository.NewCarLocationRepository(db)
 	driverRepo := repository.NewDriverRepository(db)
 	tripRepo := repository.NewTripRepository(db)
 	maintenanceRepo := repository.NewMaintenanceRepository(db)
+	maintenancePlanRepo := repository.NewMaintenancePlanRepository(db)
+	workshopRepo := repository.NewWorkshopRepository(db)
+	partRepo := repository.NewPartRepository(db)
+	stockMovementRepo := repository.NewStockMovementRepository(db)
+	fuelLogRepo := repository.NewFuelLogRepository(db)
+	carDocumentRepo := repository.NewCarDocumentRepository(db)
+	checklistRepo := repository.NewInspectionChecklistRepository(db)
+	tripInspectionRepo := repository.NewTripInspectionRepository(db)
+	geofenceRepo := repository.NewGeofenceRepository(db)
+	geofenceEventRepo := repository.NewGeofenceEventRepository(db)
+	reservationRepo := repository.NewReservationRepository(db)
+	tripRequestRepo := repository.NewTripRequestRepository(db)
+	odometerRepo := repository.NewOdometerRepository(db)
+	deviceRepo := repository.NewDeviceRepository(db)
+	deviceKeyRepo := repository.NewDeviceAPIKeyRepository(db)
+	alertRuleRepo := repository.NewAlertRuleRepository(db)
+	alertRepo := repository.NewAlertRepository(db)
+	subscriptionRepo := repository.NewNotificationSubscriptionRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db)
+
+	// Realtime hub for live monitor streaming
+	hub := realtime.NewHub()
+
+	// Notification channels; webhooks need no setup, the others are opt-in
+	notifiers := map[string]notify.Notifier{
+		notify.ChannelWebhook: notify.NewWebhookNotifier(),
+	}
+	if cfg.SMTPHost != "" {
+		notifiers[notify.ChannelEmail] = notify.NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+	if cfg.TelegramBotToken != "" {
+		notifiers[notify.ChannelTelegram] = notify.NewTelegramNotifier(cfg.TelegramAPIURL, cfg.TelegramBotToken)
+	}
+
+	// File storage for uploads
+	fileStore := storage.NewLocalFileStore(cfg.UploadDir)
+
+	// Alert engine, notification outbox and webhook emitter are shared by
+	// every usecase that raises alerts, notifications or webhook events
+	notificationOutbox := usecase.NewNotificationOutbox(subscriptionRepo, notificationRepo)
+	alertEngine := usecase.NewAlertEngine(alertRuleRepo, alertRepo, notificationOutbox)
+	webhookEmitter := usecase.NewWebhookEmitter(webhookRepo, webhookDeliveryRepo)
 
 	// Initialize usecases
-	authUsecase := usecase.NewAuthUsecase(userRepo, cfg)
-	carUsecase := usecase.NewCarUsecase(carRepo)
-	driverUsecase := usecase.NewDriverUsecase(driverRepo)
-	tripUsecase := usecase.NewTripUsecase(tripRepo, carRepo, driverRepo)
-	maintenanceUsecase := usecase.NewMaintenanceUsecase(maintenanceRepo, carRepo)
-	dashboardUsecase := usecase.NewDashboardUsecase(carRepo, driverRepo, tripRepo)
+	authUsecase := usecase.NewAuthUsecase(userRepo, driverRepo, cfg)
+	carUsecase := usecase.NewCarUsecase(carRepo, carLocationRepo, geofenceRepo, geofenceEventRepo, alertEngine, webhookEmitter, txManager, hub)
+	driverUsecase := usecase.NewDriverUsecase(driverRepo, userRepo)
+	tripUsecase := usecase.NewTripUsecase(tripRepo, carRepo, carLocationRepo, driverRepo, checklistRepo, tripInspectionRepo, maintenanceRepo, reservationRepo, tripRequestRepo, odometerRepo, alertEngine, notificationOutbox, webhookEmitter, txManager, hub, cfg)
+	maintenanceUsecase := usecase.NewMaintenanceUsecase(maintenanceRepo, carRepo, workshopRepo, partRepo, stockMovementRepo, odometerRepo, webhookEmitter, txManager, hub, cfg)
+	maintenancePlanUsecase := usecase.NewMaintenancePlanUsecase(maintenancePlanRepo, maintenanceRepo, carRepo, tripRepo, odometerRepo)
+	workshopUsecase := usecase.NewWorkshopUsecase(workshopRepo)
+	partUsecase := usecase.NewPartUsecase(partRepo, stockMovementRepo, txManager)
+	fuelLogUsecase := usecase.NewFuelLogUsecase(fuelLogRepo, carRepo, driverRepo, tripRepo, odometerRepo, fileStore, txManager, cfg)
+	carDocumentUsecase := usecase.NewCarDocumentUsecase(carDocumentRepo, carRepo, fileStore)
+	tripInspectionUsecase := usecase.NewTripInspectionUsecase(checklistRepo, tripInspectionRepo, tripRepo, fileStore)
+	geofenceUsecase := usecase.NewGeofenceUsecase(geofenceRepo, geofenceEventRepo)
+	reservationUsecase := usecase.NewReservationUsecase(reservationRepo, carRepo, driverRepo, txManager)
+	tripRequestUsecase := usecase.NewTripRequestUsecase(tripRequestRepo, carRepo, driverRepo, tripRepo, txManager)
+	odometerUsecase := usecase.NewOdometerUsecase(odometerRepo, carRepo, txManager, cfg)
+	deviceUsecase := usecase.NewDeviceUsecase(deviceRepo, deviceKeyRepo, carRepo, carUsecase)
+	alertUsecase := usecase.NewAlertUsecase(alertRepo, alertRuleRepo, carRepo, geofenceRepo, tripRepo, alertEngine, txManager, hub)
+	notificationUsecase := usecase.NewNotificationUsecase(subscriptionRepo, notificationRepo, carDocumentRepo, notificationOutbox, notifiers, txManager, cfg)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo, webhookDeliveryRepo, webhook.NewSender(webhookTimeout), txManager, cfg)
+	dashboardUsecase := usecase.NewDashboardUsecase(carRepo, driverRepo, tripRepo, carDocumentRepo, odometerRepo, alertRepo)
 
 	// Initialize handlers
 	authHandler := http.NewAuthHandler(authUsecase)
@@ -59,7 +135,23 @@ func main() {
 	driverHandler := http.NewDriverHandler(driverUsecase)
 	tripHandler := http.NewTripHandler(tripUsecase)
 	maintenanceHandler := http.NewMaintenanceHandler(maintenanceUsecase)
+	maintenancePlanHandler := http.NewMaintenancePlanHandler(maintenancePlanUsecase)
+	workshopHandler := http.NewWorkshopHandler(workshopUsecase)
+	partHandler := http.NewPartHandler(partUsecase)
+	fuelLogHandler := http.NewFuelLogHandler(fuelLogUsecase)
+	carDocumentHandler := http.NewCarDocumentHandler(carDocumentUsecase)
+	inspectionHandler := http.NewInspectionHandler(tripInspectionUsecase)
+	geofenceHandler := http.NewGeofenceHandler(geofenceUsecase)
+	reservationHandler := http.NewReservationHandler(reservationUsecase)
+	tripRequestHandler := http.NewTripRequestHandler(tripRequestUsecase)
+	odometerHandler := http.NewOdometerHandler(odometerUsecase)
+	deviceHandler := http.NewDeviceHandler(deviceUsecase)
+	alertHandler := http.NewAlertHandler(alertUsecase)
+	notificationHandler := http.NewNotificationHandler(notificationUsecase)
+	webhookHandler := http.NewWebhookHandler(webhookUsecase)
 	dashboardHandler := http.NewDashboardHandler(dashboardUsecase)
+	streamHandler := http.NewStreamHandler(hub)
+	meHandler := http.NewMeHandler(authUsecase, driverUsecase, tripUsecase, tripInspectionUsecase, tripRequestUsecase)
 
 	// JWT middleware
 	jwtMiddleware := middleware.JWTMiddleware(cfg)
@@ -70,53 +162,306 @@ func main() {
 	// Auth routes (public)
 	auth := api.Group("/auth")
 	auth.Post("/login", authHandler.Login)
-	auth.Post("/register", authHandler.Register)
 
-	// Protected routes
+	// Protected routes; device API keys are checked first and skip the JWT
+	api.Use(middleware.DeviceKeyMiddleware(deviceUsecase))
 	api.Use(jwtMiddleware)
 
+	// Accounts are created by an admin
+	auth.Post("/register", middleware.RequirePermission(middleware.PermUserWrite), authHandler.Register)
+
 	// Dashboard routes
-	api.Get("/dashboard/summary", dashboardHandler.GetSummary)
+	api.Get("/dashboard/summary", middleware.RequirePermission(middleware.PermDashboardRead), dashboardHandler.GetSummary)
+
+	// Stream routes
+	api.Get("/stream/locations", middleware.RequirePermission(middleware.PermStreamRead), streamHandler.Locations)
 
 	// Car routes
 	cars := api.Group("/cars")
-	cars.Get("/", carHandler.GetAll)
-	cars.Get("/:id", carHandler.GetByID)
-	cars.Post("/", carHandler.Create)
-	cars.Put("/:id", carHandler.Update)
-	cars.Delete("/:id", carHandler.Delete)
-	cars.Put("/:id/location", carHandler.UpdateLocation)
+	cars.Get("/", middleware.RequirePermission(middleware.PermCarRead), carHandler.GetAll)
+	cars.Get("/:id", middleware.RequirePermission(middleware.PermCarRead), carHandler.GetByID)
+	cars.Post("/", middleware.RequirePermission(middleware.PermCarWrite), carHandler.Create)
+	cars.Put("/:id", middleware.RequirePermission(middleware.PermCarWrite), carHandler.Update)
+	cars.Delete("/:id", middleware.RequirePermission(middleware.PermCarDelete), carHandler.Delete)
+	cars.Put("/:id/location", middleware.RequirePermission(middleware.PermCarLocation), middleware.RequireDeviceCar(), carHandler.UpdateLocation)
+	cars.Post("/:id/locations/batch", middleware.RequirePermission(middleware.PermCarLocation), middleware.RequireDeviceCar(), carHandler.UpdateLocationsBatch)
+	cars.Get("/:id/locations", middleware.RequirePermission(middleware.PermCarRead), carHandler.GetLocations)
+
+	// Car document routes
+	cars.Get("/:id/documents", middleware.RequirePermission(middleware.PermDocumentRead), carDocumentHandler.GetAll)
+	cars.Get("/:id/documents/:docId", middleware.RequirePermission(middleware.PermDocumentRead), carDocumentHandler.GetByID)
+	cars.Get("/:id/documents/:docId/file", middleware.RequirePermission(middleware.PermDocumentRead), carDocumentHandler.Download)
+	cars.Post("/:id/documents", middleware.RequirePermission(middleware.PermDocumentWrite), carDocumentHandler.Create)
+	cars.Put("/:id/documents/:docId", middleware.RequirePermission(middleware.PermDocumentWrite), carDocumentHandler.Update)
+	cars.Delete("/:id/documents/:docId", middleware.RequirePermission(middleware.PermDocumentDelete), carDocumentHandler.Delete)
+	api.Get("/documents/expiring", middleware.RequirePermission(middleware.PermDocumentRead), carDocumentHandler.GetExpiring)
+
+	// Odometer routes
+	cars.Get("/:id/odometer", middleware.RequirePermission(middleware.PermOdometerRead), odometerHandler.GetAll)
+	cars.Post("/:id/odometer", middleware.RequirePermission(middleware.PermOdometerWrite), odometerHandler.Create)
+	api.Get("/odometer-readings", middleware.RequirePermission(middleware.PermOdometerRead), odometerHandler.GetAll)
+	api.Post("/odometer-readings/:id/review", middleware.RequirePermission(middleware.PermOdometerWrite), odometerHandler.Review)
 
 	// Driver routes
 	drivers := api.Group("/drivers")
-	drivers.Get("/", driverHandler.GetAll)
-	drivers.Get("/:id", driverHandler.GetByID)
-	drivers.Post("/", driverHandler.Create)
-	drivers.Put("/:id", driverHandler.Update)
-	drivers.Delete("/:id", driverHandler.Delete)
+	drivers.Get("/", middleware.RequirePermission(middleware.PermDriverRead), driverHandler.GetAll)
+	drivers.Get("/:id", middleware.RequirePermission(middleware.PermDriverRead), driverHandler.GetByID)
+	drivers.Post("/", middleware.RequirePermission(middleware.PermDriverWrite), driverHandler.Create)
+	drivers.Put("/:id", middleware.RequirePermission(middleware.PermDriverWrite), driverHandler.Update)
+	drivers.Delete("/:id", middleware.RequirePermission(middleware.PermDriverDelete), driverHandler.Delete)
 
 	// Trip routes
 	trips := api.Group("/trips")
-	trips.Get("/", tripHandler.GetAll)
-	trips.Get("/:id", tripHandler.GetByID)
-	trips.Post("/checkout", tripHandler.Checkout)
-	trips.Post("/checkin", tripHandler.Checkin)
+	trips.Get("/", middleware.RequirePermission(middleware.PermTripRead), tripHandler.GetAll)
+	trips.Get("/:id", middleware.RequirePermission(middleware.PermTripRead), tripHandler.GetByID)
+	trips.Post("/checkout", middleware.RequirePermission(middleware.PermTripWrite), tripHandler.Checkout)
+	trips.Post("/checkin", middleware.RequirePermission(middleware.PermTripWrite), tripHandler.Checkin)
+	trips.Get("/:id/route", middleware.RequirePermission(middleware.PermTripRead), tripHandler.GetRoute)
+	trips.Get("/:id/inspections", middleware.RequirePermission(middleware.PermTripRead), inspectionHandler.GetByTrip)
+	trips.Post("/:id/photos", middleware.RequirePermission(middleware.PermTripWrite), inspectionHandler.UploadPhotos)
+	trips.Get("/:id/photos/:photoId", middleware.RequirePermission(middleware.PermTripRead), inspectionHandler.DownloadPhoto)
+
+	// Trip request (approval) routes
+	tripRequests := api.Group("/trip-requests")
+	tripRequests.Get("/", middleware.RequirePermission(middleware.PermTripRead), tripRequestHandler.GetAll)
+	tripRequests.Get("/:id", middleware.RequirePermission(middleware.PermTripRead), tripRequestHandler.GetByID)
+	tripRequests.Post("/", middleware.RequirePermission(middleware.PermTripWrite), tripRequestHandler.Create)
+	tripRequests.Post("/:id/approve", middleware.RequirePermission(middleware.PermTripApprove), tripRequestHandler.Approve)
+	tripRequests.Post("/:id/reject", middleware.RequirePermission(middleware.PermTripApprove), tripRequestHandler.Reject)
+	tripRequests.Post("/:id/cancel", middleware.RequirePermission(middleware.PermTripWrite), tripRequestHandler.Cancel)
+
+	// Reservation routes
+	reservations := api.Group("/reservations")
+	reservations.Get("/", middleware.RequirePermission(middleware.PermReservationRead), reservationHandler.GetAll)
+	reservations.Get("/:id", middleware.RequirePermission(middleware.PermReservationRead), reservationHandler.GetByID)
+	reservations.Post("/", middleware.RequirePermission(middleware.PermReservationWrite), reservationHandler.Create)
+	reservations.Put("/:id", middleware.RequirePermission(middleware.PermReservationWrite), reservationHandler.Update)
+	reservations.Post("/:id/cancel", middleware.RequirePermission(middleware.PermReservationWrite), reservationHandler.Cancel)
+
+	// Inspection checklist routes
+	inspectionItems := api.Group("/inspection-items")
+	inspectionItems.Get("/", middleware.RequirePermission(middleware.PermTripRead), inspectionHandler.GetChecklist)
+	inspectionItems.Get("/:id", middleware.RequirePermission(middleware.PermTripRead), inspectionHandler.GetChecklistItem)
+	inspectionItems.Post("/", middleware.RequirePermission(middleware.PermInspectionWrite), inspectionHandler.CreateChecklistItem)
+	inspectionItems.Put("/:id", middleware.RequirePermission(middleware.PermInspectionWrite), inspectionHandler.UpdateChecklistItem)
+	inspectionItems.Delete("/:id", middleware.RequirePermission(middleware.PermInspectionDelete), inspectionHandler.DeleteChecklistItem)
+
+	// Geofence routes
+	geofences := api.Group("/geofences")
+	geofences.Get("/", middleware.RequirePermission(middleware.PermGeofenceRead), geofenceHandler.GetAll)
+	geofences.Get("/events", middleware.RequirePermission(middleware.PermGeofenceRead), geofenceHandler.GetEvents)
+	geofences.Get("/:id", middleware.RequirePermission(middleware.PermGeofenceRead), geofenceHandler.GetByID)
+	geofences.Get("/:id/events", middleware.RequirePermission(middleware.PermGeofenceRead), geofenceHandler.GetEvents)
+	geofences.Post("/", middleware.RequirePermission(middleware.PermGeofenceWrite), geofenceHandler.Create)
+	geofences.Put("/:id", middleware.RequirePermission(middleware.PermGeofenceWrite), geofenceHandler.Update)
+	geofences.Delete("/:id", middleware.RequirePermission(middleware.PermGeofenceDelete), geofenceHandler.Delete)
+
+	// Device (GPS tracker) routes
+	devices := api.Group("/devices")
+	devices.Get("/", middleware.RequirePermission(middleware.PermDeviceRead), deviceHandler.GetAll)
+	devices.Get("/:id", middleware.RequirePermission(middleware.PermDeviceRead), deviceHandler.GetByID)
+	devices.Post("/", middleware.RequirePermission(middleware.PermDeviceWrite), deviceHandler.Create)
+	devices.Put("/:id", middleware.RequirePermission(middleware.PermDeviceWrite), deviceHandler.Update)
+	devices.Delete("/:id", middleware.RequirePermission(middleware.PermDeviceDelete), deviceHandler.Delete)
+	devices.Get("/:id/keys", middleware.RequirePermission(middleware.PermDeviceRead), deviceHandler.GetKeys)
+	devices.Post("/:id/keys", middleware.RequirePermission(middleware.PermDeviceWrite), deviceHandler.CreateKey)
+	devices.Post("/:id/keys/:keyId/revoke", middleware.RequirePermission(middleware.PermDeviceWrite), deviceHandler.RevokeKey)
+
+	// Alert routes
+	alerts := api.Group("/alerts")
+	alerts.Get("/", middleware.RequirePermission(middleware.PermAlertRead), alertHandler.GetAll)
+	alerts.Get("/:id", middleware.RequirePermission(middleware.PermAlertRead), alertHandler.GetByID)
+	alerts.Post("/:id/acknowledge", middleware.RequirePermission(middleware.PermAlertWrite), alertHandler.Acknowledge)
+	alerts.Post("/:id/resolve", middleware.RequirePermission(middleware.PermAlertWrite), alertHandler.Resolve)
+
+	// Alert rule routes
+	alertRules := api.Group("/alert-rules")
+	alertRules.Get("/", middleware.RequirePermission(middleware.PermAlertRead), alertHandler.GetRules)
+	alertRules.Get("/:id", middleware.RequirePermission(middleware.PermAlertRead), alertHandler.GetRuleByID)
+	alertRules.Post("/", middleware.RequirePermission(middleware.PermAlertWrite), alertHandler.CreateRule)
+	alertRules.Put("/:id", middleware.RequirePermission(middleware.PermAlertWrite), alertHandler.UpdateRule)
+	alertRules.Delete("/:id", middleware.RequirePermission(middleware.PermAlertDelete), alertHandler.DeleteRule)
+
+	// Notification routes (per user)
+	api.Get("/notifications", middleware.RequirePermission(middleware.PermNotificationSelf), notificationHandler.GetAll)
+	subscriptions := api.Group("/notification-subscriptions", middleware.RequirePermission(middleware.PermNotificationSelf))
+	subscriptions.Get("/", notificationHandler.GetSubscriptions)
+	subscriptions.Post("/", notificationHandler.CreateSubscription)
+	subscriptions.Put("/:id", notificationHandler.UpdateSubscription)
+	subscriptions.Delete("/:id", notificationHandler.DeleteSubscription)
+
+	// Outgoing webhook routes
+	webhooks := api.Group("/webhooks")
+	webhooks.Get("/", middleware.RequirePermission(middleware.PermWebhookRead), webhookHandler.GetAll)
+	webhooks.Get("/:id", middleware.RequirePermission(middleware.PermWebhookRead), webhookHandler.GetByID)
+	webhooks.Post("/", middleware.RequirePermission(middleware.PermWebhookWrite), webhookHandler.Create)
+	webhooks.Put("/:id", middleware.RequirePermission(middleware.PermWebhookWrite), webhookHandler.Update)
+	webhooks.Delete("/:id", middleware.RequirePermission(middleware.PermWebhookDelete), webhookHandler.Delete)
+	webhooks.Get("/:id/deliveries", middleware.RequirePermission(middleware.PermWebhookRead), webhookHandler.GetDeliveries)
+	webhooks.Post("/:id/deliveries/:deliveryId/redeliver", middleware.RequirePermission(middleware.PermWebhookWrite), webhookHandler.Redeliver)
 
 	// Maintenance routes
 	maintenances := api.Group("/maintenances")
-	maintenances.Get("/", maintenanceHandler.GetAll)
-	maintenances.Get("/:id", maintenanceHandler.GetByID)
-	maintenances.Post("/", maintenanceHandler.Create)
-	maintenances.Put("/:id", maintenanceHandler.Update)
-	maintenances.Delete("/:id", maintenanceHandler.Delete)
+	maintenances.Get("/", middleware.RequirePermission(middleware.PermMaintenanceRead), maintenanceHandler.GetAll)
+	maintenances.Get("/due", middleware.RequirePermission(middleware.PermMaintenanceRead), maintenancePlanHandler.GetDue)
+	maintenances.Get("/:id", middleware.RequirePermission(middleware.PermMaintenanceRead), maintenanceHandler.GetByID)
+	maintenances.Post("/", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenanceHandler.Create)
+	maintenances.Put("/:id", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenanceHandler.Update)
+	maintenances.Delete("/:id", middleware.RequirePermission(middleware.PermMaintenanceDelete), maintenanceHandler.Delete)
+	maintenances.Post("/:id/start", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenanceHandler.Start)
+	maintenances.Post("/:id/complete", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenanceHandler.Complete)
+	maintenances.Post("/:id/cancel", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenanceHandler.Cancel)
+
+	// Maintenance plan routes
+	maintenancePlans := api.Group("/maintenance-plans")
+	maintenancePlans.Get("/", middleware.RequirePermission(middleware.PermMaintenanceRead), maintenancePlanHandler.GetAll)
+	maintenancePlans.Get("/:id", middleware.RequirePermission(middleware.PermMaintenanceRead), maintenancePlanHandler.GetByID)
+	maintenancePlans.Post("/", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenancePlanHandler.Create)
+	maintenancePlans.Put("/:id", middleware.RequirePermission(middleware.PermMaintenanceWrite), maintenancePlanHandler.Update)
+	maintenancePlans.Delete("/:id", middleware.RequirePermission(middleware.PermMaintenanceDelete), maintenancePlanHandler.Delete)
+
+	// Workshop (vendor) routes
+	workshops := api.Group("/workshops")
+	workshops.Get("/", middleware.RequirePermission(middleware.PermMaintenanceRead), workshopHandler.GetAll)
+	workshops.Get("/report", middleware.RequirePermission(middleware.PermMaintenanceRead), workshopHandler.Report)
+	workshops.Get("/:id", middleware.RequirePermission(middleware.PermMaintenanceRead), workshopHandler.GetByID)
+	workshops.Post("/", middleware.RequirePermission(middleware.PermMaintenanceWrite), workshopHandler.Create)
+	workshops.Put("/:id", middleware.RequirePermission(middleware.PermMaintenanceWrite), workshopHandler.Update)
+	workshops.Delete("/:id", middleware.RequirePermission(middleware.PermMaintenanceDelete), workshopHandler.Delete)
+
+	// Spare parts inventory routes
+	parts := api.Group("/parts")
+	parts.Get("/", middleware.RequirePermission(middleware.PermInventoryRead), partHandler.GetAll)
+	parts.Get("/low-stock", middleware.RequirePermission(middleware.PermInventoryRead), partHandler.GetLowStock)
+	parts.Get("/movements", middleware.RequirePermission(middleware.PermInventoryRead), partHandler.GetMovements)
+	parts.Get("/:id", middleware.RequirePermission(middleware.PermInventoryRead), partHandler.GetByID)
+	parts.Get("/:id/movements", middleware.RequirePermission(middleware.PermInventoryRead), partHandler.GetMovements)
+	parts.Post("/", middleware.RequirePermission(middleware.PermInventoryWrite), partHandler.Create)
+	parts.Put("/:id", middleware.RequirePermission(middleware.PermInventoryWrite), partHandler.Update)
+	parts.Delete("/:id", middleware.RequirePermission(middleware.PermInventoryDelete), partHandler.Delete)
+	parts.Post("/:id/receive", middleware.RequirePermission(middleware.PermInventoryWrite), partHandler.Receive)
+	parts.Post("/:id/adjust", middleware.RequirePermission(middleware.PermInventoryWrite), partHandler.Adjust)
+
+	// Fuel log routes
+	fuelLogs := api.Group("/fuel-logs")
+	fuelLogs.Get("/", middleware.RequirePermission(middleware.PermFuelRead), fuelLogHandler.GetAll)
+	fuelLogs.Get("/efficiency", middleware.RequirePermission(middleware.PermFuelRead), fuelLogHandler.GetEfficiency)
+	fuelLogs.Get("/:id", middleware.RequirePermission(middleware.PermFuelRead), fuelLogHandler.GetByID)
+	fuelLogs.Get("/:id/receipt", middleware.RequirePermission(middleware.PermFuelRead), fuelLogHandler.DownloadReceipt)
+	fuelLogs.Post("/", middleware.RequirePermission(middleware.PermFuelWrite), fuelLogHandler.Create)
+	fuelLogs.Put("/:id", middleware.RequirePermission(middleware.PermFuelWrite), fuelLogHandler.Update)
+	fuelLogs.Delete("/:id", middleware.RequirePermission(middleware.PermFuelDelete), fuelLogHandler.Delete)
+
+	// Driver self-service routes
+	me := api.Group("/me", middleware.RequirePermission(middleware.PermSelfService))
+	me.Get("/", meHandler.Profile)
+	me.Get("/trip", meHandler.ActiveTrip)
+	me.Get("/trips", meHandler.History)
+	me.Post("/checkout", meHandler.Checkout)
+	me.Post("/checkin", meHandler.Checkin)
+	me.Get("/checklist", meHandler.Checklist)
+	me.Post("/trip/photos", meHandler.UploadPhotos)
+	me.Get("/trip-requests", meHandler.TripRequests)
+	me.Post("/trip-requests", meHandler.CreateTripRequest)
+	me.Post("/trip-requests/:id/cancel", meHandler.CancelTripRequest)
 
 	// Health check
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"status": "ok"})
 	})
 
+	// GPS tracker listeners
+	startTrackerListener(tracker.ProtocolGT06, cfg.TrackerGT06Addr, deviceUsecase)
+	startTrackerListener(tracker.ProtocolTeltonika, cfg.TrackerTeltonikaAddr, deviceUsecase)
+
+	// Periodic alert checks (stale GPS, long trips)
+	startAlertScheduler(alertUsecase, time.Duration(cfg.AlertCheckIntervalSeconds)*time.Second)
+
+	// Notification outbox delivery
+	startNotificationDispatcher(notificationUsecase, time.Duration(cfg.NotificationDispatchIntervalSeconds)*time.Second)
+
+	// Outgoing webhook delivery
+	startWebhookDispatcher(webhookUsecase, time.Duration(cfg.WebhookDispatchIntervalSeconds)*time.Second)
+
 	// Start server
 	port := fmt.Sprintf(":%s", cfg.AppPort)
 	log.Printf("Server starting on port %s", port)
 	log.Fatal(app.Listen(port))
 }
+
+// startTrackerListener serves a tracker protocol in the background when an
+// address is configured for it.
+func startTrackerListener(protocol, addr string, sink tracker.Sink) {
+	if addr == "" {
+		return
+	}
+	server, err := tracker.NewServer(protocol, addr, sink)
+	if err != nil {
+		log.Fatalf("Failed to create %s tracker listener: %v", protocol, err)
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil {
+			log.Fatalf("%s tracker listener stopped: %v", protocol, err)
+		}
+	}()
+}
+
+// startAlertScheduler runs the time based alert checks in the background
+// until the process exits. A non-positive interval disables it.
+func startAlertScheduler(alerts usecase.AlertUsecase, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if err := alerts.RunChecks(now); err != nil {
+				log.Printf("Alert checks failed: %v", err)
+			}
+		}
+	}()
+}
+
+// startNotificationDispatcher delivers queued notifications in the background
+// and periodically queues document expiry notices. A non-positive interval
+// disables it.
+func startNotificationDispatcher(notifications usecase.NotificationUsecase, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var lastDocumentCheck time.Time
+		for now := range ticker.C {
+			if now.Sub(lastDocumentCheck) >= documentCheckInterval {
+				if err := notifications.QueueDocumentExpiry(now); err != nil {
+					log.Printf("Document expiry check failed: %v", err)
+				} else {
+					lastDocumentCheck = now
+				}
+			}
+			if err := notifications.Dispatch(now); err != nil {
+				log.Printf("Notification dispatch failed: %v", err)
+			}
+		}
+	}()
+}
+
+// startWebhookDispatcher delivers queued webhook events in the background. A
+// non-positive interval disables it.
+func startWebhookDispatcher(webhooks usecase.WebhookUsecase, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if err := webhooks.Dispatch(now); err != nil {
+				log.Printf("Webhook dispatch failed: %v", err)
+			}
+		}
+	}()
+}
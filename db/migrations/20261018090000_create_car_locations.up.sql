@@ -0,0 +1,11 @@
+-- Riwayat lokasi GPS per mobil (time-series)
+CREATE TABLE car_locations (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    lat DECIMAL(10, 8) NOT NULL,
+    lng DECIMAL(11, 8) NOT NULL,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Waktu posisi tercatat
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_car_locations_car_id_recorded_at ON car_locations(car_id, recorded_at);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_trip_logs_active_car;
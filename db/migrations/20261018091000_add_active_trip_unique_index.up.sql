@@ -0,0 +1,2 @@
+-- Satu mobil hanya boleh punya satu trip aktif (pengaman terakhir di level database)
+CREATE UNIQUE INDEX idx_trip_logs_active_car ON trip_logs(car_id) WHERE end_time IS NULL;
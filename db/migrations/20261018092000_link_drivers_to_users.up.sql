@@ -0,0 +1,2 @@
+-- Hubungkan akun login (role driver) dengan data supir
+ALTER TABLE drivers ADD COLUMN user_id BIGINT UNIQUE REFERENCES users(id) ON DELETE SET NULL;
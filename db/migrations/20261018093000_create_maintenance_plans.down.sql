@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_maintenances_car_id_service_date;
+ALTER TABLE maintenances DROP COLUMN IF EXISTS maintenance_plan_id;
+DROP TABLE IF EXISTS maintenance_plans;
@@ -0,0 +1,22 @@
+-- Jadwal service rutin per mobil atau per brand/model
+CREATE TABLE maintenance_plans (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT REFERENCES cars(id) ON DELETE CASCADE, -- Null = berlaku untuk semua mobil brand/model ini
+    brand VARCHAR(50),
+    model VARCHAR(50),
+    name VARCHAR(100) NOT NULL, -- Ganti Oli, Tune Up, dll
+    interval_km INT, -- Contoh: setiap 10000 km
+    interval_months INT, -- Contoh: setiap 6 bulan
+    warning_km INT NOT NULL DEFAULT 500,
+    warning_days INT NOT NULL DEFAULT 14,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (car_id IS NOT NULL OR (brand IS NOT NULL AND model IS NOT NULL)),
+    CHECK (interval_km IS NOT NULL OR interval_months IS NOT NULL)
+);
+
+-- Service yang dicatat bisa dikaitkan ke jadwalnya
+ALTER TABLE maintenances ADD COLUMN maintenance_plan_id BIGINT REFERENCES maintenance_plans(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_maintenances_car_id_service_date ON maintenances(car_id, service_date);
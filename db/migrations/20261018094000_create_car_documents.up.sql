@@ -0,0 +1,18 @@
+-- Dokumen kendaraan (STNK, Pajak, KIR, Asuransi) beserta masa berlakunya
+CREATE TABLE car_documents (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL, -- STNK, PAJAK, KIR, INSURANCE
+    number VARCHAR(100),
+    issue_date TIMESTAMP,
+    expiry_date TIMESTAMP NOT NULL,
+    file_key VARCHAR(255), -- Lokasi file di storage
+    file_name VARCHAR(255),
+    file_content_type VARCHAR(100),
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_car_documents_car_id ON car_documents(car_id);
+CREATE INDEX idx_car_documents_expiry_date ON car_documents(expiry_date);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS trip_inspection_photos;
+DROP TABLE IF EXISTS trip_inspection_items;
+DROP TABLE IF EXISTS trip_inspections;
+DROP TABLE IF EXISTS inspection_checklist_items;
@@ -0,0 +1,57 @@
+-- Daftar pertanyaan inspeksi yang bisa diatur admin
+CREATE TABLE inspection_checklist_items (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    label VARCHAR(150) NOT NULL,
+    stage VARCHAR(20) NOT NULL, -- CHECKOUT, CHECKIN, BOTH
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    on_fail VARCHAR(20) NOT NULL DEFAULT 'IGNORE', -- IGNORE, BLOCK, MAINTENANCE
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Hasil inspeksi per trip, satu untuk checkout dan satu untuk checkin
+CREATE TABLE trip_inspections (
+    id BIGSERIAL PRIMARY KEY,
+    trip_id BIGINT NOT NULL REFERENCES trip_logs(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL, -- CHECKOUT, CHECKIN
+    fuel_level INTEGER CHECK (fuel_level BETWEEN 0 AND 100),
+    damage_notes TEXT,
+    passed BOOLEAN NOT NULL DEFAULT TRUE,
+    maintenance_id BIGINT REFERENCES maintenances(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trip_id, stage)
+);
+
+CREATE TABLE trip_inspection_items (
+    id BIGSERIAL PRIMARY KEY,
+    inspection_id BIGINT NOT NULL REFERENCES trip_inspections(id) ON DELETE CASCADE,
+    checklist_item_id BIGINT NOT NULL REFERENCES inspection_checklist_items(id),
+    code VARCHAR(50) NOT NULL, -- Disalin agar riwayat tetap terbaca walau checklist berubah
+    label VARCHAR(150) NOT NULL,
+    ok BOOLEAN NOT NULL,
+    notes TEXT
+);
+
+CREATE TABLE trip_inspection_photos (
+    id BIGSERIAL PRIMARY KEY,
+    trip_id BIGINT NOT NULL REFERENCES trip_logs(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL,
+    file_key VARCHAR(255) NOT NULL, -- Lokasi file di storage
+    file_name VARCHAR(255),
+    content_type VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_trip_inspection_items_inspection_id ON trip_inspection_items(inspection_id);
+CREATE INDEX idx_trip_inspection_photos_trip_id ON trip_inspection_photos(trip_id);
+
+-- Checklist bawaan, sama dengan yang ditampilkan di aplikasi driver
+INSERT INTO inspection_checklist_items (code, label, stage, required, on_fail, sort_order) VALUES
+('FUEL', 'Bahan bakar cukup', 'CHECKOUT', TRUE, 'IGNORE', 1),
+('TIRES', 'Kondisi ban baik', 'CHECKOUT', TRUE, 'BLOCK', 2),
+('STNK', 'STNK tersedia di mobil', 'CHECKOUT', TRUE, 'BLOCK', 3),
+('CAR_LOCKED', 'Mobil sudah dikunci', 'CHECKIN', TRUE, 'IGNORE', 1),
+('STNK_RETURNED', 'STNK dikembalikan', 'CHECKIN', TRUE, 'IGNORE', 2);
@@ -0,0 +1,29 @@
+-- Area geofence: lingkaran (GeoJSON Point + radius) atau poligon (GeoJSON Polygon)
+CREATE TABLE geofences (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    type VARCHAR(20) NOT NULL, -- CIRCLE, POLYGON
+    geometry JSONB NOT NULL,
+    radius_m DOUBLE PRECISION DEFAULT 0, -- Hanya dipakai untuk CIRCLE
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Riwayat mobil masuk/keluar geofence
+CREATE TABLE geofence_events (
+    id BIGSERIAL PRIMARY KEY,
+    geofence_id BIGINT NOT NULL REFERENCES geofences(id) ON DELETE CASCADE,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    type VARCHAR(10) NOT NULL, -- ENTER, EXIT
+    lat DECIMAL(10, 8) NOT NULL,
+    lng DECIMAL(11, 8) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Dipakai untuk mencari status terakhir mobil per geofence
+CREATE INDEX idx_geofence_events_car_geofence ON geofence_events(car_id, geofence_id, occurred_at DESC);
+CREATE INDEX idx_geofence_events_geofence_id ON geofence_events(geofence_id, occurred_at DESC);
+CREATE INDEX idx_geofence_events_occurred_at ON geofence_events(occurred_at);
@@ -0,0 +1,21 @@
+-- Booking mobil pool untuk jadwal ke depan
+CREATE TABLE reservations (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    driver_id BIGINT REFERENCES drivers(id) ON DELETE SET NULL, -- Kosong jika supir ditentukan saat pengambilan
+    requester_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    purpose VARCHAR(255) NOT NULL,
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'CONFIRMED', -- CONFIRMED, FULFILLED, CANCELLED
+    trip_id BIGINT REFERENCES trip_logs(id) ON DELETE SET NULL,
+    cancel_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_time > start_time)
+);
+
+-- Query kalender dan cek bentrok jadwal
+CREATE INDEX idx_reservations_car_time ON reservations(car_id, start_time, end_time);
+CREATE INDEX idx_reservations_driver_time ON reservations(driver_id, start_time, end_time);
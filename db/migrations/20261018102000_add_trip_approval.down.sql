@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS trip_requests;
+
+ALTER TABLE cars DROP COLUMN IF EXISTS requires_approval;
+
+DROP INDEX IF EXISTS idx_trip_logs_status;
+DROP INDEX IF EXISTS idx_trip_logs_active_car;
+CREATE UNIQUE INDEX idx_trip_logs_active_car ON trip_logs(car_id) WHERE end_time IS NULL;
+ALTER TABLE trip_logs DROP COLUMN IF EXISTS status;
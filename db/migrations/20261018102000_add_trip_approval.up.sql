@@ -0,0 +1,37 @@
+-- Status trip eksplisit, tidak lagi hanya mengandalkan end_time IS NULL
+ALTER TABLE trip_logs ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'IN_PROGRESS'; -- IN_PROGRESS, COMPLETED
+UPDATE trip_logs SET status = 'COMPLETED' WHERE end_time IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_trip_logs_active_car;
+CREATE UNIQUE INDEX idx_trip_logs_active_car ON trip_logs(car_id) WHERE status = 'IN_PROGRESS';
+CREATE INDEX idx_trip_logs_status ON trip_logs(status);
+
+-- Mobil yang checkout-nya wajib disetujui manager
+ALTER TABLE cars ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Pengajuan trip: REQUESTED -> APPROVED/REJECTED -> CHECKED_OUT -> COMPLETED, CANCELLED sebelum checkout
+CREATE TABLE trip_requests (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    driver_id BIGINT NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+    requester_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    purpose VARCHAR(255) NOT NULL,
+    destination VARCHAR(255),
+    planned_start TIMESTAMP,
+    planned_end TIMESTAMP,
+    status VARCHAR(20) NOT NULL DEFAULT 'REQUESTED',
+    approver_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMP,
+    approval_note TEXT,
+    rejected_at TIMESTAMP,
+    reject_reason TEXT,
+    cancelled_at TIMESTAMP,
+    cancel_reason TEXT,
+    trip_id BIGINT REFERENCES trip_logs(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_trip_requests_status ON trip_requests(status);
+CREATE INDEX idx_trip_requests_car_driver ON trip_requests(car_id, driver_id, status);
+CREATE UNIQUE INDEX idx_trip_requests_trip_id ON trip_requests(trip_id) WHERE trip_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS odometer_readings;
+
+ALTER TABLE cars DROP COLUMN IF EXISTS current_km;
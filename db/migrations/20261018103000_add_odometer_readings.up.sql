@@ -0,0 +1,47 @@
+-- Odometer terakhir yang diketahui per mobil
+ALTER TABLE cars ADD COLUMN current_km INT NOT NULL DEFAULT 0;
+
+UPDATE cars c SET current_km = t.km
+FROM (
+    SELECT car_id, MAX(GREATEST(COALESCE(start_km, 0), COALESCE(end_km, 0))) AS km
+    FROM trip_logs
+    GROUP BY car_id
+) t
+WHERE t.car_id = c.id;
+
+-- Riwayat pembacaan odometer; yang tidak wajar ditandai (flagged) untuk direview
+CREATE TABLE odometer_readings (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    km INT NOT NULL,
+    previous_km INT NOT NULL DEFAULT 0,
+    source VARCHAR(20) NOT NULL, -- CHECKOUT, CHECKIN, MAINTENANCE, MANUAL
+    trip_id BIGINT REFERENCES trip_logs(id) ON DELETE SET NULL,
+    maintenance_id BIGINT REFERENCES maintenances(id) ON DELETE SET NULL,
+    recorded_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    recorded_at TIMESTAMP NOT NULL,
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    flag_reason VARCHAR(255),
+    reviewed_at TIMESTAMP,
+    reviewed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_odometer_readings_car ON odometer_readings(car_id, recorded_at DESC);
+CREATE INDEX idx_odometer_readings_unreviewed ON odometer_readings(flagged) WHERE flagged = TRUE AND reviewed_at IS NULL;
+
+-- Isi riwayat dari trip yang sudah ada (tanpa flag, data lama dianggap valid)
+INSERT INTO odometer_readings (car_id, km, previous_km, source, trip_id, recorded_at)
+SELECT car_id, km,
+       COALESCE(MAX(km) OVER (PARTITION BY car_id ORDER BY recorded_at, seq ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING), 0),
+       source, trip_id, recorded_at
+FROM (
+    SELECT car_id, start_km AS km, 'CHECKOUT' AS source, id AS trip_id, start_time AS recorded_at, 0 AS seq
+    FROM trip_logs
+    WHERE start_km IS NOT NULL AND start_time IS NOT NULL
+    UNION ALL
+    SELECT car_id, end_km, 'CHECKIN', id, end_time, 1
+    FROM trip_logs
+    WHERE end_km IS NOT NULL AND end_time IS NOT NULL
+) r;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_trip_logs_distance_mismatch;
+
+ALTER TABLE trip_logs DROP COLUMN IF EXISTS distance_mismatch;
+ALTER TABLE trip_logs DROP COLUMN IF EXISTS max_speed_kmh;
+ALTER TABLE trip_logs DROP COLUMN IF EXISTS idle_seconds;
+ALTER TABLE trip_logs DROP COLUMN IF EXISTS moving_seconds;
+ALTER TABLE trip_logs DROP COLUMN IF EXISTS gps_distance_km;
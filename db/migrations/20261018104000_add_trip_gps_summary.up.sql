@@ -0,0 +1,9 @@
+-- Ringkasan track GPS per trip, dihitung saat checkin
+ALTER TABLE trip_logs ADD COLUMN gps_distance_km DECIMAL(10,2);
+ALTER TABLE trip_logs ADD COLUMN moving_seconds INT;
+ALTER TABLE trip_logs ADD COLUMN idle_seconds INT;
+ALTER TABLE trip_logs ADD COLUMN max_speed_kmh DECIMAL(6,2);
+-- TRUE jika jarak GPS dan odometer berbeda melebihi toleransi
+ALTER TABLE trip_logs ADD COLUMN distance_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_trip_logs_distance_mismatch ON trip_logs(distance_mismatch) WHERE distance_mismatch = TRUE;
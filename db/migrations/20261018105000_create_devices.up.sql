@@ -0,0 +1,15 @@
+-- GPS tracker hardware (GT06, Teltonika) yang terhubung lewat TCP
+CREATE TABLE devices (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    imei VARCHAR(20) NOT NULL UNIQUE,
+    protocol VARCHAR(20) NOT NULL, -- GT06, TELTONIKA
+    car_id BIGINT REFERENCES cars(id) ON DELETE SET NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_seen_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Satu mobil hanya boleh punya satu device aktif
+CREATE UNIQUE INDEX idx_devices_active_car ON devices(car_id) WHERE active = TRUE AND car_id IS NOT NULL;
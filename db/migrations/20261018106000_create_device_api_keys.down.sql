@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS device_api_keys;
+
+DELETE FROM devices WHERE imei IS NULL;
+ALTER TABLE devices DROP CONSTRAINT IF EXISTS chk_devices_imei;
+ALTER TABLE devices ALTER COLUMN imei SET NOT NULL;
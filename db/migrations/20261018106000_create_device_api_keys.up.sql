@@ -0,0 +1,18 @@
+-- Device HTTP (aplikasi HP, script simulator) tidak punya IMEI
+ALTER TABLE devices ALTER COLUMN imei DROP NOT NULL;
+ALTER TABLE devices ADD CONSTRAINT chk_devices_imei CHECK (protocol = 'HTTP' OR imei IS NOT NULL);
+
+-- API key per device untuk kirim lokasi tanpa JWT user; hanya hash SHA-256 yang disimpan
+CREATE TABLE device_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    device_id BIGINT NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_device_api_keys_device_id ON device_api_keys(device_id);
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS idx_car_locations_car_id_recorded_at ON car_locations(car_id, recorded_at);
+DROP INDEX IF EXISTS idx_car_locations_car_recorded_unique;
+
+ALTER TABLE car_locations DROP COLUMN IF EXISTS battery;
+ALTER TABLE car_locations DROP COLUMN IF EXISTS accuracy;
+ALTER TABLE car_locations DROP COLUMN IF EXISTS heading;
+ALTER TABLE car_locations DROP COLUMN IF EXISTS speed;
@@ -0,0 +1,16 @@
+-- Data tambahan dari perangkat GPS
+ALTER TABLE car_locations ADD COLUMN speed DECIMAL(6,2);    -- km/jam
+ALTER TABLE car_locations ADD COLUMN heading INT;           -- derajat dari utara
+ALTER TABLE car_locations ADD COLUMN accuracy DECIMAL(8,2); -- meter
+ALTER TABLE car_locations ADD COLUMN battery INT;           -- persen
+
+-- Hapus titik ganda sebelum membuat unique index, simpan yang pertama masuk
+DELETE FROM car_locations a
+USING car_locations b
+WHERE a.car_id = b.car_id
+  AND a.recorded_at = b.recorded_at
+  AND a.id > b.id;
+
+-- Satu titik per mobil per waktu, agar upload ulang dari perangkat tidak dobel
+CREATE UNIQUE INDEX idx_car_locations_car_recorded_unique ON car_locations(car_id, recorded_at);
+DROP INDEX IF EXISTS idx_car_locations_car_id_recorded_at;
@@ -0,0 +1,51 @@
+-- Aturan alert; arti threshold tergantung type:
+-- SPEEDING & UNAUTHORIZED_MOVEMENT = km/jam, STALE_GPS = menit, LONG_TRIP = jam
+CREATE TABLE alert_rules (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL, -- SPEEDING, STALE_GPS, LONG_TRIP, UNAUTHORIZED_MOVEMENT, GEOFENCE_BREACH
+    severity VARCHAR(10) NOT NULL, -- INFO, WARNING, CRITICAL
+    threshold DECIMAL(10,2) NOT NULL DEFAULT 0,
+    car_id BIGINT REFERENCES cars(id) ON DELETE CASCADE, -- Kosong = semua mobil
+    geofence_id BIGINT REFERENCES geofences(id) ON DELETE CASCADE, -- Hanya GEOFENCE_BREACH; kosong = semua geofence
+    transition VARCHAR(10), -- Hanya GEOFENCE_BREACH: ENTER, EXIT, kosong = keduanya
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Alert yang dihasilkan rule
+CREATE TABLE alerts (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT REFERENCES alert_rules(id) ON DELETE SET NULL,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    trip_id BIGINT REFERENCES trip_logs(id) ON DELETE SET NULL,
+    geofence_id BIGINT REFERENCES geofences(id) ON DELETE SET NULL,
+    type VARCHAR(30) NOT NULL,
+    severity VARCHAR(10) NOT NULL,
+    message VARCHAR(255) NOT NULL,
+    value DECIMAL(10,2), -- Nilai yang melanggar, satuan sama dengan threshold
+    lat DECIMAL(10, 8),
+    lng DECIMAL(11, 8),
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN', -- OPEN, ACKNOWLEDGED, RESOLVED
+    triggered_at TIMESTAMP NOT NULL,
+    acknowledged_at TIMESTAMP,
+    acknowledged_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    resolved_by BIGINT REFERENCES users(id) ON DELETE SET NULL, -- Kosong jika diselesaikan otomatis
+    resolution_note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Satu alert yang belum resolved per rule per mobil, agar kondisi yang berulang tidak membanjiri
+CREATE UNIQUE INDEX idx_alerts_rule_car_unresolved ON alerts(rule_id, car_id) WHERE status <> 'RESOLVED';
+CREATE INDEX idx_alerts_car_triggered_at ON alerts(car_id, triggered_at DESC);
+CREATE INDEX idx_alerts_triggered_at ON alerts(triggered_at DESC);
+
+-- Rule bawaan
+INSERT INTO alert_rules (name, type, severity, threshold) VALUES
+('Speeding above 100 km/h', 'SPEEDING', 'WARNING', 100),
+('No GPS signal for 15 minutes', 'STALE_GPS', 'WARNING', 15),
+('Trip longer than 12 hours', 'LONG_TRIP', 'INFO', 12),
+('Moving while not checked out', 'UNAUTHORIZED_MOVEMENT', 'CRITICAL', 10);
@@ -0,0 +1,40 @@
+-- Preferensi notifikasi per user
+CREATE TABLE notification_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL, -- EMAIL, WEBHOOK, TELEGRAM
+    target VARCHAR(500) NOT NULL, -- Alamat email, URL webhook, atau chat ID Telegram
+    event_types VARCHAR(255) NOT NULL, -- Dipisah koma: ALERT, TRIP_CHECKOUT, TRIP_CHECKIN, DOCUMENT_EXPIRY
+    min_severity VARCHAR(10), -- Hanya untuk ALERT; kosong = semua severity
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notification_subscriptions_user_id ON notification_subscriptions(user_id);
+
+-- Outbox notifikasi: ditulis dalam transaksi yang sama dengan perubahan, dikirim oleh dispatcher
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT REFERENCES notification_subscriptions(id) ON DELETE SET NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    target VARCHAR(500) NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    dedupe_key VARCHAR(100),
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, SENT, FAILED
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Dipakai dispatcher untuk mengambil notifikasi yang jatuh tempo
+CREATE INDEX idx_notifications_pending ON notifications(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+-- Notifikasi berulang (mis. dokumen kedaluwarsa) hanya dikirim sekali per subscription
+CREATE UNIQUE INDEX idx_notifications_subscription_dedupe ON notifications(subscription_id, dedupe_key) WHERE dedupe_key IS NOT NULL;
@@ -0,0 +1,36 @@
+-- Webhook keluar untuk sistem eksternal (ERP, HR)
+CREATE TABLE webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    secret VARCHAR(100) NOT NULL, -- Kunci HMAC-SHA256 untuk header X-Fleet-Signature
+    event_types VARCHAR(255) NOT NULL, -- Dipisah koma: trip.checked_out, trip.checked_in, maintenance.created, car.status_changed
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Antrian sekaligus log pengiriman webhook
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id VARCHAR(40) NOT NULL, -- Sama untuk semua webhook dan pengiriman ulang dari event yang sama
+    event_type VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL, -- Body JSON persis seperti yang ditandatangani
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, SUCCEEDED, FAILED
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    response_status INT,
+    response_body TEXT, -- Dipotong 1 KB
+    last_error TEXT,
+    duration_ms BIGINT,
+    delivered_at TIMESTAMP,
+    redelivery_of BIGINT REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Dipakai dispatcher untuk mengambil pengiriman yang jatuh tempo
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_webhook_deliveries_webhook_created ON webhook_deliveries(webhook_id, created_at DESC);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_maintenances_car_in_progress;
+DROP INDEX IF EXISTS idx_maintenances_status;
+
+ALTER TABLE maintenances
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,25 @@
+-- Maintenance menjadi work order: SCHEDULED -> IN_PROGRESS -> COMPLETED / CANCELLED
+ALTER TABLE maintenances
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED',
+    ADD COLUMN started_at TIMESTAMP,
+    ADD COLUMN completed_at TIMESTAMP,
+    ADD COLUMN cancelled_at TIMESTAMP;
+
+-- Data lama adalah catatan servis yang sudah terjadi
+UPDATE maintenances
+SET status = 'COMPLETED', started_at = service_date, completed_at = service_date;
+
+-- Mobil yang masih berstatus MAINTENANCE: maintenance terakhirnya dianggap masih dikerjakan
+UPDATE maintenances
+SET status = 'IN_PROGRESS', completed_at = NULL
+WHERE id IN (
+    SELECT DISTINCT ON (m.car_id) m.id
+    FROM maintenances m
+    JOIN cars c ON c.id = m.car_id
+    WHERE c.status = 'MAINTENANCE'
+    ORDER BY m.car_id, m.service_date DESC, m.id DESC
+);
+
+CREATE INDEX idx_maintenances_status ON maintenances(status);
+-- Dipakai saat checkout dan saat mengembalikan mobil ke AVAILABLE
+CREATE INDEX idx_maintenances_car_in_progress ON maintenances(car_id) WHERE status = 'IN_PROGRESS';
@@ -0,0 +1,7 @@
+ALTER TABLE maintenances
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS other_cost,
+    DROP COLUMN IF EXISTS labour_cost,
+    DROP COLUMN IF EXISTS parts_cost;
+
+DROP TABLE IF EXISTS maintenance_items;
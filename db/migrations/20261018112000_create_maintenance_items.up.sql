@@ -0,0 +1,23 @@
+-- Rincian biaya work order: suku cadang, jasa, dan lainnya
+CREATE TABLE maintenance_items (
+    id BIGSERIAL PRIMARY KEY,
+    maintenance_id BIGINT NOT NULL REFERENCES maintenances(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL, -- PART, LABOUR, OTHER
+    part_number VARCHAR(50),
+    description VARCHAR(255) NOT NULL,
+    quantity DECIMAL(12,3) NOT NULL,
+    unit_price DECIMAL(15,2) NOT NULL,
+    tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0, -- Persen, mis. 11 untuk PPN 11%
+    subtotal DECIMAL(15,2) NOT NULL, -- quantity x unit_price
+    tax_amount DECIMAL(15,2) NOT NULL,
+    total DECIMAL(15,2) NOT NULL -- subtotal + tax_amount
+);
+
+CREATE INDEX idx_maintenance_items_maintenance_id ON maintenance_items(maintenance_id);
+
+-- Ringkasan per work order; cost tetap total keseluruhan termasuk pajak
+ALTER TABLE maintenances
+    ADD COLUMN parts_cost DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN labour_cost DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN other_cost DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
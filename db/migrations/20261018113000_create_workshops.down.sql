@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_maintenances_workshop_id;
+
+ALTER TABLE maintenances
+    DROP COLUMN IF EXISTS workshop_id;
+
+DROP TABLE IF EXISTS workshops;
@@ -0,0 +1,41 @@
+-- Master data bengkel/vendor pengganti workshop_name teks bebas
+CREATE TABLE workshops (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    address TEXT,
+    lat DOUBLE PRECISION,
+    lng DOUBLE PRECISION,
+    contact_name VARCHAR(100),
+    phone VARCHAR(20),
+    email VARCHAR(100),
+    specialties VARCHAR(255), -- Dipisah koma, huruf kecil: engine,body,tires
+    rating DECIMAL(2,1) CHECK (rating BETWEEN 0 AND 5),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Nama unik tanpa membedakan huruf besar/kecil
+CREATE UNIQUE INDEX idx_workshops_name_lower ON workshops(LOWER(name));
+
+-- Migrasi nama teks bebas: spasi dirapikan, varian huruf besar/kecil digabung
+-- dengan ejaan yang paling sering dipakai
+INSERT INTO workshops (name)
+SELECT DISTINCT ON (LOWER(name)) name
+FROM (
+    SELECT regexp_replace(btrim(workshop_name), '\s+', ' ', 'g') AS name, COUNT(*) AS uses
+    FROM maintenances
+    WHERE workshop_name IS NOT NULL AND btrim(workshop_name) <> ''
+    GROUP BY 1
+) names
+ORDER BY LOWER(name), uses DESC, name;
+
+ALTER TABLE maintenances
+    ADD COLUMN workshop_id BIGINT REFERENCES workshops(id) ON DELETE SET NULL;
+
+UPDATE maintenances m
+SET workshop_id = w.id,
+    workshop_name = w.name
+FROM workshops w
+WHERE LOWER(w.name) = LOWER(regexp_replace(btrim(m.workshop_name), '\s+', ' ', 'g'));
+
+CREATE INDEX idx_maintenances_workshop_id ON maintenances(workshop_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_maintenance_items_part_id;
+
+ALTER TABLE maintenance_items
+    DROP COLUMN IF EXISTS part_id;
+
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS parts;
@@ -0,0 +1,40 @@
+-- Katalog suku cadang di gudang bengkel sendiri (ban, oli, filter)
+CREATE TABLE parts (
+    id BIGSERIAL PRIMARY KEY,
+    part_number VARCHAR(50) NOT NULL UNIQUE, -- Huruf besar
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(50), -- Mis. tyre, oil, filter
+    unit VARCHAR(20) NOT NULL DEFAULT 'pcs', -- pcs, liter, set
+    unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0, -- Harga beli terakhir
+    stock DECIMAL(12,3) NOT NULL DEFAULT 0 CHECK (stock >= 0), -- Hanya berubah lewat stock_movements
+    reorder_level DECIMAL(12,3) NOT NULL DEFAULT 0, -- 0 = tidak pernah dianggap stok menipis
+    reorder_quantity DECIMAL(12,3) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_parts_category ON parts(category);
+
+-- Riwayat mutasi stok; quantity positif = masuk, negatif = keluar
+CREATE TABLE stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    part_id BIGINT NOT NULL REFERENCES parts(id) ON DELETE RESTRICT,
+    type VARCHAR(20) NOT NULL, -- RECEIVE, ISSUE, RETURN, ADJUST
+    quantity DECIMAL(12,3) NOT NULL,
+    stock_after DECIMAL(12,3) NOT NULL,
+    unit_cost DECIMAL(15,2),
+    maintenance_id BIGINT REFERENCES maintenances(id) ON DELETE SET NULL, -- Work order untuk ISSUE dan RETURN
+    reference VARCHAR(100), -- Mis. nomor faktur pemasok
+    note TEXT,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_part_id ON stock_movements(part_id, created_at);
+CREATE INDEX idx_stock_movements_maintenance_id ON stock_movements(maintenance_id);
+
+-- Item work order yang mengambil suku cadang dari stok
+ALTER TABLE maintenance_items
+    ADD COLUMN part_id BIGINT REFERENCES parts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_maintenance_items_part_id ON maintenance_items(part_id);
@@ -0,0 +1,4 @@
+ALTER TABLE odometer_readings
+    DROP COLUMN IF EXISTS fuel_log_id;
+
+DROP TABLE IF EXISTS fuel_logs;
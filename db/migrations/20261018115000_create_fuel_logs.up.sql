@@ -0,0 +1,36 @@
+-- Catatan pengisian BBM per mobil
+CREATE TABLE fuel_logs (
+    id BIGSERIAL PRIMARY KEY,
+    car_id BIGINT NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+    driver_id BIGINT REFERENCES drivers(id) ON DELETE SET NULL,
+    trip_id BIGINT REFERENCES trip_logs(id) ON DELETE SET NULL,
+    filled_at TIMESTAMP NOT NULL,
+    liters DECIMAL(10,3) NOT NULL CHECK (liters > 0),
+    price_per_liter DECIMAL(15,2) NOT NULL,
+    total_cost DECIMAL(15,2) NOT NULL, -- liters x price_per_liter
+    odometer_km INT NOT NULL,
+    station VARCHAR(100),
+    full_tank BOOLEAN NOT NULL DEFAULT FALSE,
+    receipt_key VARCHAR(255), -- Lokasi file struk di penyimpanan
+    receipt_name VARCHAR(255),
+    receipt_content_type VARCHAR(100),
+    notes TEXT,
+    -- Dihitung ulang setiap kali catatan mobil berubah; hanya terisi pada isi penuh
+    distance_km INT, -- Jarak sejak isi penuh sebelumnya
+    interval_liters DECIMAL(10,3), -- Liter sejak isi penuh sebelumnya, termasuk isi sebagian
+    km_per_liter DECIMAL(8,2),
+    baseline_km_per_liter DECIMAL(8,2), -- Median efisiensi mobil sebelum pengisian ini
+    flagged BOOLEAN NOT NULL DEFAULT FALSE, -- Efisiensi menyimpang jauh, mis. dugaan pencurian BBM
+    flag_reason VARCHAR(255),
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_fuel_logs_car_id ON fuel_logs(car_id, filled_at);
+CREATE INDEX idx_fuel_logs_driver_id ON fuel_logs(driver_id);
+CREATE INDEX idx_fuel_logs_flagged ON fuel_logs(flagged) WHERE flagged;
+
+-- Pembacaan odometer dari pengisian BBM
+ALTER TABLE odometer_readings
+    ADD COLUMN fuel_log_id BIGINT REFERENCES fuel_logs(id) ON DELETE SET NULL;
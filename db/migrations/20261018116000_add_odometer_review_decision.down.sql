@@ -0,0 +1,2 @@
+ALTER TABLE odometer_readings
+    DROP COLUMN IF EXISTS accepted;
@@ -0,0 +1,5 @@
+-- Keputusan review pembacaan yang ditandai: TRUE = km dipakai, FALSE = dibuang, kosong = belum direview
+ALTER TABLE odometer_readings ADD COLUMN accepted BOOLEAN;
+
+-- Pembacaan lama yang sudah direview sudah terlanjur mengubah current_km
+UPDATE odometer_readings SET accepted = TRUE WHERE reviewed_at IS NOT NULL;
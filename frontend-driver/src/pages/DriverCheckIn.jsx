@@ -17,7 +17,7 @@ import {
 
 export default function DriverCheckIn() {
     const navigate = useNavigate()
-    const { driver, setActiveTrip } = useDriverStore()
+    const { setActiveTrip } = useDriverStore()
     const fileInputRef = useRef(null)
 
     const [cars, setCars] = useState([])
@@ -88,10 +88,6 @@ export default function DriverCheckIn() {
             setError('Masukkan KM awal')
             return
         }
-        if (!formData.fuelOk || !formData.tiresOk || !formData.stnkOk) {
-            setError('Lengkapi semua checklist')
-            return
-        }
         if (!formData.photo) {
             setError('Foto kondisi mobil wajib diambil')
             return
@@ -103,14 +99,26 @@ export default function DriverCheckIn() {
         try {
             const checkoutData = {
                 car_id: parseInt(formData.carId),
-                driver_id: driver.driverId || driver.id,
                 start_km: parseInt(formData.startKm),
-                notes: `Checklist: Fuel OK, Tires OK, STNK OK. Photo attached.`
+                inspection: {
+                    items: [
+                        { code: 'FUEL', ok: formData.fuelOk },
+                        { code: 'TIRES', ok: formData.tiresOk },
+                        { code: 'STNK', ok: formData.stnkOk },
+                    ]
+                }
             }
 
             const response = await driverAPI.checkout(checkoutData)
             const trip = response.data.data
 
+            // Trip sudah berjalan, foto gagal upload tidak membatalkan checkout
+            try {
+                await driverAPI.uploadTripPhotos('CHECKOUT', [formData.photo])
+            } catch (uploadErr) {
+                console.error('Failed to upload inspection photo:', uploadErr)
+            }
+
             // Find the selected car
             const selectedCar = cars.find(c => c.id === parseInt(formData.carId))
             setActiveTrip(trip, selectedCar)
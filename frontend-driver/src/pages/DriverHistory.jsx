@@ -25,7 +25,7 @@ export default function DriverHistory() {
         if (!driver) return
 
         try {
-            const response = await driverAPI.getHistory(driver.driverId || driver.id, {
+            const response = await driverAPI.getHistory({
                 page: pageNum,
                 limit: 10
             })
@@ -25,9 +25,9 @@ export default function DriverHome() {
             if (!driver) return
 
             try {
-                const response = await driverAPI.getActiveTrip(driver.driverId || driver.id)
-                if (response.data.data && response.data.data.length > 0) {
-                    const trip = response.data.data[0]
+                const response = await driverAPI.getActiveTrip()
+                const trip = response.data.data
+                if (trip) {
                     setActiveTrip(trip, trip.car)
                 }
             } catch (err) {
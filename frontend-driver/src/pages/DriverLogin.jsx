@@ -19,19 +19,27 @@ export default function DriverLogin() {
 
         try {
             const response = await driverAPI.login(formData)
-            const { token, user } = response.data.data
+            const { token, user, driver } = response.data.data
+
+            if (!driver) {
+                setError('Akun ini belum terhubung dengan data supir')
+                return
+            }
 
             setAuth(token, {
                 id: user.id,
-                name: user.username,
-                driverId: user.id
+                name: driver.name,
+                username: user.username,
+                driverId: driver.id,
+                phone: driver.phone_number,
+                licenseNumber: driver.license_number
             })
 
             // Check if driver has an active trip
             try {
-                const tripResponse = await driverAPI.getActiveTrip(user.id)
-                if (tripResponse.data.data && tripResponse.data.data.length > 0) {
-                    const activeTrip = tripResponse.data.data[0]
+                const tripResponse = await driverAPI.getActiveTrip()
+                const activeTrip = tripResponse.data.data
+                if (activeTrip) {
                     setActiveTrip(activeTrip, activeTrip.car)
                 }
             } catch (tripErr) {
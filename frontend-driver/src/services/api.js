@@ -40,24 +40,35 @@ export const driverAPI = {
     // Get available cars for checkout
     getAvailableCars: () => api.get('/cars', { params: { status: 'AVAILABLE' } }),
 
-    // Get driver's active trip (ongoing trip)
-    getActiveTrip: (driverId) => api.get('/trips', {
-        params: { driver_id: driverId, active: true, limit: 1 }
-    }),
+    // Get logged-in driver's profile
+    getProfile: () => api.get('/me'),
+
+    // Get driver's active trip (ongoing trip), data is null when there is none
+    getActiveTrip: () => api.get('/me/trip'),
 
     // Get driver's trip history
-    getHistory: (driverId, params = {}) => api.get('/trips', {
-        params: { ...params, driver_id: driverId }
-    }),
+    getHistory: (params = {}) => api.get('/me/trips', { params }),
+
+    // Checkout (start trip) - driver is taken from the token
+    checkout: (data) => api.post('/me/checkout', data),
 
-    // Checkout (start trip)
-    checkout: (data) => api.post('/trips/checkout', data),
+    // Checkin (end the active trip)
+    checkin: (data) => api.post('/me/checkin', data),
 
-    // Checkin (end trip)
-    checkin: (data) => api.post('/trips/checkin', data),
+    // Trip requests for cars that need manager approval
+    getTripRequests: (params = {}) => api.get('/me/trip-requests', { params }),
+    createTripRequest: (data) => api.post('/me/trip-requests', data),
+    cancelTripRequest: (id, reason) => api.post(`/me/trip-requests/${id}/cancel`, { reason }),
 
-    // Get driver info by ID
-    getDriver: (id) => api.get(`/drivers/${id}`),
+    // Inspection photos for the active trip (stage: CHECKOUT or CHECKIN)
+    uploadTripPhotos: (stage, files) => {
+        const form = new FormData()
+        form.append('stage', stage)
+        files.forEach(file => form.append('photos', file))
+        return api.post('/me/trip/photos', form, {
+            headers: { 'Content-Type': 'multipart/form-data' },
+        })
+    },
 }
 
 export default api
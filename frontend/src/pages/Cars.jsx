@@ -41,7 +41,7 @@ export default function Cars() {
     const [modalOpen, setModalOpen] = useState(false)
     const [editingCar, setEditingCar] = useState(null)
     const [formData, setFormData] = useState({
-        license_plate: '', brand: '', model: '', year: new Date().getFullYear(), status: 'AVAILABLE'
+        license_plate: '', brand: '', model: '', year: new Date().getFullYear(), status: 'AVAILABLE', requires_approval: false
     })
     const [saving, setSaving] = useState(false)
     const [pagination, setPagination] = useState({ page: 1, total: 0 })
@@ -78,7 +78,7 @@ export default function Cars() {
             }
             setModalOpen(false)
             setEditingCar(null)
-            setFormData({ license_plate: '', brand: '', model: '', year: new Date().getFullYear(), status: 'AVAILABLE' })
+            setFormData({ license_plate: '', brand: '', model: '', year: new Date().getFullYear(), status: 'AVAILABLE', requires_approval: false })
             fetchCars()
         } catch (error) {
             alert(error.response?.data?.error || 'Gagal menyimpan data')
@@ -95,6 +95,7 @@ export default function Cars() {
             model: car.model,
             year: car.year,
             status: car.status,
+            requires_approval: car.requires_approval || false,
         })
         setModalOpen(true)
     }
@@ -111,7 +112,7 @@ export default function Cars() {
 
     const openAddModal = () => {
         setEditingCar(null)
-        setFormData({ license_plate: '', brand: '', model: '', year: new Date().getFullYear(), status: 'AVAILABLE' })
+        setFormData({ license_plate: '', brand: '', model: '', year: new Date().getFullYear(), status: 'AVAILABLE', requires_approval: false })
         setModalOpen(true)
     }
 
@@ -280,6 +281,14 @@ export default function Cars() {
                             </select>
                         </div>
                     </div>
+                    <label className="flex items-center gap-2 text-gray-400 text-sm">
+                        <input
+                            type="checkbox"
+                            checked={formData.requires_approval}
+                            onChange={(e) => setFormData({ ...formData, requires_approval: e.target.checked })}
+                        />
+                        Checkout wajib persetujuan manager
+                    </label>
                     <button
                         type="submit"
                         disabled={saving}
@@ -1,6 +1,6 @@
 import { useState, useEffect } from 'react'
 import { MapContainer, TileLayer, Marker, Popup } from 'react-leaflet'
-import { carsAPI } from '../services/api'
+import { carsAPI, streamAPI } from '../services/api'
 import { Car, RefreshCw, MapPin, Search } from 'lucide-react' // Tambah icon Search
 import L from 'leaflet'
 import 'leaflet/dist/leaflet.css'
@@ -48,12 +48,49 @@ export default function LiveMonitor() {
     const [searchQuery, setSearchQuery] = useState('') // State untuk pencarian
 
     useEffect(() => {
+        // Snapshot awal, lalu update realtime lewat stream
         fetchCars()
-        // Refresh every 30 seconds
-        const interval = setInterval(fetchCars, 1000)
-        return () => clearInterval(interval)
+
+        let stream = null
+        let retryTimer = null
+        let closed = false
+
+        const connect = () => {
+            stream = streamAPI.subscribeLocations(applyEvent)
+            stream.done
+                .catch((error) => console.error('Location stream error:', error))
+                .finally(() => {
+                    if (closed) return
+                    // Reconnect kalau koneksi putus
+                    retryTimer = setTimeout(() => {
+                        fetchCars()
+                        connect()
+                    }, 5000)
+                })
+        }
+        connect()
+
+        return () => {
+            closed = true
+            clearTimeout(retryTimer)
+            if (stream) stream.close()
+        }
     }, [])
 
+    const applyEvent = (event) => {
+        setCars((prev) => prev.map((car) => {
+            if (car.id !== event.car_id) return car
+            const updated = { ...car, status: event.status }
+            if (event.type === 'location') {
+                updated.last_lat = event.lat
+                updated.last_lng = event.lng
+                updated.last_update_loc = event.timestamp
+            }
+            return updated
+        }))
+        setLastUpdate(new Date())
+    }
+
     const fetchCars = async () => {
         try {
             const response = await carsAPI.getAll({ limit: 100 })
@@ -69,7 +69,15 @@ export default function DriverCheckOut() {
             const checkinData = {
                 trip_id: activeTrip.id,
                 end_km: parseInt(formData.endKm),
-                notes: formData.notes || 'Perjalanan selesai tanpa kendala'
+                notes: formData.notes || 'Perjalanan selesai tanpa kendala',
+                inspection: {
+                    // Laporan kendala diteruskan sebagai catatan kerusakan agar dibuatkan tiket bengkel
+                    damage_notes: location.state?.reportIssue ? formData.notes : '',
+                    items: [
+                        { code: 'CAR_LOCKED', ok: formData.carLocked },
+                        { code: 'STNK_RETURNED', ok: formData.stnkReturned },
+                    ]
+                }
             }
 
             await driverAPI.checkin(checkinData)
@@ -35,6 +35,7 @@ api.interceptors.response.use(
 // Auth API
 export const authAPI = {
     login: (data) => api.post('/auth/login', data),
+    // Admin only; role defaults to driver
     register: (data) => api.post('/auth/register', data),
 }
 
@@ -51,6 +52,8 @@ export const carsAPI = {
     update: (id, data) => api.put(`/cars/${id}`, data),
     delete: (id) => api.delete(`/cars/${id}`),
     updateLocation: (id, data) => api.put(`/cars/${id}/location`, data),
+    getLocations: (id, params) => api.get(`/cars/${id}/locations`, { params }),
+    uploadLocations: (id, points) => api.post(`/cars/${id}/locations/batch`, { points }),
 }
 
 // Drivers API
@@ -58,6 +61,7 @@ export const driversAPI = {
     getAll: (params) => api.get('/drivers', { params }),
     getById: (id) => api.get(`/drivers/${id}`),
     create: (data) => api.post('/drivers', data),
+    // data.user_id links a login account; omit it to keep the current link, unlink_user: true removes it
     update: (id, data) => api.put(`/drivers/${id}`, data),
     delete: (id) => api.delete(`/drivers/${id}`),
 }
@@ -68,15 +72,231 @@ export const tripsAPI = {
     getById: (id) => api.get(`/trips/${id}`),
     checkout: (data) => api.post('/trips/checkout', data),
     checkin: (data) => api.post('/trips/checkin', data),
+    // GeoJSON Feature (LineString) of the GPS track
+    getRoute: (id) => api.get(`/trips/${id}/route`),
+    getInspections: (id) => api.get(`/trips/${id}/inspections`),
+    uploadPhotos: (id, stage, files) => {
+        const form = new FormData()
+        form.append('stage', stage)
+        files.forEach(file => form.append('photos', file))
+        return api.post(`/trips/${id}/photos`, form, {
+            headers: { 'Content-Type': 'multipart/form-data' },
+        })
+    },
 }
 
 // Maintenance API
 export const maintenanceAPI = {
+    // params: car_id, workshop_id, status (SCHEDULED/IN_PROGRESS/COMPLETED/CANCELLED), page, limit
     getAll: (params) => api.get('/maintenances', { params }),
     getById: (id) => api.get(`/maintenances/${id}`),
+    // data.items (optional): [{ type: PART/LABOUR/OTHER, part_id, part_number, description, quantity, unit_price, tax_rate }]
+    // Items with part_id are issued from stock; plan_id counts the service towards that maintenance plan
     create: (data) => api.post('/maintenances', data),
     update: (id, data) => api.put(`/maintenances/${id}`, data),
     delete: (id) => api.delete(`/maintenances/${id}`),
+    start: (id) => api.post(`/maintenances/${id}/start`),
+    // data (optional): cost, odometer_km
+    complete: (id, data) => api.post(`/maintenances/${id}/complete`, data),
+    cancel: (id) => api.post(`/maintenances/${id}/cancel`),
+}
+
+// Workshops (vendors) API
+export const workshopsAPI = {
+    // params: search, specialty, page, limit
+    getAll: (params) => api.get('/workshops', { params }),
+    getById: (id) => api.get(`/workshops/${id}`),
+    create: (data) => api.post('/workshops', data),
+    update: (id, data) => api.put(`/workshops/${id}`, data),
+    delete: (id) => api.delete(`/workshops/${id}`),
+    // params: from, to (completion date)
+    getReport: (params) => api.get('/workshops/report', { params }),
+}
+
+// Spare parts inventory API
+export const partsAPI = {
+    // params: search, category, low_stock, page, limit
+    getAll: (params) => api.get('/parts', { params }),
+    getById: (id) => api.get(`/parts/${id}`),
+    create: (data) => api.post('/parts', data),
+    update: (id, data) => api.put(`/parts/${id}`, data),
+    delete: (id) => api.delete(`/parts/${id}`),
+    // data: quantity, unit_cost (optional), reference, note
+    receive: (id, data) => api.post(`/parts/${id}/receive`, data),
+    // data: quantity (negative to write off), note
+    adjust: (id, data) => api.post(`/parts/${id}/adjust`, data),
+    // params: part_id, maintenance_id, type (RECEIVE/ISSUE/RETURN/ADJUST), page, limit
+    getMovements: (params) => api.get('/parts/movements', { params }),
+    getLowStock: () => api.get('/parts/low-stock'),
+}
+
+// Fuel logs API
+const fuelLogForm = (data, receipt) => {
+    const form = new FormData()
+    Object.entries(data).forEach(([key, value]) => {
+        if (value !== undefined && value !== null) form.append(key, value)
+    })
+    form.append('receipt', receipt)
+    return form
+}
+
+export const fuelLogsAPI = {
+    // params: car_id, driver_id, trip_id, flagged, from, to, page, limit
+    getAll: (params) => api.get('/fuel-logs', { params }),
+    getById: (id) => api.get(`/fuel-logs/${id}`),
+    // data: car_id, driver_id, trip_id, filled_at, liters, price_per_liter, odometer_km,
+    // station, full_tank, notes; receipt (optional): photo or PDF of the receipt
+    create: (data, receipt) => receipt
+        ? api.post('/fuel-logs', fuelLogForm(data, receipt), { headers: { 'Content-Type': 'multipart/form-data' } })
+        : api.post('/fuel-logs', data),
+    // car_id and odometer_km must be sent unchanged
+    update: (id, data, receipt) => receipt
+        ? api.put(`/fuel-logs/${id}`, fuelLogForm(data, receipt), { headers: { 'Content-Type': 'multipart/form-data' } })
+        : api.put(`/fuel-logs/${id}`, data),
+    delete: (id) => api.delete(`/fuel-logs/${id}`),
+    getReceipt: (id) => api.get(`/fuel-logs/${id}/receipt`, { responseType: 'blob' }),
+    // params: car_id, from, to
+    getEfficiency: (params) => api.get('/fuel-logs/efficiency', { params }),
+}
+
+// Trip requests (approval workflow) API
+export const tripRequestsAPI = {
+    getAll: (params) => api.get('/trip-requests', { params }),
+    getById: (id) => api.get(`/trip-requests/${id}`),
+    create: (data) => api.post('/trip-requests', data),
+    approve: (id, reason) => api.post(`/trip-requests/${id}/approve`, { reason }),
+    reject: (id, reason) => api.post(`/trip-requests/${id}/reject`, { reason }),
+    cancel: (id, reason) => api.post(`/trip-requests/${id}/cancel`, { reason }),
+}
+
+// Reservations API
+export const reservationsAPI = {
+    // Calendar query: car_id, driver_id, status, from, to
+    getAll: (params) => api.get('/reservations', { params }),
+    getById: (id) => api.get(`/reservations/${id}`),
+    create: (data) => api.post('/reservations', data),
+    update: (id, data) => api.put(`/reservations/${id}`, data),
+    cancel: (id, reason) => api.post(`/reservations/${id}/cancel`, { reason }),
+}
+
+// Odometer API
+export const odometerAPI = {
+    // params: car_id, source, flagged, reviewed, page, limit
+    getAll: (params) => api.get('/odometer-readings', { params }),
+    getByCar: (carId, params) => api.get(`/cars/${carId}/odometer`, { params }),
+    record: (carId, data) => api.post(`/cars/${carId}/odometer`, data),
+    // accept: true applies the flagged km to the car, false discards it
+    review: (id, accept, note) => api.post(`/odometer-readings/${id}/review`, { accept, note }),
+}
+
+// Devices (GPS trackers) API
+export const devicesAPI = {
+    // params: car_id, protocol, active, search, page, limit
+    getAll: (params) => api.get('/devices', { params }),
+    getById: (id) => api.get(`/devices/${id}`),
+    create: (data) => api.post('/devices', data),
+    update: (id, data) => api.put(`/devices/${id}`, data),
+    delete: (id) => api.delete(`/devices/${id}`),
+    getKeys: (id) => api.get(`/devices/${id}/keys`),
+    // The plaintext key is only returned by this call
+    createKey: (id, name) => api.post(`/devices/${id}/keys`, { name }),
+    revokeKey: (id, keyId) => api.post(`/devices/${id}/keys/${keyId}/revoke`),
+}
+
+// Alerts API
+export const alertsAPI = {
+    // params: car_id, type, severity, status, from, to, page, limit
+    getAll: (params) => api.get('/alerts', { params }),
+    getById: (id) => api.get(`/alerts/${id}`),
+    acknowledge: (id) => api.post(`/alerts/${id}/acknowledge`),
+    resolve: (id, note) => api.post(`/alerts/${id}/resolve`, { note }),
+    getRules: (params) => api.get('/alert-rules', { params }),
+    getRule: (id) => api.get(`/alert-rules/${id}`),
+    createRule: (data) => api.post('/alert-rules', data),
+    updateRule: (id, data) => api.put(`/alert-rules/${id}`, data),
+    deleteRule: (id) => api.delete(`/alert-rules/${id}`),
+}
+
+// Notifications API (current user)
+export const notificationsAPI = {
+    // params: event_type, status, page, limit
+    getAll: (params) => api.get('/notifications', { params }),
+    getSubscriptions: () => api.get('/notification-subscriptions'),
+    createSubscription: (data) => api.post('/notification-subscriptions', data),
+    updateSubscription: (id, data) => api.put(`/notification-subscriptions/${id}`, data),
+    deleteSubscription: (id) => api.delete(`/notification-subscriptions/${id}`),
+}
+
+// Outgoing webhooks API (admin)
+export const webhooksAPI = {
+    getAll: () => api.get('/webhooks'),
+    getById: (id) => api.get(`/webhooks/${id}`),
+    // The signing secret is only returned by create
+    create: (data) => api.post('/webhooks', data),
+    update: (id, data) => api.put(`/webhooks/${id}`, data),
+    delete: (id) => api.delete(`/webhooks/${id}`),
+    // params: event_type, status (PENDING/SUCCEEDED/FAILED), page, limit
+    getDeliveries: (id, params) => api.get(`/webhooks/${id}/deliveries`, { params }),
+    redeliver: (id, deliveryId) => api.post(`/webhooks/${id}/deliveries/${deliveryId}/redeliver`),
+}
+
+// Geofences API
+export const geofencesAPI = {
+    getAll: (params) => api.get('/geofences', { params }),
+    getById: (id) => api.get(`/geofences/${id}`),
+    create: (data) => api.post('/geofences', data),
+    update: (id, data) => api.put(`/geofences/${id}`, data),
+    delete: (id) => api.delete(`/geofences/${id}`),
+    // params: car_id, geofence_id, type (ENTER/EXIT), from, to, page, limit
+    getEvents: (params) => api.get('/geofences/events', { params }),
+}
+
+// Stream API (Server-Sent Events over fetch so the Authorization header can be sent)
+export const streamAPI = {
+    subscribeLocations: (onEvent, params = {}) => {
+        const controller = new AbortController()
+        const query = new URLSearchParams(params).toString()
+        const token = useAuthStore.getState().token
+
+        const run = async () => {
+            const response = await fetch(`/api/stream/locations${query ? `?${query}` : ''}`, {
+                headers: { Authorization: `Bearer ${token}` },
+                signal: controller.signal,
+            })
+            if (!response.ok || !response.body) {
+                throw new Error(`Stream failed with status ${response.status}`)
+            }
+
+            const reader = response.body.getReader()
+            const decoder = new TextDecoder()
+            let buffer = ''
+
+            while (true) {
+                const { value, done } = await reader.read()
+                if (done) break
+                buffer += decoder.decode(value, { stream: true })
+
+                // Events are separated by a blank line
+                let boundary
+                while ((boundary = buffer.indexOf('\n\n')) !== -1) {
+                    const chunk = buffer.slice(0, boundary)
+                    buffer = buffer.slice(boundary + 2)
+                    const data = chunk
+                        .split('\n')
+                        .filter((line) => line.startsWith('data: '))
+                        .map((line) => line.slice(6))
+                        .join('\n')
+                    if (data) onEvent(JSON.parse(data))
+                }
+            }
+        }
+
+        const done = run().catch((error) => {
+            if (error.name !== 'AbortError') throw error
+        })
+
+        return { close: () => controller.abort(), done }
+    },
 }
 
 // Driver App API
@@ -103,6 +323,9 @@ export const driverAPI = {
     // Checkin (end trip)
     checkin: (data) => api.post('/trips/checkin', data),
 
+    // Inspection photos for a trip (stage: CHECKOUT or CHECKIN)
+    uploadTripPhotos: (tripId, stage, files) => tripsAPI.uploadPhotos(tripId, stage, files),
+
     // Get driver info by ID
     getDriver: (id) => api.get(`/drivers/${id}`),
 }
@@ -21,6 +21,37 @@ type Config struct {
 	DBName          string
 	JWTSecret       string
 	JWTExpireHours  int
+	UploadDir       string
+	UploadMaxMB     int
+	// Odometer readings outside these bounds (km) are flagged for review
+	OdometerToleranceKm int
+	OdometerMaxJumpKm   int
+	OdometerMaxTripKm   int
+	// Allowed gap between GPS and odometer trip distance, in percent of the odometer distance
+	TripDistanceTolerancePct int
+	// TCP listen addresses for hardware trackers; empty disables the listener
+	TrackerGT06Addr      string
+	TrackerTeltonikaAddr string
+	// How often stale GPS and long trip alerts are checked; 0 disables the scheduler
+	AlertCheckIntervalSeconds int
+	// Notification channels; email and Telegram are disabled until configured
+	SMTPHost         string
+	SMTPPort         int
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	TelegramAPIURL   string
+	TelegramBotToken string
+	// Outbox dispatcher; 0 interval disables delivery
+	NotificationDispatchIntervalSeconds int
+	NotificationMaxAttempts             int
+	// Outgoing webhook dispatcher; 0 interval disables delivery
+	WebhookDispatchIntervalSeconds int
+	WebhookMaxAttempts             int
+	// Full-tank fills whose km/L is this many percent off the car's median over
+	// the last FuelBaselineFills fills are flagged
+	FuelDeviationPct  int
+	FuelBaselineFills int
 }
 
 var AppConfig *Config
@@ -42,6 +73,28 @@ func LoadConfig() *Config {
 	viper.SetDefault("DB_NAME", "fleet_monitor")
 	viper.SetDefault("JWT_SECRET", "secret")
 	viper.SetDefault("JWT_EXPIRE_HOURS", 24)
+	viper.SetDefault("UPLOAD_DIR", "uploads")
+	viper.SetDefault("UPLOAD_MAX_MB", 10)
+	viper.SetDefault("ODOMETER_TOLERANCE_KM", 5)
+	viper.SetDefault("ODOMETER_MAX_JUMP_KM", 500)
+	viper.SetDefault("ODOMETER_MAX_TRIP_KM", 1500)
+	viper.SetDefault("TRIP_DISTANCE_TOLERANCE_PCT", 20)
+	viper.SetDefault("TRACKER_GT06_ADDR", "")
+	viper.SetDefault("TRACKER_TELTONIKA_ADDR", "")
+	viper.SetDefault("ALERT_CHECK_INTERVAL_SECONDS", 60)
+	viper.SetDefault("SMTP_HOST", "")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMTP_USERNAME", "")
+	viper.SetDefault("SMTP_PASSWORD", "")
+	viper.SetDefault("SMTP_FROM", "fleet-monitor@localhost")
+	viper.SetDefault("TELEGRAM_API_URL", "https://api.telegram.org")
+	viper.SetDefault("TELEGRAM_BOT_TOKEN", "")
+	viper.SetDefault("NOTIFICATION_DISPATCH_INTERVAL_SECONDS", 30)
+	viper.SetDefault("NOTIFICATION_MAX_ATTEMPTS", 5)
+	viper.SetDefault("WEBHOOK_DISPATCH_INTERVAL_SECONDS", 10)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("FUEL_DEVIATION_PCT", 25)
+	viper.SetDefault("FUEL_BASELINE_FILLS", 10)
 
 	AppConfig = &Config{
 		AppPort:        viper.GetString("APP_PORT"),
@@ -53,6 +106,36 @@ func LoadConfig() *Config {
 		DBName:         viper.GetString("DB_NAME"),
 		JWTSecret:      viper.GetString("JWT_SECRET"),
 		JWTExpireHours: viper.GetInt("JWT_EXPIRE_HOURS"),
+		UploadDir:      viper.GetString("UPLOAD_DIR"),
+		UploadMaxMB:    viper.GetInt("UPLOAD_MAX_MB"),
+
+		OdometerToleranceKm: viper.GetInt("ODOMETER_TOLERANCE_KM"),
+		OdometerMaxJumpKm:   viper.GetInt("ODOMETER_MAX_JUMP_KM"),
+		OdometerMaxTripKm:   viper.GetInt("ODOMETER_MAX_TRIP_KM"),
+
+		TripDistanceTolerancePct: viper.GetInt("TRIP_DISTANCE_TOLERANCE_PCT"),
+
+		TrackerGT06Addr:      viper.GetString("TRACKER_GT06_ADDR"),
+		TrackerTeltonikaAddr: viper.GetString("TRACKER_TELTONIKA_ADDR"),
+
+		AlertCheckIntervalSeconds: viper.GetInt("ALERT_CHECK_INTERVAL_SECONDS"),
+
+		SMTPHost:         viper.GetString("SMTP_HOST"),
+		SMTPPort:         viper.GetInt("SMTP_PORT"),
+		SMTPUsername:     viper.GetString("SMTP_USERNAME"),
+		SMTPPassword:     viper.GetString("SMTP_PASSWORD"),
+		SMTPFrom:         viper.GetString("SMTP_FROM"),
+		TelegramAPIURL:   viper.GetString("TELEGRAM_API_URL"),
+		TelegramBotToken: viper.GetString("TELEGRAM_BOT_TOKEN"),
+
+		NotificationDispatchIntervalSeconds: viper.GetInt("NOTIFICATION_DISPATCH_INTERVAL_SECONDS"),
+		NotificationMaxAttempts:             viper.GetInt("NOTIFICATION_MAX_ATTEMPTS"),
+
+		WebhookDispatchIntervalSeconds: viper.GetInt("WEBHOOK_DISPATCH_INTERVAL_SECONDS"),
+		WebhookMaxAttempts:             viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+
+		FuelDeviationPct:  viper.GetInt("FUEL_DEVIATION_PCT"),
+		FuelBaselineFills: viper.GetInt("FUEL_BASELINE_FILLS"),
 	}
 
 	return AppConfig
@@ -0,0 +1,255 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AlertHandler struct {
+	alertUsecase usecase.AlertUsecase
+}
+
+func NewAlertHandler(alertUsecase usecase.AlertUsecase) *AlertHandler {
+	return &AlertHandler{alertUsecase: alertUsecase}
+}
+
+// GetAll lists alerts, newest first. Filters: car_id, type, severity, status, from, to.
+func (h *AlertHandler) GetAll(c *fiber.Ctx) error {
+	from, err := helper.ParseTimeParam(c.Query("from"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	to, err := helper.ParseTimeParam(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	params := model.AlertListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 20),
+		CarID:    int64(c.QueryInt("car_id", 0)),
+		Type:     strings.ToUpper(c.Query("type")),
+		Severity: strings.ToUpper(c.Query("severity")),
+		Status:   strings.ToUpper(c.Query("status")),
+		From:     from,
+		To:       to,
+	}
+
+	alerts, total, err := h.alertUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get alerts",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       alerts,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *AlertHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	alert, err := h.alertUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Alert not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Alert found", alert))
+}
+
+func (h *AlertHandler) Acknowledge(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	alert, err := h.alertUsecase.Acknowledge(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to acknowledge alert",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Alert acknowledged", alert))
+}
+
+// Resolve closes an alert. The body with a note is optional.
+func (h *AlertHandler) Resolve(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.AlertResolveRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	alert, err := h.alertUsecase.Resolve(id, req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to resolve alert",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Alert resolved", alert))
+}
+
+func (h *AlertHandler) GetRules(c *fiber.Ctx) error {
+	params := model.AlertRuleListParams{
+		Page:  c.QueryInt("page", 1),
+		Limit: c.QueryInt("limit", 20),
+		Type:  strings.ToUpper(c.Query("type")),
+	}
+	if active := c.Query("active"); active != "" {
+		value := c.QueryBool("active")
+		params.Active = &value
+	}
+
+	rules, total, err := h.alertUsecase.GetRules(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get alert rules",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       rules,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *AlertHandler) GetRuleByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	rule, err := h.alertUsecase.GetRuleByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Alert rule not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Alert rule found", rule))
+}
+
+func (h *AlertHandler) CreateRule(c *fiber.Ctx) error {
+	var req model.AlertRuleRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	rule, err := h.alertUsecase.CreateRule(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create alert rule",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Alert rule created successfully", rule))
+}
+
+func (h *AlertHandler) UpdateRule(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.AlertRuleRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	rule, err := h.alertUsecase.UpdateRule(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update alert rule",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Alert rule updated successfully", rule))
+}
+
+func (h *AlertHandler) DeleteRule(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.alertUsecase.DeleteRule(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete alert rule",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Alert rule deleted successfully", nil))
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/usecase"
 
@@ -17,11 +18,8 @@ func NewAuthHandler(authUsecase usecase.AuthUsecase) *AuthHandler {
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req model.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	result, err := h.authUsecase.Login(req)
@@ -37,11 +35,8 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	var req model.RegisterRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	result, err := h.authUsecase.Register(req)
@@ -0,0 +1,235 @@
+package http
+
+import (
+	"errors"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"fmt"
+	"mime/multipart"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+type CarDocumentHandler struct {
+	documentUsecase usecase.CarDocumentUsecase
+}
+
+func NewCarDocumentHandler(documentUsecase usecase.CarDocumentUsecase) *CarDocumentHandler {
+	return &CarDocumentHandler{documentUsecase: documentUsecase}
+}
+
+func (h *CarDocumentHandler) GetAll(c *fiber.Ctx) error {
+	carID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	documents, err := h.documentUsecase.GetByCarID(carID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Failed to get documents",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Car documents", documents))
+}
+
+func (h *CarDocumentHandler) GetByID(c *fiber.Ctx) error {
+	carID, docID, err := documentIDs(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	document, err := h.documentUsecase.GetByID(carID, docID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Document not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Document found", document))
+}
+
+func (h *CarDocumentHandler) Create(c *fiber.Ctx) error {
+	carID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.CarDocumentRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	file, closeFile, err := formFile(c, "file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid file",
+			err.Error(),
+		))
+	}
+	defer closeFile()
+
+	document, err := h.documentUsecase.Create(carID, req, file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create document",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Document created successfully", document))
+}
+
+func (h *CarDocumentHandler) Update(c *fiber.Ctx) error {
+	carID, docID, err := documentIDs(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.CarDocumentRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	file, closeFile, err := formFile(c, "file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid file",
+			err.Error(),
+		))
+	}
+	defer closeFile()
+
+	document, err := h.documentUsecase.Update(carID, docID, req, file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update document",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Document updated successfully", document))
+}
+
+func (h *CarDocumentHandler) Delete(c *fiber.Ctx) error {
+	carID, docID, err := documentIDs(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.documentUsecase.Delete(carID, docID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete document",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Document deleted successfully", nil))
+}
+
+func (h *CarDocumentHandler) Download(c *fiber.Ctx) error {
+	carID, docID, err := documentIDs(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	file, err := h.documentUsecase.GetFile(carID, docID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"File not found",
+			err.Error(),
+		))
+	}
+
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", file.Filename))
+	// fasthttp closes the stream once it has been sent
+	return c.SendStream(file.Content)
+}
+
+func (h *CarDocumentHandler) GetExpiring(c *fiber.Ctx) error {
+	within, err := helper.ParseDurationParam(c.Query("within"), 30*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid within parameter",
+			err.Error(),
+		))
+	}
+
+	documents, err := h.documentUsecase.GetExpiring(within)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get expiring documents",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Expiring documents", documents))
+}
+
+func documentIDs(c *fiber.Ctx) (int64, int64, error) {
+	carID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	docID, err := strconv.ParseInt(c.Params("docId"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return carID, docID, nil
+}
+
+// formFile opens an optional multipart file. It returns a nil upload when the
+// field is absent; the returned close func is always safe to call.
+func formFile(c *fiber.Ctx, field string) (*model.FileUpload, func(), error) {
+	noop := func() {}
+
+	header, err := c.FormFile(field)
+	if err != nil {
+		if errors.Is(err, fasthttp.ErrMissingFile) || errors.Is(err, fasthttp.ErrNoMultipartForm) {
+			return nil, noop, nil
+		}
+		return nil, noop, err
+	}
+
+	return openUpload(header)
+}
+
+func openUpload(header *multipart.FileHeader) (*model.FileUpload, func(), error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	return &model.FileUpload{
+		Filename:    header.Filename,
+		ContentType: header.Header.Get(fiber.HeaderContentType),
+		Size:        header.Size,
+		Content:     file,
+	}, func() { file.Close() }, nil
+}
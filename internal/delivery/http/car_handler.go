@@ -1,6 +1,7 @@
 package http
 
 import (
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/usecase"
 	"strconv"
@@ -69,11 +70,8 @@ func (h *CarHandler) GetByID(c *fiber.Ctx) error {
 
 func (h *CarHandler) Create(c *fiber.Ctx) error {
 	var req model.CarRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	car, err := h.carUsecase.Create(req)
@@ -97,11 +95,8 @@ func (h *CarHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req model.CarRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	car, err := h.carUsecase.Update(id, req)
@@ -144,11 +139,8 @@ func (h *CarHandler) UpdateLocation(c *fiber.Ctx) error {
 	}
 
 	var req model.UpdateLocationRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.carUsecase.UpdateLocation(id, req); err != nil {
@@ -160,3 +152,72 @@ func (h *CarHandler) UpdateLocation(c *fiber.Ctx) error {
 
 	return c.JSON(model.SuccessResponse("Location updated successfully", nil))
 }
+
+// UpdateLocationsBatch accepts points a device buffered while offline.
+// Points already stored are reported as duplicates so the device can retry
+// the same batch safely.
+func (h *CarHandler) UpdateLocationsBatch(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.BatchLocationRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.carUsecase.RecordLocations(id, req.Points)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to upload locations",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Locations uploaded successfully", result))
+}
+
+func (h *CarHandler) GetLocations(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	from, err := helper.ParseTimeParam(c.Query("from"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	to, err := helper.ParseTimeParam(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	params := model.CarLocationListParams{
+		From:  from,
+		To:    to,
+		Limit: c.QueryInt("limit", 1000),
+	}
+
+	locations, err := h.carUsecase.GetLocations(id, params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get locations",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Location history", locations))
+}
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DeviceHandler struct {
+	deviceUsecase usecase.DeviceUsecase
+}
+
+func NewDeviceHandler(deviceUsecase usecase.DeviceUsecase) *DeviceHandler {
+	return &DeviceHandler{deviceUsecase: deviceUsecase}
+}
+
+func (h *DeviceHandler) GetAll(c *fiber.Ctx) error {
+	params := model.DeviceListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		CarID:    int64(c.QueryInt("car_id", 0)),
+		Protocol: strings.ToUpper(c.Query("protocol")),
+		Search:   c.Query("search"),
+	}
+	if active := c.Query("active"); active != "" {
+		value := c.QueryBool("active")
+		params.Active = &value
+	}
+
+	devices, total, err := h.deviceUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get devices",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       devices,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *DeviceHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	device, err := h.deviceUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Device not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Device found", device))
+}
+
+func (h *DeviceHandler) Create(c *fiber.Ctx) error {
+	var req model.DeviceRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	device, err := h.deviceUsecase.Create(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create device",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Device created successfully", device))
+}
+
+func (h *DeviceHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.DeviceRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	device, err := h.deviceUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update device",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Device updated successfully", device))
+}
+
+func (h *DeviceHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.deviceUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete device",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Device deleted successfully", nil))
+}
+
+func (h *DeviceHandler) GetKeys(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	keys, err := h.deviceUsecase.GetKeys(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Failed to get API keys",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("API keys", keys))
+}
+
+// CreateKey issues a device API key. The key is only shown in this response.
+func (h *DeviceHandler) CreateKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.DeviceAPIKeyRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	key, err := h.deviceUsecase.CreateKey(id, req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create API key",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("API key created, store it now as it will not be shown again", key))
+}
+
+func (h *DeviceHandler) RevokeKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+	keyID, err := strconv.ParseInt(c.Params("keyId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid key ID",
+			"Key ID must be a number",
+		))
+	}
+
+	key, err := h.deviceUsecase.RevokeKey(id, keyID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to revoke API key",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("API key revoked", key))
+}
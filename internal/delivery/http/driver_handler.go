@@ -1,6 +1,8 @@
 package http
 
 import (
+	"errors"
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/usecase"
 	"strconv"
@@ -69,15 +71,16 @@ func (h *DriverHandler) GetByID(c *fiber.Ctx) error {
 
 func (h *DriverHandler) Create(c *fiber.Ctx) error {
 	var req model.DriverRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	driver, err := h.driverUsecase.Create(req)
 	if err != nil {
+		var validationErr *helper.ValidationError
+		if errors.As(err, &validationErr) {
+			return err
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
 			"Failed to create driver",
 			err.Error(),
@@ -97,15 +100,16 @@ func (h *DriverHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req model.DriverRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	driver, err := h.driverUsecase.Update(id, req)
 	if err != nil {
+		var validationErr *helper.ValidationError
+		if errors.As(err, &validationErr) {
+			return err
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
 			"Failed to update driver",
 			err.Error(),
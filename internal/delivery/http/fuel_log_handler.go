@@ -0,0 +1,227 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FuelLogHandler struct {
+	fuelLogUsecase usecase.FuelLogUsecase
+}
+
+func NewFuelLogHandler(fuelLogUsecase usecase.FuelLogUsecase) *FuelLogHandler {
+	return &FuelLogHandler{fuelLogUsecase: fuelLogUsecase}
+}
+
+// GetAll lists fills, newest first. Filters: car_id, driver_id, trip_id,
+// flagged, from, to.
+func (h *FuelLogHandler) GetAll(c *fiber.Ctx) error {
+	params := model.FuelLogListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		CarID:    int64(c.QueryInt("car_id", 0)),
+		DriverID: int64(c.QueryInt("driver_id", 0)),
+		TripID:   int64(c.QueryInt("trip_id", 0)),
+	}
+	if flagged := c.Query("flagged"); flagged != "" {
+		value := c.QueryBool("flagged")
+		params.Flagged = &value
+	}
+
+	var err error
+	if params.From, err = helper.ParseTimeParam(c.Query("from")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	if params.To, err = helper.ParseTimeParam(c.Query("to")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	logs, total, err := h.fuelLogUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get fuel logs",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       logs,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *FuelLogHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	log, err := h.fuelLogUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Fuel log not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Fuel log found", log))
+}
+
+func (h *FuelLogHandler) Create(c *fiber.Ctx) error {
+	var req model.FuelLogRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	receipt, closeReceipt, err := formFile(c, "receipt")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid file",
+			err.Error(),
+		))
+	}
+	defer closeReceipt()
+
+	userID, _ := c.Locals("user_id").(int64)
+	log, err := h.fuelLogUsecase.Create(req, receipt, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create fuel log",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Fuel log created successfully", log))
+}
+
+func (h *FuelLogHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.FuelLogRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	receipt, closeReceipt, err := formFile(c, "receipt")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid file",
+			err.Error(),
+		))
+	}
+	defer closeReceipt()
+
+	log, err := h.fuelLogUsecase.Update(id, req, receipt)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update fuel log",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Fuel log updated successfully", log))
+}
+
+func (h *FuelLogHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.fuelLogUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete fuel log",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Fuel log deleted successfully", nil))
+}
+
+func (h *FuelLogHandler) DownloadReceipt(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	file, err := h.fuelLogUsecase.GetReceipt(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"File not found",
+			err.Error(),
+		))
+	}
+
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", file.Filename))
+	// fasthttp closes the stream once it has been sent
+	return c.SendStream(file.Content)
+}
+
+// GetEfficiency reports fuel use and km/L per car for fills between from and
+// to. Filters: car_id.
+func (h *FuelLogHandler) GetEfficiency(c *fiber.Ctx) error {
+	from, err := helper.ParseTimeParam(c.Query("from"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	to, err := helper.ParseTimeParam(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	report, err := h.fuelLogUsecase.GetEfficiency(model.FuelEfficiencyParams{
+		CarID: int64(c.QueryInt("car_id", 0)),
+		From:  from,
+		To:    to,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get fuel efficiency",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Fuel efficiency", report))
+}
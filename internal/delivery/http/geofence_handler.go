@@ -0,0 +1,194 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GeofenceHandler struct {
+	geofenceUsecase usecase.GeofenceUsecase
+}
+
+func NewGeofenceHandler(geofenceUsecase usecase.GeofenceUsecase) *GeofenceHandler {
+	return &GeofenceHandler{geofenceUsecase: geofenceUsecase}
+}
+
+func (h *GeofenceHandler) GetAll(c *fiber.Ctx) error {
+	params := model.GeofenceListParams{
+		Page:  c.QueryInt("page", 1),
+		Limit: c.QueryInt("limit", 10),
+	}
+	if active := c.Query("active"); active != "" {
+		value := c.QueryBool("active")
+		params.Active = &value
+	}
+
+	geofences, total, err := h.geofenceUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get geofences",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       geofences,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *GeofenceHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	geofence, err := h.geofenceUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Geofence not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Geofence found", geofence))
+}
+
+func (h *GeofenceHandler) Create(c *fiber.Ctx) error {
+	var req model.GeofenceRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	geofence, err := h.geofenceUsecase.Create(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create geofence",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Geofence created successfully", geofence))
+}
+
+func (h *GeofenceHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.GeofenceRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	geofence, err := h.geofenceUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update geofence",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Geofence updated successfully", geofence))
+}
+
+func (h *GeofenceHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.geofenceUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete geofence",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Geofence deleted successfully", nil))
+}
+
+// GetEvents lists enter/exit events. Filters: car_id, geofence_id, type, from, to.
+// When mounted under /geofences/:id the path ID selects the geofence.
+func (h *GeofenceHandler) GetEvents(c *fiber.Ctx) error {
+	from, err := helper.ParseTimeParam(c.Query("from"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	to, err := helper.ParseTimeParam(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	params := model.GeofenceEventListParams{
+		Page:       c.QueryInt("page", 1),
+		Limit:      c.QueryInt("limit", 50),
+		CarID:      int64(c.QueryInt("car_id", 0)),
+		GeofenceID: int64(c.QueryInt("geofence_id", 0)),
+		Type:       strings.ToUpper(c.Query("type")),
+		From:       from,
+		To:         to,
+	}
+	if c.Params("id") != "" {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+				"Invalid ID",
+				"ID must be a number",
+			))
+		}
+		params.GeofenceID = id
+	}
+
+	events, total, err := h.geofenceUsecase.GetEvents(params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get geofence events",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       events,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
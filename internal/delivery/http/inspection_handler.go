@@ -0,0 +1,223 @@
+package http
+
+import (
+	"errors"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+type InspectionHandler struct {
+	inspectionUsecase usecase.TripInspectionUsecase
+}
+
+func NewInspectionHandler(inspectionUsecase usecase.TripInspectionUsecase) *InspectionHandler {
+	return &InspectionHandler{inspectionUsecase: inspectionUsecase}
+}
+
+func (h *InspectionHandler) GetChecklist(c *fiber.Ctx) error {
+	items, err := h.inspectionUsecase.GetChecklist(c.Query("stage"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get checklist",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Inspection checklist", items))
+}
+
+func (h *InspectionHandler) GetChecklistItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	item, err := h.inspectionUsecase.GetChecklistItem(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Checklist item not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Checklist item found", item))
+}
+
+func (h *InspectionHandler) CreateChecklistItem(c *fiber.Ctx) error {
+	var req model.ChecklistItemRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	item, err := h.inspectionUsecase.CreateChecklistItem(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create checklist item",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Checklist item created successfully", item))
+}
+
+func (h *InspectionHandler) UpdateChecklistItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.ChecklistItemRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	item, err := h.inspectionUsecase.UpdateChecklistItem(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update checklist item",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Checklist item updated successfully", item))
+}
+
+func (h *InspectionHandler) DeleteChecklistItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.inspectionUsecase.DeleteChecklistItem(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete checklist item",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Checklist item deleted successfully", nil))
+}
+
+func (h *InspectionHandler) GetByTrip(c *fiber.Ctx) error {
+	tripID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	summary, err := h.inspectionUsecase.GetByTripID(tripID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Failed to get inspections",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Trip inspections", summary))
+}
+
+func (h *InspectionHandler) UploadPhotos(c *fiber.Ctx) error {
+	tripID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	files, closeFiles, err := formFiles(c, "photos")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid file",
+			err.Error(),
+		))
+	}
+	defer closeFiles()
+
+	photos, err := h.inspectionUsecase.AddPhotos(tripID, c.FormValue("stage"), files)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to upload photos",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Photos uploaded successfully", photos))
+}
+
+func (h *InspectionHandler) DownloadPhoto(c *fiber.Ctx) error {
+	tripID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+	photoID, err := strconv.ParseInt(c.Params("photoId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	file, err := h.inspectionUsecase.GetPhoto(tripID, photoID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"File not found",
+			err.Error(),
+		))
+	}
+
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", file.Filename))
+	return c.SendStream(file.Content)
+}
+
+// formFiles opens every file sent under the multipart field. A request without
+// a multipart body yields an empty slice.
+func formFiles(c *fiber.Ctx, field string) ([]*model.FileUpload, func(), error) {
+	var closers []func()
+	closeAll := func() {
+		for _, closeFile := range closers {
+			closeFile()
+		}
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		if errors.Is(err, fasthttp.ErrNoMultipartForm) {
+			return nil, closeAll, nil
+		}
+		return nil, closeAll, err
+	}
+
+	var uploads []*model.FileUpload
+	for _, header := range form.File[field] {
+		upload, closeFile, err := openUpload(header)
+		if err != nil {
+			closeAll()
+			return nil, func() {}, err
+		}
+		closers = append(closers, closeFile)
+		uploads = append(uploads, upload)
+	}
+	return uploads, closeAll, nil
+}
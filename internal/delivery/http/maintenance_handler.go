@@ -1,9 +1,11 @@
 package http
 
 import (
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/usecase"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,9 +20,11 @@ func NewMaintenanceHandler(maintenanceUsecase usecase.MaintenanceUsecase) *Maint
 
 func (h *MaintenanceHandler) GetAll(c *fiber.Ctx) error {
 	params := model.MaintenanceListParams{
-		Page:  c.QueryInt("page", 1),
-		Limit: c.QueryInt("limit", 10),
-		CarID: int64(c.QueryInt("car_id", 0)),
+		Page:       c.QueryInt("page", 1),
+		Limit:      c.QueryInt("limit", 10),
+		CarID:      int64(c.QueryInt("car_id", 0)),
+		Status:     strings.ToUpper(c.Query("status")),
+		WorkshopID: int64(c.QueryInt("workshop_id", 0)),
 	}
 
 	maintenances, total, err := h.maintenanceUsecase.GetAll(params)
@@ -68,11 +72,8 @@ func (h *MaintenanceHandler) GetByID(c *fiber.Ctx) error {
 
 func (h *MaintenanceHandler) Create(c *fiber.Ctx) error {
 	var req model.MaintenanceRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	maintenance, err := h.maintenanceUsecase.Create(req)
@@ -96,11 +97,8 @@ func (h *MaintenanceHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req model.MaintenanceRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	maintenance, err := h.maintenanceUsecase.Update(id, req)
@@ -132,3 +130,72 @@ func (h *MaintenanceHandler) Delete(c *fiber.Ctx) error {
 
 	return c.JSON(model.SuccessResponse("Maintenance deleted successfully", nil))
 }
+
+func (h *MaintenanceHandler) Start(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	maintenance, err := h.maintenanceUsecase.Start(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to start maintenance",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Maintenance started", maintenance))
+}
+
+// Complete closes a work order. The body with the final cost and odometer
+// reading is optional.
+func (h *MaintenanceHandler) Complete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.MaintenanceCompleteRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	maintenance, err := h.maintenanceUsecase.Complete(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to complete maintenance",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Maintenance completed", maintenance))
+}
+
+func (h *MaintenanceHandler) Cancel(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	maintenance, err := h.maintenanceUsecase.Cancel(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to cancel maintenance",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Maintenance cancelled", maintenance))
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MaintenancePlanHandler struct {
+	planUsecase usecase.MaintenancePlanUsecase
+}
+
+func NewMaintenancePlanHandler(planUsecase usecase.MaintenancePlanUsecase) *MaintenancePlanHandler {
+	return &MaintenancePlanHandler{planUsecase: planUsecase}
+}
+
+func (h *MaintenancePlanHandler) GetAll(c *fiber.Ctx) error {
+	params := model.MaintenancePlanListParams{
+		Page:  c.QueryInt("page", 1),
+		Limit: c.QueryInt("limit", 10),
+		CarID: int64(c.QueryInt("car_id", 0)),
+	}
+
+	plans, total, err := h.planUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get maintenance plans",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       plans,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *MaintenancePlanHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	plan, err := h.planUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Maintenance plan not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Maintenance plan found", plan))
+}
+
+func (h *MaintenancePlanHandler) Create(c *fiber.Ctx) error {
+	var req model.MaintenancePlanRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	plan, err := h.planUsecase.Create(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create maintenance plan",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Maintenance plan created successfully", plan))
+}
+
+func (h *MaintenancePlanHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.MaintenancePlanRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	plan, err := h.planUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update maintenance plan",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Maintenance plan updated successfully", plan))
+}
+
+func (h *MaintenancePlanHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.planUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete maintenance plan",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Maintenance plan deleted successfully", nil))
+}
+
+func (h *MaintenancePlanHandler) GetDue(c *fiber.Ctx) error {
+	items, err := h.planUsecase.GetDue(time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get due maintenances",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Due maintenances", items))
+}
@@ -0,0 +1,349 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MeHandler serves the driver self-service API. The driver is always
+// resolved from the token, never from the request body.
+type MeHandler struct {
+	authUsecase       usecase.AuthUsecase
+	driverUsecase     usecase.DriverUsecase
+	tripUsecase       usecase.TripUsecase
+	inspectionUsecase usecase.TripInspectionUsecase
+	requestUsecase    usecase.TripRequestUsecase
+}
+
+func NewMeHandler(
+	authUsecase usecase.AuthUsecase,
+	driverUsecase usecase.DriverUsecase,
+	tripUsecase usecase.TripUsecase,
+	inspectionUsecase usecase.TripInspectionUsecase,
+	requestUsecase usecase.TripRequestUsecase,
+) *MeHandler {
+	return &MeHandler{
+		authUsecase:       authUsecase,
+		driverUsecase:     driverUsecase,
+		tripUsecase:       tripUsecase,
+		inspectionUsecase: inspectionUsecase,
+		requestUsecase:    requestUsecase,
+	}
+}
+
+func (h *MeHandler) Profile(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(int64)
+
+	me, err := h.authUsecase.Me(userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Profile not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Profile found", me))
+}
+
+func (h *MeHandler) ActiveTrip(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	trip, err := h.tripUsecase.GetActiveByDriverID(driver.ID)
+	if err != nil {
+		return c.JSON(model.SuccessResponse("No active trip", nil))
+	}
+
+	return c.JSON(model.SuccessResponse("Active trip found", trip))
+}
+
+func (h *MeHandler) History(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	params := model.TripListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		DriverID: driver.ID,
+	}
+
+	trips, total, err := h.tripUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get trips",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       trips,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *MeHandler) Checkout(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	var req model.SelfCheckoutRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	trip, err := h.tripUsecase.Checkout(model.CheckoutRequest{
+		UserID:        userID,
+		CarID:         req.CarID,
+		DriverID:      driver.ID,
+		StartKm:       req.StartKm,
+		Notes:         req.Notes,
+		Inspection:    req.Inspection,
+		TripRequestID: req.TripRequestID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Checkout failed",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Checkout successful", trip))
+}
+
+func (h *MeHandler) Checkin(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	var req model.SelfCheckinRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	active, err := h.tripUsecase.GetActiveByDriverID(driver.ID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Checkin failed",
+			err.Error(),
+		))
+	}
+
+	trip, err := h.tripUsecase.Checkin(model.CheckinRequest{
+		TripID:     active.ID,
+		EndKm:      req.EndKm,
+		Notes:      req.Notes,
+		Inspection: req.Inspection,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Checkin failed",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Checkin successful", trip))
+}
+
+func (h *MeHandler) Checklist(c *fiber.Ctx) error {
+	items, err := h.inspectionUsecase.GetChecklist(c.Query("stage", "CHECKOUT"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get checklist",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Inspection checklist", items))
+}
+
+// UploadPhotos attaches inspection photos to the driver's active trip.
+func (h *MeHandler) UploadPhotos(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	active, err := h.tripUsecase.GetActiveByDriverID(driver.ID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Upload failed",
+			err.Error(),
+		))
+	}
+
+	files, closeFiles, err := formFiles(c, "photos")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid file",
+			err.Error(),
+		))
+	}
+	defer closeFiles()
+
+	photos, err := h.inspectionUsecase.AddPhotos(active.ID, c.FormValue("stage"), files)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to upload photos",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Photos uploaded successfully", photos))
+}
+
+func (h *MeHandler) TripRequests(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	params := model.TripRequestListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		Status:   strings.ToUpper(c.Query("status")),
+		DriverID: driver.ID,
+	}
+
+	requests, total, err := h.requestUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get trip requests",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       requests,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *MeHandler) CreateTripRequest(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	var req model.SelfTripRequestRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	request, err := h.requestUsecase.Create(model.TripRequestRequest{
+		CarID:        req.CarID,
+		DriverID:     driver.ID,
+		Purpose:      req.Purpose,
+		Destination:  req.Destination,
+		PlannedStart: req.PlannedStart,
+		PlannedEnd:   req.PlannedEnd,
+	}, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create trip request",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Trip request created successfully", request))
+}
+
+// CancelTripRequest lets drivers withdraw their own requests before checkout.
+func (h *MeHandler) CancelTripRequest(c *fiber.Ctx) error {
+	driver, err := h.currentDriver(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+			"Driver profile not found",
+			err.Error(),
+		))
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	existing, err := h.requestUsecase.GetByID(id)
+	if err != nil || existing.DriverID != driver.ID {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Trip request not found",
+			"trip request not found",
+		))
+	}
+
+	var req model.TripRequestDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	request, err := h.requestUsecase.Cancel(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to cancel trip request",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Trip request cancelled", request))
+}
+
+func (h *MeHandler) currentDriver(c *fiber.Ctx) (*model.DriverResponse, error) {
+	userID, _ := c.Locals("user_id").(int64)
+	return h.driverUsecase.GetByUserID(userID)
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"fleet-monitor/internal/model"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeviceKeyHeader carries a device API key instead of a user JWT.
+const DeviceKeyHeader = "X-API-Key"
+
+// DeviceKeyAuthenticator resolves a device API key to what it may access.
+type DeviceKeyAuthenticator interface {
+	AuthenticateKey(rawKey string) (*model.DeviceIdentity, error)
+}
+
+// DeviceKeyMiddleware authenticates requests that carry a device API key and
+// gives them the device role. Requests without the header pass through
+// untouched so JWTMiddleware can handle them.
+func DeviceKeyMiddleware(auth DeviceKeyAuthenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rawKey := c.Get(DeviceKeyHeader)
+		if rawKey == "" {
+			return c.Next()
+		}
+
+		identity, err := auth.AuthenticateKey(rawKey)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse(
+				"Unauthorized",
+				err.Error(),
+			))
+		}
+
+		c.Locals("device_id", identity.DeviceID)
+		c.Locals("device_car_id", identity.CarID)
+		c.Locals("role", RoleDevice)
+
+		return c.Next()
+	}
+}
+
+// RequireDeviceCar keeps device callers on their own car: the :id route
+// parameter must be the car the key is assigned to. User requests pass.
+func RequireDeviceCar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		carID, ok := c.Locals("device_car_id").(int64)
+		if !ok {
+			return c.Next()
+		}
+
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil || id != carID {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+				"Forbidden",
+				"API key is not valid for this car",
+			))
+		}
+		return c.Next()
+	}
+}
@@ -11,6 +11,11 @@ import (
 
 func JWTMiddleware(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		// Already authenticated by DeviceKeyMiddleware
+		if c.Locals("device_id") != nil {
+			return c.Next()
+		}
+
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse(
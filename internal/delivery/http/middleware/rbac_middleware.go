@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	PermUserWrite         = "user:write"
+	PermDashboardRead     = "dashboard:read"
+	PermStreamRead        = "stream:read"
+	PermCarRead           = "car:read"
+	PermCarWrite          = "car:write"
+	PermCarDelete         = "car:delete"
+	PermCarLocation       = "car:location"
+	PermDriverRead        = "driver:read"
+	PermDriverWrite       = "driver:write"
+	PermDriverDelete      = "driver:delete"
+	PermTripRead          = "trip:read"
+	PermTripWrite         = "trip:write"
+	PermTripApprove       = "trip:approve"
+	PermMaintenanceRead   = "maintenance:read"
+	PermMaintenanceWrite  = "maintenance:write"
+	PermMaintenanceDelete = "maintenance:delete"
+	PermDocumentRead      = "document:read"
+	PermDocumentWrite     = "document:write"
+	PermDocumentDelete    = "document:delete"
+	PermInspectionWrite   = "inspection:write"
+	PermInspectionDelete  = "inspection:delete"
+	PermGeofenceRead      = "geofence:read"
+	PermGeofenceWrite     = "geofence:write"
+	PermGeofenceDelete    = "geofence:delete"
+	PermReservationRead   = "reservation:read"
+	PermReservationWrite  = "reservation:write"
+	PermOdometerRead      = "odometer:read"
+	PermOdometerWrite     = "odometer:write"
+	PermDeviceRead        = "device:read"
+	PermDeviceWrite       = "device:write"
+	PermDeviceDelete      = "device:delete"
+	PermAlertRead         = "alert:read"
+	PermAlertWrite        = "alert:write"
+	PermAlertDelete       = "alert:delete"
+	PermNotificationSelf  = "notification:self"
+	PermWebhookRead       = "webhook:read"
+	PermWebhookWrite      = "webhook:write"
+	PermWebhookDelete     = "webhook:delete"
+	PermInventoryRead     = "inventory:read"
+	PermInventoryWrite    = "inventory:write"
+	PermInventoryDelete   = "inventory:delete"
+	PermFuelRead          = "fuel:read"
+	PermFuelWrite         = "fuel:write"
+	PermFuelDelete        = "fuel:delete"
+	PermSelfService       = "self:driver"
+)
+
+// RoleDevice is the role given to requests authenticated with a device API key.
+const RoleDevice = "device"
+
+// Policy maps each permission to the roles allowed to use it.
+var Policy = map[string][]string{
+	PermUserWrite:         {entity.UserRoleAdmin},
+	PermDashboardRead:     {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermStreamRead:        {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermCarRead:           {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager, entity.UserRoleDriver},
+	PermCarWrite:          {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermCarDelete:         {entity.UserRoleAdmin},
+	PermCarLocation:       {entity.UserRoleAdmin, entity.UserRoleOperator, RoleDevice},
+	PermDriverRead:        {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermDriverWrite:       {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermDriverDelete:      {entity.UserRoleAdmin},
+	PermTripRead:          {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermTripWrite:         {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermTripApprove:       {entity.UserRoleAdmin, entity.UserRoleManager},
+	PermMaintenanceRead:   {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermMaintenanceWrite:  {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermMaintenanceDelete: {entity.UserRoleAdmin},
+	PermDocumentRead:      {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermDocumentWrite:     {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermDocumentDelete:    {entity.UserRoleAdmin},
+	PermInspectionWrite:   {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermInspectionDelete:  {entity.UserRoleAdmin},
+	PermGeofenceRead:      {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermGeofenceWrite:     {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermGeofenceDelete:    {entity.UserRoleAdmin},
+	PermReservationRead:   {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermReservationWrite:  {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermOdometerRead:      {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermOdometerWrite:     {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermDeviceRead:        {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermDeviceWrite:       {entity.UserRoleAdmin},
+	PermDeviceDelete:      {entity.UserRoleAdmin},
+	PermAlertRead:         {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermAlertWrite:        {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermAlertDelete:       {entity.UserRoleAdmin},
+	PermNotificationSelf:  {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermWebhookRead:       {entity.UserRoleAdmin},
+	PermWebhookWrite:      {entity.UserRoleAdmin},
+	PermWebhookDelete:     {entity.UserRoleAdmin},
+	PermInventoryRead:     {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermInventoryWrite:    {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermInventoryDelete:   {entity.UserRoleAdmin},
+	PermFuelRead:          {entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleManager},
+	PermFuelWrite:         {entity.UserRoleAdmin, entity.UserRoleOperator},
+	PermFuelDelete:        {entity.UserRoleAdmin},
+	PermSelfService:       {entity.UserRoleDriver},
+}
+
+// RequireRole allows the request only when the token role is one of roles.
+// It must run after JWTMiddleware.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+		return forbidden(c)
+	}
+}
+
+// RequirePermission looks the permission up in Policy. Unknown permissions deny everyone.
+func RequirePermission(permission string) fiber.Handler {
+	return RequireRole(Policy[permission]...)
+}
+
+func forbidden(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse(
+		"Forbidden",
+		"You do not have permission to access this resource",
+	))
+}
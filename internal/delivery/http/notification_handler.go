@@ -0,0 +1,132 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationHandler serves the current user's subscriptions and the
+// notifications sent to them.
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{notificationUsecase: notificationUsecase}
+}
+
+// GetAll lists the user's notifications, newest first. Filters: event_type, status.
+func (h *NotificationHandler) GetAll(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(int64)
+	params := model.NotificationListParams{
+		Page:      c.QueryInt("page", 1),
+		Limit:     c.QueryInt("limit", 20),
+		UserID:    userID,
+		EventType: strings.ToUpper(c.Query("event_type")),
+		Status:    strings.ToUpper(c.Query("status")),
+	}
+
+	notifications, total, err := h.notificationUsecase.GetNotifications(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get notifications",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       notifications,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *NotificationHandler) GetSubscriptions(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(int64)
+	subscriptions, err := h.notificationUsecase.GetSubscriptions(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get subscriptions",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Notification subscriptions", subscriptions))
+}
+
+func (h *NotificationHandler) CreateSubscription(c *fiber.Ctx) error {
+	var req model.NotificationSubscriptionRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	subscription, err := h.notificationUsecase.CreateSubscription(userID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create subscription",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Subscription created successfully", subscription))
+}
+
+func (h *NotificationHandler) UpdateSubscription(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.NotificationSubscriptionRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	subscription, err := h.notificationUsecase.UpdateSubscription(id, userID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update subscription",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Subscription updated successfully", subscription))
+}
+
+func (h *NotificationHandler) DeleteSubscription(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	if err := h.notificationUsecase.DeleteSubscription(id, userID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete subscription",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Subscription deleted successfully", nil))
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OdometerHandler struct {
+	odometerUsecase usecase.OdometerUsecase
+}
+
+func NewOdometerHandler(odometerUsecase usecase.OdometerUsecase) *OdometerHandler {
+	return &OdometerHandler{odometerUsecase: odometerUsecase}
+}
+
+// GetAll serves both /odometer-readings and /cars/:id/odometer.
+func (h *OdometerHandler) GetAll(c *fiber.Ctx) error {
+	params := model.OdometerListParams{
+		Page:   c.QueryInt("page", 1),
+		Limit:  c.QueryInt("limit", 20),
+		CarID:  int64(c.QueryInt("car_id", 0)),
+		Source: strings.ToUpper(c.Query("source")),
+	}
+	if flagged := c.Query("flagged"); flagged != "" {
+		value := c.QueryBool("flagged")
+		params.Flagged = &value
+	}
+	if reviewed := c.Query("reviewed"); reviewed != "" {
+		value := c.QueryBool("reviewed")
+		params.Reviewed = &value
+	}
+	if c.Params("id") != "" {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+				"Invalid ID",
+				"ID must be a number",
+			))
+		}
+		params.CarID = id
+	}
+
+	readings, total, err := h.odometerUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get odometer readings",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       readings,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *OdometerHandler) Create(c *fiber.Ctx) error {
+	carID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.OdometerReadingRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	reading, err := h.odometerUsecase.Record(carID, req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to record odometer reading",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Odometer reading recorded", reading))
+}
+
+func (h *OdometerHandler) Review(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.OdometerReviewRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	reading, err := h.odometerUsecase.Review(id, req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to review odometer reading",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Odometer reading reviewed", reading))
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PartHandler struct {
+	partUsecase usecase.PartUsecase
+}
+
+func NewPartHandler(partUsecase usecase.PartUsecase) *PartHandler {
+	return &PartHandler{partUsecase: partUsecase}
+}
+
+// GetAll lists the parts catalog. Filters: search, category, low_stock.
+func (h *PartHandler) GetAll(c *fiber.Ctx) error {
+	params := model.PartListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		Search:   c.Query("search"),
+		Category: c.Query("category"),
+		LowStock: c.QueryBool("low_stock"),
+	}
+
+	parts, total, err := h.partUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get parts",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       parts,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *PartHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	part, err := h.partUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Part not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Part found", part))
+}
+
+func (h *PartHandler) Create(c *fiber.Ctx) error {
+	var req model.PartRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	part, err := h.partUsecase.Create(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create part",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Part created successfully", part))
+}
+
+func (h *PartHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.PartRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	part, err := h.partUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update part",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Part updated successfully", part))
+}
+
+func (h *PartHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.partUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete part",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Part deleted successfully", nil))
+}
+
+// Receive books stock delivered by a supplier.
+func (h *PartHandler) Receive(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.StockReceiveRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	movement, err := h.partUsecase.Receive(id, req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to receive stock",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Stock received successfully", movement))
+}
+
+// Adjust corrects stock after a count.
+func (h *PartHandler) Adjust(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.StockAdjustRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	movement, err := h.partUsecase.Adjust(id, req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to adjust stock",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Stock adjusted successfully", movement))
+}
+
+// GetMovements lists stock movements, newest first. On /parts/:id/movements
+// the part comes from the path. Filters: part_id, maintenance_id, type.
+func (h *PartHandler) GetMovements(c *fiber.Ctx) error {
+	params := model.StockMovementListParams{
+		Page:          c.QueryInt("page", 1),
+		Limit:         c.QueryInt("limit", 20),
+		PartID:        int64(c.QueryInt("part_id", 0)),
+		MaintenanceID: int64(c.QueryInt("maintenance_id", 0)),
+		Type:          strings.ToUpper(c.Query("type")),
+	}
+	if c.Params("id") != "" {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+				"Invalid ID",
+				"ID must be a number",
+			))
+		}
+		params.PartID = id
+	}
+
+	movements, total, err := h.partUsecase.GetMovements(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get stock movements",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       movements,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+// GetLowStock lists parts at or below their reorder level for purchasing.
+func (h *PartHandler) GetLowStock(c *fiber.Ctx) error {
+	parts, err := h.partUsecase.GetLowStock()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get low stock parts",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Low stock parts", parts))
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReservationHandler struct {
+	reservationUsecase usecase.ReservationUsecase
+}
+
+func NewReservationHandler(reservationUsecase usecase.ReservationUsecase) *ReservationHandler {
+	return &ReservationHandler{reservationUsecase: reservationUsecase}
+}
+
+// GetAll returns reservations overlapping the from-to window for the booking calendar.
+func (h *ReservationHandler) GetAll(c *fiber.Ctx) error {
+	from, err := helper.ParseTimeParam(c.Query("from"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	to, err := helper.ParseTimeParam(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	params := model.ReservationListParams{
+		CarID:    int64(c.QueryInt("car_id", 0)),
+		DriverID: int64(c.QueryInt("driver_id", 0)),
+		Status:   strings.ToUpper(c.Query("status")),
+		From:     from,
+		To:       to,
+		Limit:    c.QueryInt("limit", 500),
+	}
+
+	reservations, err := h.reservationUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get reservations",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Reservations", reservations))
+}
+
+func (h *ReservationHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	reservation, err := h.reservationUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Reservation not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Reservation found", reservation))
+}
+
+func (h *ReservationHandler) Create(c *fiber.Ctx) error {
+	var req model.ReservationRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	reservation, err := h.reservationUsecase.Create(req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse(
+			"Failed to create reservation",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Reservation created successfully", reservation))
+}
+
+func (h *ReservationHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.ReservationRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	reservation, err := h.reservationUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse(
+			"Failed to update reservation",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Reservation updated successfully", reservation))
+}
+
+func (h *ReservationHandler) Cancel(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.CancelReservationRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	reservation, err := h.reservationUsecase.Cancel(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to cancel reservation",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Reservation cancelled successfully", reservation))
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/realtime"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+type StreamHandler struct {
+	hub realtime.Hub
+}
+
+func NewStreamHandler(hub realtime.Hub) *StreamHandler {
+	return &StreamHandler{hub: hub}
+}
+
+// Locations streams car position and status changes as Server-Sent Events.
+// Clients may narrow the stream with ?car_ids=1,2,3 and ?status=IN_USE,AVAILABLE.
+func (h *StreamHandler) Locations(c *fiber.Ctx) error {
+	filter := model.StreamFilter{}
+
+	if carIDs := c.Query("car_ids"); carIDs != "" {
+		for _, raw := range strings.Split(carIDs, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+					"Invalid car_ids",
+					"car_ids must be a comma separated list of numbers",
+				))
+			}
+			filter.CarIDs = append(filter.CarIDs, id)
+		}
+	}
+	if statuses := c.Query("status"); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			filter.Statuses = append(filter.Statuses, strings.TrimSpace(status))
+		}
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	sub := h.hub.Subscribe(filter)
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer h.hub.Unsubscribe(sub)
+
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		// Tell the client the stream is open
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-sub.Events:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// A failed flush means the client went away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package http
 
 import (
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/usecase"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -30,6 +32,11 @@ func (h *TripHandler) GetAll(c *fiber.Ctx) error {
 		CarID:    int64(c.QueryInt("car_id", 0)),
 		DriverID: int64(c.QueryInt("driver_id", 0)),
 		Active:   activePtr,
+		Status:   strings.ToUpper(c.Query("status")),
+	}
+	if mismatch := c.Query("distance_mismatch"); mismatch != "" {
+		value := c.QueryBool("distance_mismatch")
+		params.DistanceMismatch = &value
 	}
 
 	trips, total, err := h.tripUsecase.GetAll(params)
@@ -75,15 +82,35 @@ func (h *TripHandler) GetByID(c *fiber.Ctx) error {
 	return c.JSON(model.SuccessResponse("Trip found", trip))
 }
 
-func (h *TripHandler) Checkout(c *fiber.Ctx) error {
-	var req model.CheckoutRequest
-	if err := c.BodyParser(&req); err != nil {
+// GetRoute returns the trip's GPS track as a bare GeoJSON Feature so map
+// libraries can load it directly.
+func (h *TripHandler) GetRoute(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	route, err := h.tripUsecase.GetRoute(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Failed to get trip route",
 			err.Error(),
 		))
 	}
 
+	return c.JSON(route)
+}
+
+func (h *TripHandler) Checkout(c *fiber.Ctx) error {
+	var req model.CheckoutRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	req.UserID, _ = c.Locals("user_id").(int64)
 	trip, err := h.tripUsecase.Checkout(req)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
@@ -97,11 +124,8 @@ func (h *TripHandler) Checkout(c *fiber.Ctx) error {
 
 func (h *TripHandler) Checkin(c *fiber.Ctx) error {
 	var req model.CheckinRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
-			"Invalid request",
-			err.Error(),
-		))
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	trip, err := h.tripUsecase.Checkin(req)
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TripRequestHandler struct {
+	requestUsecase usecase.TripRequestUsecase
+}
+
+func NewTripRequestHandler(requestUsecase usecase.TripRequestUsecase) *TripRequestHandler {
+	return &TripRequestHandler{requestUsecase: requestUsecase}
+}
+
+func (h *TripRequestHandler) GetAll(c *fiber.Ctx) error {
+	params := model.TripRequestListParams{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		Status:   strings.ToUpper(c.Query("status")),
+		CarID:    int64(c.QueryInt("car_id", 0)),
+		DriverID: int64(c.QueryInt("driver_id", 0)),
+	}
+
+	requests, total, err := h.requestUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get trip requests",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       requests,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *TripRequestHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	request, err := h.requestUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Trip request not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Trip request found", request))
+}
+
+func (h *TripRequestHandler) Create(c *fiber.Ctx) error {
+	var req model.TripRequestRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	request, err := h.requestUsecase.Create(req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create trip request",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Trip request created successfully", request))
+}
+
+func (h *TripRequestHandler) Approve(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.TripRequestDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	request, err := h.requestUsecase.Approve(id, userID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to approve trip request",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Trip request approved", request))
+}
+
+func (h *TripRequestHandler) Reject(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.TripRequestDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	request, err := h.requestUsecase.Reject(id, userID, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to reject trip request",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Trip request rejected", request))
+}
+
+func (h *TripRequestHandler) Cancel(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.TripRequestDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := helper.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+	}
+
+	request, err := h.requestUsecase.Cancel(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to cancel trip request",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Trip request cancelled", request))
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUsecase usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{webhookUsecase: webhookUsecase}
+}
+
+func (h *WebhookHandler) GetAll(c *fiber.Ctx) error {
+	webhooks, err := h.webhookUsecase.GetAll()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get webhooks",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Webhooks", webhooks))
+}
+
+func (h *WebhookHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	webhook, err := h.webhookUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Webhook not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Webhook found", webhook))
+}
+
+// Create registers a webhook. The response holds the signing secret; it is
+// not shown again.
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var req model.WebhookRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(int64)
+	webhook, err := h.webhookUsecase.Create(req, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create webhook",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Webhook created successfully", webhook))
+}
+
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.WebhookRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	webhook, err := h.webhookUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update webhook",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Webhook updated successfully", webhook))
+}
+
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.webhookUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete webhook",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Webhook deleted successfully", nil))
+}
+
+// GetDeliveries lists a webhook's delivery log, newest first. Filters: event_type, status.
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	params := model.WebhookDeliveryListParams{
+		Page:      c.QueryInt("page", 1),
+		Limit:     c.QueryInt("limit", 20),
+		WebhookID: id,
+		EventType: c.Query("event_type"),
+		Status:    strings.ToUpper(c.Query("status")),
+	}
+
+	deliveries, total, err := h.webhookUsecase.GetDeliveries(params)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to get deliveries",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       deliveries,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+// Redeliver sends a logged delivery again and returns the new attempt.
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+	deliveryID, err := strconv.ParseInt(c.Params("deliveryId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"Delivery ID must be a number",
+		))
+	}
+
+	delivery, err := h.webhookUsecase.Redeliver(id, deliveryID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to redeliver webhook",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Webhook redelivered", delivery))
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/usecase"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WorkshopHandler struct {
+	workshopUsecase usecase.WorkshopUsecase
+}
+
+func NewWorkshopHandler(workshopUsecase usecase.WorkshopUsecase) *WorkshopHandler {
+	return &WorkshopHandler{workshopUsecase: workshopUsecase}
+}
+
+// GetAll lists workshops by name. Filters: search, specialty.
+func (h *WorkshopHandler) GetAll(c *fiber.Ctx) error {
+	params := model.WorkshopListParams{
+		Page:      c.QueryInt("page", 1),
+		Limit:     c.QueryInt("limit", 10),
+		Search:    c.Query("search"),
+		Specialty: strings.ToLower(strings.TrimSpace(c.Query("specialty"))),
+	}
+
+	workshops, total, err := h.workshopUsecase.GetAll(params)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get workshops",
+			err.Error(),
+		))
+	}
+
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit > 0 {
+		totalPages++
+	}
+
+	return c.JSON(model.PaginationResponse{
+		Success:    true,
+		Data:       workshops,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *WorkshopHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	workshop, err := h.workshopUsecase.GetByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse(
+			"Workshop not found",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Workshop found", workshop))
+}
+
+func (h *WorkshopHandler) Create(c *fiber.Ctx) error {
+	var req model.WorkshopRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	workshop, err := h.workshopUsecase.Create(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to create workshop",
+			err.Error(),
+		))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse("Workshop created successfully", workshop))
+}
+
+func (h *WorkshopHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	var req model.WorkshopRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	workshop, err := h.workshopUsecase.Update(id, req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to update workshop",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Workshop updated successfully", workshop))
+}
+
+func (h *WorkshopHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid ID",
+			"ID must be a number",
+		))
+	}
+
+	if err := h.workshopUsecase.Delete(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Failed to delete workshop",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Workshop deleted successfully", nil))
+}
+
+// Report sums spend and turnaround per workshop over work orders completed
+// between from and to.
+func (h *WorkshopHandler) Report(c *fiber.Ctx) error {
+	from, err := helper.ParseTimeParam(c.Query("from"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid from parameter",
+			err.Error(),
+		))
+	}
+	to, err := helper.ParseTimeParam(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse(
+			"Invalid to parameter",
+			err.Error(),
+		))
+	}
+
+	report, err := h.workshopUsecase.Report(model.WorkshopReportParams{From: from, To: to})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(
+			"Failed to get workshop report",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(model.SuccessResponse("Workshop report", report))
+}
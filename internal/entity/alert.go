@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// AlertRule describes a condition that raises an alert for a car. The meaning
+// of Threshold depends on Type: km/h for SPEEDING and UNAUTHORIZED_MOVEMENT,
+// minutes for STALE_GPS, hours for LONG_TRIP. GEOFENCE_BREACH ignores it.
+type AlertRule struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string    `gorm:"size:100;not null" json:"name"`
+	Type       string    `gorm:"size:30;not null" json:"type"`     // SPEEDING, STALE_GPS, LONG_TRIP, UNAUTHORIZED_MOVEMENT, GEOFENCE_BREACH
+	Severity   string    `gorm:"size:10;not null" json:"severity"` // INFO, WARNING, CRITICAL
+	Threshold  float64   `gorm:"type:decimal(10,2);not null;default:0" json:"threshold"`
+	CarID      *int64    `json:"car_id"` // Empty = applies to every car
+	Car        *Car      `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	GeofenceID *int64    `json:"geofence_id"` // GEOFENCE_BREACH only; empty = every geofence
+	Geofence   *Geofence `gorm:"foreignKey:GeofenceID" json:"geofence,omitempty"`
+	Transition string    `gorm:"size:10" json:"transition"` // GEOFENCE_BREACH only: ENTER, EXIT, empty = both
+	Active     bool      `gorm:"not null" json:"active"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
+// Alert is one rule violation for a car. A rule keeps at most one unresolved
+// alert per car, so a condition that persists does not raise duplicates.
+type Alert struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	RuleID         *int64     `json:"rule_id"`
+	Rule           *AlertRule `gorm:"foreignKey:RuleID" json:"rule,omitempty"`
+	CarID          int64      `gorm:"not null" json:"car_id"`
+	Car            *Car       `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	TripID         *int64     `json:"trip_id"`
+	GeofenceID     *int64     `json:"geofence_id"`
+	Type           string     `gorm:"size:30;not null" json:"type"`
+	Severity       string     `gorm:"size:10;not null" json:"severity"`
+	Message        string     `gorm:"size:255;not null" json:"message"`
+	Value          *float64   `gorm:"type:decimal(10,2)" json:"value"` // Offending value, in the threshold's unit
+	Lat            *float64   `gorm:"type:decimal(10,8)" json:"lat"`
+	Lng            *float64   `gorm:"type:decimal(11,8)" json:"lng"`
+	Status         string     `gorm:"size:20;not null;default:'OPEN'" json:"status"` // OPEN, ACKNOWLEDGED, RESOLVED
+	TriggeredAt    time.Time  `gorm:"not null" json:"triggered_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	AcknowledgedBy *int64     `json:"acknowledged_by"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	ResolvedBy     *int64     `json:"resolved_by"` // Empty when resolved automatically
+	ResolutionNote string     `gorm:"type:text" json:"resolution_note"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Alert) TableName() string {
+	return "alerts"
+}
+
+const (
+	AlertTypeSpeeding             = "SPEEDING"
+	AlertTypeStaleGPS             = "STALE_GPS"
+	AlertTypeLongTrip             = "LONG_TRIP"
+	AlertTypeUnauthorizedMovement = "UNAUTHORIZED_MOVEMENT"
+	AlertTypeGeofenceBreach       = "GEOFENCE_BREACH"
+)
+
+const (
+	AlertSeverityInfo     = "INFO"
+	AlertSeverityWarning  = "WARNING"
+	AlertSeverityCritical = "CRITICAL"
+)
+
+const (
+	AlertStatusOpen         = "OPEN"
+	AlertStatusAcknowledged = "ACKNOWLEDGED"
+	AlertStatusResolved     = "RESOLVED"
+)
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type CarDocument struct {
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID           int64      `gorm:"not null;index" json:"car_id"`
+	Car             *Car       `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	Type            string     `gorm:"size:20;not null" json:"type"` // STNK, PAJAK, KIR, INSURANCE
+	Number          string     `gorm:"size:100" json:"number"`
+	IssueDate       *time.Time `json:"issue_date"`
+	ExpiryDate      time.Time  `gorm:"not null" json:"expiry_date"`
+	FileKey         string     `gorm:"size:255" json:"-"`
+	FileName        string     `gorm:"size:255" json:"file_name"`
+	FileContentType string     `gorm:"size:100" json:"file_content_type"`
+	Notes           string     `gorm:"type:text" json:"notes"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (CarDocument) TableName() string {
+	return "car_documents"
+}
+
+const (
+	DocumentTypeSTNK      = "STNK"
+	DocumentTypePajak     = "PAJAK"
+	DocumentTypeKIR       = "KIR"
+	DocumentTypeInsurance = "INSURANCE"
+)
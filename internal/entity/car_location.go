@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type CarLocation struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID      int64     `gorm:"not null;index" json:"car_id"`
+	Lat        float64   `gorm:"type:decimal(10,8);not null" json:"lat"`
+	Lng        float64   `gorm:"type:decimal(11,8);not null" json:"lng"`
+	Speed      *float64  `gorm:"type:decimal(6,2)" json:"speed"`    // km/h
+	Heading    *int      `json:"heading"`                           // degrees from north
+	Accuracy   *float64  `gorm:"type:decimal(8,2)" json:"accuracy"` // meter
+	Battery    *int      `json:"battery"`                           // percent
+	RecordedAt time.Time `gorm:"not null" json:"recorded_at"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (CarLocation) TableName() string {
+	return "car_locations"
+}
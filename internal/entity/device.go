@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Device is a GPS source assigned to a car: a hardware tracker identified by
+// its IMEI, or an HTTP client (phone app, script) that pushes locations with
+// a device API key.
+type Device struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string     `gorm:"size:100;not null" json:"name"`
+	IMEI       *string    `gorm:"column:imei;size:20;unique" json:"imei"` // Empty for HTTP devices
+	Protocol   string     `gorm:"size:20;not null" json:"protocol"`       // GT06, TELTONIKA, HTTP
+	CarID      *int64     `json:"car_id"`
+	Car        *Car       `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	Active     bool       `gorm:"not null" json:"active"`
+	LastSeenAt *time.Time `json:"last_seen_at"` // Last time the device connected or sent a position
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Device) TableName() string {
+	return "devices"
+}
+
+const (
+	DeviceProtocolGT06      = "GT06"
+	DeviceProtocolTeltonika = "TELTONIKA"
+	DeviceProtocolHTTP      = "HTTP"
+)
+
+// DeviceAPIKey lets a device push locations for its car without a user JWT.
+// Only a SHA-256 hash of the key is stored; the prefix is used to find it.
+type DeviceAPIKey struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	DeviceID   int64      `gorm:"not null" json:"device_id"`
+	Device     *Device    `gorm:"foreignKey:DeviceID" json:"device,omitempty"`
+	Name       string     `gorm:"size:100;not null" json:"name"`
+	Prefix     string     `gorm:"size:16;not null;unique" json:"prefix"`
+	KeyHash    string     `gorm:"size:64;not null" json:"-"`
+	CreatedBy  *int64     `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (DeviceAPIKey) TableName() string {
+	return "device_api_keys"
+}
@@ -4,6 +4,8 @@ import "time"
 
 type Driver struct {
 	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        *int64    `gorm:"unique" json:"user_id"` // The driver's login account (driver role)
+	User          *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Name          string    `gorm:"size:100;not null" json:"name"`
 	PhoneNumber   string    `gorm:"size:20" json:"phone_number"`
 	LicenseNumber string    `gorm:"size:50;unique" json:"license_number"`
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// FuelLog is one refuelling of a car. The efficiency fields are derived: they
+// are set on full-tank fills from the distance and the litres since the
+// previous full-tank fill, and recomputed whenever the car's log changes.
+type FuelLog struct {
+	ID                 int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID              int64     `gorm:"not null" json:"car_id"`
+	Car                *Car      `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	DriverID           *int64    `json:"driver_id"`
+	Driver             *Driver   `gorm:"foreignKey:DriverID" json:"driver,omitempty"`
+	TripID             *int64    `json:"trip_id"`
+	FilledAt           time.Time `gorm:"not null" json:"filled_at"`
+	Liters             float64   `gorm:"type:decimal(10,3);not null" json:"liters"`
+	PricePerLiter      float64   `gorm:"type:decimal(15,2);not null" json:"price_per_liter"`
+	TotalCost          float64   `gorm:"type:decimal(15,2);not null" json:"total_cost"` // liters x price_per_liter
+	OdometerKm         int       `gorm:"not null" json:"odometer_km"`
+	Station            string    `gorm:"size:100" json:"station"`
+	FullTank           bool      `gorm:"not null" json:"full_tank"`
+	ReceiptKey         string    `gorm:"size:255" json:"-"`
+	ReceiptName        string    `gorm:"size:255" json:"receipt_name"`
+	ReceiptContentType string    `gorm:"size:100" json:"receipt_content_type"`
+	Notes              string    `gorm:"type:text" json:"notes"`
+	DistanceKm         *int      `json:"distance_km"`                                    // Distance since the previous full tank
+	IntervalLiters     *float64  `gorm:"type:decimal(10,3)" json:"interval_liters"`      // Liters since the previous full tank, partial fills included
+	KmPerLiter         *float64  `gorm:"type:decimal(8,2)" json:"km_per_liter"`          // distance_km / interval_liters
+	BaselineKmPerLiter *float64  `gorm:"type:decimal(8,2)" json:"baseline_km_per_liter"` // The car's median efficiency before this fill
+	Flagged            bool      `gorm:"not null" json:"flagged"`
+	FlagReason         string    `gorm:"size:255" json:"flag_reason"`
+	CreatedBy          *int64    `json:"created_by"`
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (FuelLog) TableName() string {
+	return "fuel_logs"
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+type Geofence struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	Type        string    `gorm:"size:20;not null" json:"type"`        // CIRCLE, POLYGON
+	Geometry    string    `gorm:"type:jsonb;not null" json:"geometry"` // GeoJSON Point (circle center) or Polygon
+	RadiusM     float64   `gorm:"column:radius_m" json:"radius_m"`     // CIRCLE only
+	Active      bool      `gorm:"not null" json:"active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Geofence) TableName() string {
+	return "geofences"
+}
+
+type GeofenceEvent struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	GeofenceID int64     `gorm:"not null" json:"geofence_id"`
+	Geofence   *Geofence `gorm:"foreignKey:GeofenceID" json:"geofence,omitempty"`
+	CarID      int64     `gorm:"not null" json:"car_id"`
+	Car        *Car      `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	Type       string    `gorm:"size:10;not null" json:"type"` // ENTER, EXIT
+	Lat        float64   `gorm:"type:decimal(10,8);not null" json:"lat"`
+	Lng        float64   `gorm:"type:decimal(11,8);not null" json:"lng"`
+	OccurredAt time.Time `gorm:"not null" json:"occurred_at"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (GeofenceEvent) TableName() string {
+	return "geofence_events"
+}
+
+const (
+	GeofenceTypeCircle  = "CIRCLE"
+	GeofenceTypePolygon = "POLYGON"
+)
+
+const (
+	GeofenceEventEnter = "ENTER"
+	GeofenceEventExit  = "EXIT"
+)
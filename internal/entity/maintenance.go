@@ -3,16 +3,64 @@ package entity
 import "time"
 
 type Maintenance struct {
-	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	CarID        int64     `gorm:"not null" json:"car_id"`
-	Car          *Car      `gorm:"foreignKey:CarID" json:"car,omitempty"`
-	ServiceDate  time.Time `gorm:"not null" json:"service_date"`
-	Description  string    `gorm:"type:text;not null" json:"description"`
-	Cost         float64   `gorm:"type:decimal(15,2);default:0" json:"cost"`
-	WorkshopName string    `gorm:"size:100" json:"workshop_name"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID           int64             `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID        int64             `gorm:"not null" json:"car_id"`
+	Car          *Car              `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	ServiceDate  time.Time         `gorm:"not null" json:"service_date"`
+	Description  string            `gorm:"type:text;not null" json:"description"`
+	Cost         float64           `gorm:"type:decimal(15,2);default:0" json:"cost"` // Total including tax; computed from the items when present
+	PartsCost    float64           `gorm:"type:decimal(15,2);not null;default:0" json:"parts_cost"`
+	LabourCost   float64           `gorm:"type:decimal(15,2);not null;default:0" json:"labour_cost"`
+	OtherCost    float64           `gorm:"type:decimal(15,2);not null;default:0" json:"other_cost"`
+	TaxAmount    float64           `gorm:"type:decimal(15,2);not null;default:0" json:"tax_amount"`
+	WorkshopID   *int64            `json:"workshop_id"`
+	Workshop     *Workshop         `gorm:"foreignKey:WorkshopID" json:"workshop,omitempty"`
+	WorkshopName string            `gorm:"size:100" json:"workshop_name"` // Copy of the workshop name when the work order was created
+	PlanID       *int64            `gorm:"column:maintenance_plan_id" json:"plan_id"`
+	Status       string            `gorm:"size:20;not null;default:'SCHEDULED'" json:"status"` // SCHEDULED, IN_PROGRESS, COMPLETED, CANCELLED
+	StartedAt    *time.Time        `json:"started_at"`
+	CompletedAt  *time.Time        `json:"completed_at"`
+	CancelledAt  *time.Time        `json:"cancelled_at"`
+	Items        []MaintenanceItem `gorm:"foreignKey:MaintenanceID" json:"items,omitempty"`
+	CreatedAt    time.Time         `gorm:"autoCreateTime" json:"created_at"`
 }
 
 func (Maintenance) TableName() string {
 	return "maintenances"
 }
+
+// MaintenanceItem is one line of a work order's bill. Subtotal, TaxAmount and
+// Total are derived from the other fields when the item is saved.
+type MaintenanceItem struct {
+	ID            int64   `gorm:"primaryKey;autoIncrement" json:"id"`
+	MaintenanceID int64   `gorm:"not null" json:"maintenance_id"`
+	Type          string  `gorm:"size:20;not null" json:"type"` // PART, LABOUR, OTHER
+	PartID        *int64  `json:"part_id"`                      // Part taken from our own stock
+	PartNumber    string  `gorm:"size:50" json:"part_number"`
+	Description   string  `gorm:"size:255;not null" json:"description"`
+	Quantity      float64 `gorm:"type:decimal(12,3);not null" json:"quantity"`
+	UnitPrice     float64 `gorm:"type:decimal(15,2);not null" json:"unit_price"`
+	TaxRate       float64 `gorm:"type:decimal(5,2);not null;default:0" json:"tax_rate"` // Percent, e.g. 11 for 11% VAT
+	Subtotal      float64 `gorm:"type:decimal(15,2);not null" json:"subtotal"`
+	TaxAmount     float64 `gorm:"type:decimal(15,2);not null" json:"tax_amount"`
+	Total         float64 `gorm:"type:decimal(15,2);not null" json:"total"`
+}
+
+func (MaintenanceItem) TableName() string {
+	return "maintenance_items"
+}
+
+const (
+	MaintenanceItemTypePart   = "PART"
+	MaintenanceItemTypeLabour = "LABOUR"
+	MaintenanceItemTypeOther  = "OTHER"
+)
+
+// SCHEDULED -> IN_PROGRESS -> COMPLETED, CANCELLED before completion.
+// The car stays in MAINTENANCE while any work order is IN_PROGRESS.
+const (
+	MaintenanceStatusScheduled  = "SCHEDULED"
+	MaintenanceStatusInProgress = "IN_PROGRESS"
+	MaintenanceStatusCompleted  = "COMPLETED"
+	MaintenanceStatusCancelled  = "CANCELLED"
+)
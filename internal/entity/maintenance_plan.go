@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// MaintenancePlan is a recurring service rule, either for one car (CarID)
+// or for every car of a brand/model.
+type MaintenancePlan struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID          *int64    `json:"car_id"`
+	Car            *Car      `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	Brand          string    `gorm:"size:50" json:"brand"`
+	Model          string    `gorm:"size:50" json:"model"`
+	Name           string    `gorm:"size:100;not null" json:"name"` // Oil change, tune-up, etc.
+	IntervalKm     *int      `json:"interval_km"`
+	IntervalMonths *int      `json:"interval_months"`
+	WarningKm      int       `gorm:"default:500" json:"warning_km"`
+	WarningDays    int       `gorm:"default:14" json:"warning_days"`
+	Active         bool      `gorm:"not null" json:"active"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
+
+const (
+	MaintenanceDueOverdue  = "OVERDUE"
+	MaintenanceDueUpcoming = "UPCOMING"
+	MaintenanceDueOK       = "OK"
+)
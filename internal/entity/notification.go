@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// NotificationSubscription is one user's wish to be told about some events on
+// a channel. Target is an email address, webhook URL or Telegram chat ID.
+type NotificationSubscription struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      int64     `gorm:"not null" json:"user_id"`
+	Channel     string    `gorm:"size:20;not null" json:"channel"` // EMAIL, WEBHOOK, TELEGRAM
+	Target      string    `gorm:"size:500;not null" json:"target"`
+	EventTypes  string    `gorm:"size:255;not null" json:"event_types"` // Comma separated, e.g. ALERT,TRIP_CHECKIN
+	MinSeverity string    `gorm:"size:10" json:"min_severity"`          // ALERT only; empty = every severity
+	Active      bool      `gorm:"not null" json:"active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (NotificationSubscription) TableName() string {
+	return "notification_subscriptions"
+}
+
+// Notification is an outbox row: a message waiting to be delivered, or the
+// record of one that was. Rows are written in the same transaction as the
+// change that caused them and delivered later by the dispatcher.
+type Notification struct {
+	ID             int64                     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID *int64                    `json:"subscription_id"`
+	Subscription   *NotificationSubscription `gorm:"foreignKey:SubscriptionID" json:"subscription,omitempty"`
+	UserID         int64                     `gorm:"not null" json:"user_id"`
+	Channel        string                    `gorm:"size:20;not null" json:"channel"`
+	Target         string                    `gorm:"size:500;not null" json:"target"`
+	EventType      string                    `gorm:"size:30;not null" json:"event_type"`
+	DedupeKey      *string                   `gorm:"size:100" json:"-"` // Keeps the same notification from being sent twice
+	Subject        string                    `gorm:"size:255;not null" json:"subject"`
+	Body           string                    `gorm:"type:text;not null" json:"body"`
+	Status         string                    `gorm:"size:20;not null;default:'PENDING'" json:"status"` // PENDING, SENT, FAILED
+	Attempts       int                       `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time                 `gorm:"not null" json:"next_attempt_at"`
+	LastError      string                    `gorm:"type:text" json:"last_error"`
+	SentAt         *time.Time                `json:"sent_at"`
+	CreatedAt      time.Time                 `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time                 `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+const (
+	NotificationEventAlert          = "ALERT"
+	NotificationEventTripCheckout   = "TRIP_CHECKOUT"
+	NotificationEventTripCheckin    = "TRIP_CHECKIN"
+	NotificationEventDocumentExpiry = "DOCUMENT_EXPIRY"
+)
+
+const (
+	NotificationStatusPending = "PENDING"
+	NotificationStatusSent    = "SENT"
+	NotificationStatusFailed  = "FAILED"
+)
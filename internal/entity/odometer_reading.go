@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// OdometerReading is one km value reported for a car. Readings that do not
+// fit the car's last known odometer are kept but flagged, and only move the
+// car's current_km once a reviewer accepts them.
+type OdometerReading struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID         int64      `gorm:"not null" json:"car_id"`
+	Car           *Car       `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	Km            int        `gorm:"not null" json:"km"`
+	PreviousKm    int        `gorm:"not null" json:"previous_km"`    // The car's current_km before this reading
+	Source        string     `gorm:"size:20;not null" json:"source"` // CHECKOUT, CHECKIN, MAINTENANCE, FUEL, MANUAL
+	TripID        *int64     `json:"trip_id"`
+	MaintenanceID *int64     `json:"maintenance_id"`
+	FuelLogID     *int64     `json:"fuel_log_id"`
+	RecordedBy    *int64     `json:"recorded_by"`
+	RecordedAt    time.Time  `gorm:"not null" json:"recorded_at"`
+	Flagged       bool       `gorm:"not null" json:"flagged"`
+	FlagReason    string     `gorm:"size:255" json:"flag_reason"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewedBy    *int64     `json:"reviewed_by"`
+	ReviewNote    string     `gorm:"type:text" json:"review_note"`
+	Accepted      *bool      `json:"accepted"` // Review decision; nil until reviewed
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (OdometerReading) TableName() string {
+	return "odometer_readings"
+}
+
+const (
+	OdometerSourceCheckout    = "CHECKOUT"
+	OdometerSourceCheckin     = "CHECKIN"
+	OdometerSourceMaintenance = "MAINTENANCE"
+	OdometerSourceFuel        = "FUEL"
+	OdometerSourceManual      = "MANUAL"
+)
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// Part is a spare part kept in the in-house garage. Stock only changes
+// through stock movements.
+type Part struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PartNumber      string    `gorm:"size:50;not null" json:"part_number"` // Unique, upper case
+	Name            string    `gorm:"size:100;not null" json:"name"`
+	Category        string    `gorm:"size:50" json:"category"`                                // E.g. tyre, oil, filter
+	Unit            string    `gorm:"size:20;not null;default:'pcs'" json:"unit"`             // pcs, liter, set
+	UnitCost        float64   `gorm:"type:decimal(15,2);not null;default:0" json:"unit_cost"` // Last purchase price
+	Stock           float64   `gorm:"type:decimal(12,3);not null;default:0" json:"stock"`
+	ReorderLevel    float64   `gorm:"type:decimal(12,3);not null;default:0" json:"reorder_level"`    // Minimum stock before reordering
+	ReorderQuantity float64   `gorm:"type:decimal(12,3);not null;default:0" json:"reorder_quantity"` // Usual reorder quantity
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Part) TableName() string {
+	return "parts"
+}
+
+// StockMovement is one change to a part's stock. Quantity is positive when
+// stock comes in and negative when it goes out.
+type StockMovement struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PartID        int64     `gorm:"not null" json:"part_id"`
+	Part          *Part     `gorm:"foreignKey:PartID" json:"part,omitempty"`
+	Type          string    `gorm:"size:20;not null" json:"type"` // RECEIVE, ISSUE, RETURN, ADJUST
+	Quantity      float64   `gorm:"type:decimal(12,3);not null" json:"quantity"`
+	StockAfter    float64   `gorm:"type:decimal(12,3);not null" json:"stock_after"`
+	UnitCost      *float64  `gorm:"type:decimal(15,2)" json:"unit_cost"`
+	MaintenanceID *int64    `json:"maintenance_id"`            // Work order for ISSUE and RETURN
+	Reference     string    `gorm:"size:100" json:"reference"` // E.g. the supplier's invoice number
+	Note          string    `gorm:"type:text" json:"note"`
+	CreatedBy     *int64    `json:"created_by"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+// RECEIVE from a supplier, ISSUE to a work order, RETURN from a cancelled or
+// reduced work order, ADJUST from a stock count.
+const (
+	StockMovementReceive = "RECEIVE"
+	StockMovementIssue   = "ISSUE"
+	StockMovementReturn  = "RETURN"
+	StockMovementAdjust  = "ADJUST"
+)
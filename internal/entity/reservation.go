@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+type Reservation struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID        int64     `gorm:"not null" json:"car_id"`
+	Car          *Car      `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	DriverID     *int64    `json:"driver_id"` // Empty when the driver is chosen at pickup
+	Driver       *Driver   `gorm:"foreignKey:DriverID" json:"driver,omitempty"`
+	RequesterID  *int64    `json:"requester_id"` // User who made the booking
+	Requester    *User     `gorm:"foreignKey:RequesterID" json:"requester,omitempty"`
+	StartTime    time.Time `gorm:"not null" json:"start_time"`
+	EndTime      time.Time `gorm:"not null" json:"end_time"`
+	Purpose      string    `gorm:"size:255;not null" json:"purpose"`
+	Notes        string    `gorm:"type:text" json:"notes"`
+	Status       string    `gorm:"size:20;not null;default:'CONFIRMED'" json:"status"` // CONFIRMED, FULFILLED, CANCELLED
+	TripID       *int64    `json:"trip_id"`                                            // Trip that fulfilled this booking
+	CancelReason string    `gorm:"type:text" json:"cancel_reason"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Reservation) TableName() string {
+	return "reservations"
+}
+
+const (
+	ReservationStatusConfirmed = "CONFIRMED"
+	ReservationStatusFulfilled = "FULFILLED"
+	ReservationStatusCancelled = "CANCELLED"
+)
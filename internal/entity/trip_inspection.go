@@ -0,0 +1,78 @@
+package entity
+
+import "time"
+
+// InspectionChecklistItem is a configurable question asked during a
+// checkout or checkin inspection.
+type InspectionChecklistItem struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string    `gorm:"size:50;not null;unique" json:"code"`
+	Label     string    `gorm:"size:150;not null" json:"label"`
+	Stage     string    `gorm:"size:20;not null" json:"stage"`                    // CHECKOUT, CHECKIN, BOTH
+	Required  bool      `gorm:"not null" json:"required"`                         // Must be answered during the inspection
+	OnFail    string    `gorm:"size:20;not null;default:'IGNORE'" json:"on_fail"` // IGNORE, BLOCK, MAINTENANCE
+	Active    bool      `gorm:"not null" json:"active"`
+	SortOrder int       `gorm:"not null;default:0" json:"sort_order"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (InspectionChecklistItem) TableName() string {
+	return "inspection_checklist_items"
+}
+
+type TripInspection struct {
+	ID            int64                `gorm:"primaryKey;autoIncrement" json:"id"`
+	TripID        int64                `gorm:"not null" json:"trip_id"`
+	Stage         string               `gorm:"size:20;not null" json:"stage"` // CHECKOUT, CHECKIN
+	FuelLevel     *int                 `json:"fuel_level"`                    // Percent 0-100
+	DamageNotes   string               `gorm:"type:text" json:"damage_notes"`
+	Passed        bool                 `gorm:"not null" json:"passed"`
+	MaintenanceID *int64               `json:"maintenance_id"` // Work order opened from this inspection
+	Items         []TripInspectionItem `gorm:"foreignKey:InspectionID" json:"items,omitempty"`
+	CreatedAt     time.Time            `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TripInspection) TableName() string {
+	return "trip_inspections"
+}
+
+type TripInspectionItem struct {
+	ID              int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	InspectionID    int64  `gorm:"not null" json:"inspection_id"`
+	ChecklistItemID int64  `gorm:"not null" json:"checklist_item_id"`
+	Code            string `gorm:"size:50;not null" json:"code"`
+	Label           string `gorm:"size:150;not null" json:"label"`
+	OK              bool   `gorm:"column:ok;not null" json:"ok"`
+	Notes           string `gorm:"type:text" json:"notes"`
+}
+
+func (TripInspectionItem) TableName() string {
+	return "trip_inspection_items"
+}
+
+type TripInspectionPhoto struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	TripID      int64     `gorm:"not null;index" json:"trip_id"`
+	Stage       string    `gorm:"size:20;not null" json:"stage"`
+	FileKey     string    `gorm:"size:255;not null" json:"-"`
+	FileName    string    `gorm:"size:255" json:"file_name"`
+	ContentType string    `gorm:"size:100" json:"content_type"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TripInspectionPhoto) TableName() string {
+	return "trip_inspection_photos"
+}
+
+const (
+	InspectionStageCheckout = "CHECKOUT"
+	InspectionStageCheckin  = "CHECKIN"
+	InspectionStageBoth     = "BOTH"
+)
+
+const (
+	InspectionOnFailIgnore      = "IGNORE"
+	InspectionOnFailBlock       = "BLOCK"       // Checkout is refused; at checkin the car goes to the workshop
+	InspectionOnFailMaintenance = "MAINTENANCE" // Opens a work order; the car may still be driven
+)
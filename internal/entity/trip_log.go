@@ -13,9 +13,21 @@ type TripLog struct {
 	StartKm   int        `json:"start_km"`
 	EndKm     *int       `json:"end_km"`
 	Notes     string     `gorm:"type:text" json:"notes"`
-	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	Status    string     `gorm:"size:20;not null;default:'IN_PROGRESS'" json:"status"` // IN_PROGRESS, COMPLETED
+	// GPS track summary, computed at checkin
+	GpsDistanceKm    *float64  `gorm:"type:decimal(10,2)" json:"gps_distance_km"`
+	MovingSeconds    *int      `json:"moving_seconds"`
+	IdleSeconds      *int      `json:"idle_seconds"`
+	MaxSpeedKmh      *float64  `gorm:"type:decimal(6,2)" json:"max_speed_kmh"`
+	DistanceMismatch bool      `gorm:"not null" json:"distance_mismatch"` // GPS and odometer distances differ by more than the tolerance
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
 
 func (TripLog) TableName() string {
 	return "trip_logs"
 }
+
+const (
+	TripStatusInProgress = "IN_PROGRESS"
+	TripStatusCompleted  = "COMPLETED"
+)
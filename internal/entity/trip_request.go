@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// TripRequest is a driver's request to use a car, approved or rejected by a
+// manager before checkout. Once checked out it follows the linked trip.
+type TripRequest struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CarID        int64      `gorm:"not null" json:"car_id"`
+	Car          *Car       `gorm:"foreignKey:CarID" json:"car,omitempty"`
+	DriverID     int64      `gorm:"not null" json:"driver_id"`
+	Driver       *Driver    `gorm:"foreignKey:DriverID" json:"driver,omitempty"`
+	RequesterID  *int64     `json:"requester_id"`
+	Requester    *User      `gorm:"foreignKey:RequesterID" json:"requester,omitempty"`
+	Purpose      string     `gorm:"size:255;not null" json:"purpose"`
+	Destination  string     `gorm:"size:255" json:"destination"`
+	PlannedStart *time.Time `json:"planned_start"`
+	PlannedEnd   *time.Time `json:"planned_end"`
+	Status       string     `gorm:"size:20;not null;default:'REQUESTED'" json:"status"`
+	ApproverID   *int64     `json:"approver_id"` // Manager who approved or rejected it
+	Approver     *User      `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+	ApprovedAt   *time.Time `json:"approved_at"`
+	ApprovalNote string     `gorm:"type:text" json:"approval_note"`
+	RejectedAt   *time.Time `json:"rejected_at"`
+	RejectReason string     `gorm:"type:text" json:"reject_reason"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CancelReason string     `gorm:"type:text" json:"cancel_reason"`
+	TripID       *int64     `json:"trip_id"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (TripRequest) TableName() string {
+	return "trip_requests"
+}
+
+// REQUESTED -> APPROVED/REJECTED -> CHECKED_OUT -> COMPLETED, CANCELLED before checkout
+const (
+	TripRequestStatusRequested  = "REQUESTED"
+	TripRequestStatusApproved   = "APPROVED"
+	TripRequestStatusRejected   = "REJECTED"
+	TripRequestStatusCheckedOut = "CHECKED_OUT"
+	TripRequestStatusCompleted  = "COMPLETED"
+	TripRequestStatusCancelled  = "CANCELLED"
+)
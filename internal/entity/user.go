@@ -14,3 +14,10 @@ type User struct {
 func (User) TableName() string {
 	return "users"
 }
+
+const (
+	UserRoleAdmin    = "admin"
+	UserRoleOperator = "operator"
+	UserRoleManager  = "manager" // Approves trip requests
+	UserRoleDriver   = "driver"
+)
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// Webhook is an external system that wants domain events POSTed to URL.
+// Every delivery is signed with Secret.
+type Webhook struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string    `gorm:"size:100;not null" json:"name"`
+	URL        string    `gorm:"size:500;not null" json:"url"`
+	Secret     string    `gorm:"size:100;not null" json:"-"`
+	EventTypes string    `gorm:"size:255;not null" json:"event_types"` // Comma separated, e.g. trip.checked_out,car.status_changed
+	Active     bool      `gorm:"not null" json:"active"`
+	CreatedBy  *int64    `json:"created_by"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is one event queued for one webhook and the log of its
+// attempts. Redeliveries are new rows pointing at the original.
+type WebhookDelivery struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WebhookID      int64      `gorm:"not null" json:"webhook_id"`
+	Webhook        *Webhook   `gorm:"foreignKey:WebhookID" json:"webhook,omitempty"`
+	EventID        string     `gorm:"size:40;not null" json:"event_id"`
+	EventType      string     `gorm:"size:50;not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`                // JSON body exactly as signed
+	Status         string     `gorm:"size:20;not null;default:'PENDING'" json:"status"` // PENDING, SUCCEEDED, FAILED
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null" json:"next_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	ResponseBody   string     `gorm:"type:text" json:"response_body"` // Truncated to 1 KB
+	LastError      string     `gorm:"type:text" json:"last_error"`
+	DurationMs     *int64     `json:"duration_ms"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	RedeliveryOf   *int64     `json:"redelivery_of"` // Original delivery when redelivered manually
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+const (
+	WebhookEventTripCheckedOut     = "trip.checked_out"
+	WebhookEventTripCheckedIn      = "trip.checked_in"
+	WebhookEventMaintenanceCreated = "maintenance.created"
+	WebhookEventCarStatusChanged   = "car.status_changed"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "PENDING"
+	WebhookDeliveryStatusSucceeded = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    = "FAILED"
+)
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// Workshop is a vendor that services the fleet. Names are unique regardless
+// of case and spacing.
+type Workshop struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	Address     string    `gorm:"type:text" json:"address"`
+	Lat         *float64  `json:"lat"`
+	Lng         *float64  `json:"lng"`
+	ContactName string    `gorm:"size:100" json:"contact_name"`
+	Phone       string    `gorm:"size:20" json:"phone"`
+	Email       string    `gorm:"size:100" json:"email"`
+	Specialties string    `gorm:"size:255" json:"specialties"`     // Comma separated, e.g. engine,body,tires
+	Rating      *float64  `gorm:"type:decimal(2,1)" json:"rating"` // 0.0 - 5.0
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Workshop) TableName() string {
+	return "workshops"
+}
@@ -0,0 +1,58 @@
+// Package geo holds the small amount of spherical geometry the fleet needs:
+// distances between GPS fixes and point-in-shape tests for geofences.
+package geo
+
+import "math"
+
+const earthRadiusM = 6371000.0
+
+// Point is a WGS84 coordinate.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Distance returns the great-circle distance between two points in meters.
+func Distance(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusM * math.Asin(math.Sqrt(h))
+}
+
+// InCircle reports whether p lies within radiusM meters of center.
+func InCircle(p, center Point, radiusM float64) bool {
+	return Distance(p, center) <= radiusM
+}
+
+// InPolygon reports whether p lies inside the polygon. The first ring is the
+// outer boundary and any further rings are holes, as in GeoJSON. Rings are
+// treated as planar, which is accurate enough for city-sized areas.
+func InPolygon(p Point, rings [][]Point) bool {
+	if len(rings) == 0 || !inRing(p, rings[0]) {
+		return false
+	}
+	for _, hole := range rings[1:] {
+		if inRing(p, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRing is the even-odd ray casting test.
+func inRing(p Point, ring []Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,99 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	GeometryPoint      = "Point"
+	GeometryPolygon    = "Polygon"
+	GeometryLineString = "LineString"
+)
+
+// Geometry is the subset of a GeoJSON geometry object used by geofences and
+// trip routes.
+// Coordinates stay raw until the type is known.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParsePoint decodes a GeoJSON Point. GeoJSON orders positions as [lng, lat].
+func ParsePoint(raw []byte) (Point, error) {
+	var g Geometry
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return Point{}, errors.New("geometry must be a GeoJSON object")
+	}
+	if g.Type != GeometryPoint {
+		return Point{}, fmt.Errorf("geometry type must be %s", GeometryPoint)
+	}
+
+	var pos []float64
+	if err := json.Unmarshal(g.Coordinates, &pos); err != nil {
+		return Point{}, errors.New("point coordinates must be [lng, lat]")
+	}
+	return toPoint(pos)
+}
+
+// ParsePolygon decodes a GeoJSON Polygon. Each ring needs at least three
+// distinct positions; an unclosed ring is accepted and closed implicitly.
+func ParsePolygon(raw []byte) ([][]Point, error) {
+	var g Geometry
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return nil, errors.New("geometry must be a GeoJSON object")
+	}
+	if g.Type != GeometryPolygon {
+		return nil, fmt.Errorf("geometry type must be %s", GeometryPolygon)
+	}
+
+	var coords [][][]float64
+	if err := json.Unmarshal(g.Coordinates, &coords); err != nil {
+		return nil, errors.New("polygon coordinates must be an array of [lng, lat] rings")
+	}
+	if len(coords) == 0 {
+		return nil, errors.New("polygon needs at least one ring")
+	}
+
+	rings := make([][]Point, 0, len(coords))
+	for _, ring := range coords {
+		points := make([]Point, 0, len(ring))
+		for _, pos := range ring {
+			p, err := toPoint(pos)
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, p)
+		}
+		if len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+		if len(points) < 3 {
+			return nil, errors.New("polygon rings need at least three positions")
+		}
+		rings = append(rings, points)
+	}
+	return rings, nil
+}
+
+func toPoint(pos []float64) (Point, error) {
+	if len(pos) < 2 {
+		return Point{}, errors.New("positions must be [lng, lat]")
+	}
+	p := Point{Lat: pos[1], Lng: pos[0]}
+	if p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+		return Point{}, errors.New("positions must be [lng, lat] within valid ranges")
+	}
+	return p, nil
+}
+
+// LineString encodes the points as GeoJSON LineString coordinates.
+func LineString(points []Point) Geometry {
+	coords := make([][2]float64, 0, len(points))
+	for _, p := range points {
+		coords = append(coords, [2]float64{p.Lng, p.Lat})
+	}
+	raw, _ := json.Marshal(coords)
+	return Geometry{Type: GeometryLineString, Coordinates: raw}
+}
@@ -0,0 +1,50 @@
+package geo
+
+import "time"
+
+// TrackPoint is a GPS fix with the time it was recorded.
+type TrackPoint struct {
+	Point
+	At time.Time
+}
+
+// TrackSummary describes a GPS track between its first and last fix.
+type TrackSummary struct {
+	DistanceM   float64
+	MovingTime  time.Duration
+	IdleTime    time.Duration
+	MaxSpeedKmh float64
+	Points      int
+}
+
+// SummarizeTrack walks a time-ordered track segment by segment. Segments
+// slower than idleKmh count as idle time and add no distance, which keeps GPS
+// jitter of a parked car out of the total. Segments faster than maxKmh are
+// treated as bad fixes and skipped entirely.
+func SummarizeTrack(points []TrackPoint, idleKmh, maxKmh float64) TrackSummary {
+	summary := TrackSummary{Points: len(points)}
+	for i := 1; i < len(points); i++ {
+		prev, cur := points[i-1], points[i]
+		elapsed := cur.At.Sub(prev.At)
+		if elapsed <= 0 {
+			continue
+		}
+
+		meters := Distance(prev.Point, cur.Point)
+		speedKmh := meters / elapsed.Seconds() * 3.6
+		if speedKmh > maxKmh {
+			continue
+		}
+		if speedKmh < idleKmh {
+			summary.IdleTime += elapsed
+			continue
+		}
+
+		summary.DistanceM += meters
+		summary.MovingTime += elapsed
+		if speedKmh > summary.MaxSpeedKmh {
+			summary.MaxSpeedKmh = speedKmh
+		}
+	}
+	return summary
+}
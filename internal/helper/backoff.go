@@ -0,0 +1,16 @@
+package helper
+
+import "time"
+
+// Backoff returns the wait before the next retry: base after the first
+// attempt, doubling with every further attempt, never more than max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,22 @@
+package helper
+
+import "github.com/gofiber/fiber/v2"
+
+// BindError wraps a request body that could not be parsed.
+type BindError struct {
+	Err error
+}
+
+func (e *BindError) Error() string {
+	return e.Err.Error()
+}
+
+// BindAndValidate parses the request body into out and validates it.
+// The returned error is rendered by GlobalErrorHandler: 400 for a malformed
+// body and 422 with field-level details for validation failures.
+func BindAndValidate(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		return &BindError{Err: err}
+	}
+	return ValidateStruct(out)
+}
@@ -1,12 +1,23 @@
 package helper
 
 import (
+	"errors"
 	"fleet-monitor/internal/model"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func GlobalErrorHandler(c *fiber.Ctx, err error) error {
+	var bindErr *BindError
+	if errors.As(err, &bindErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse("Invalid request", bindErr.Error()))
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(model.ValidationErrorResponse(validationErr.Error(), validationErr.Fields))
+	}
+
 	code := fiber.StatusInternalServerError
 	message := "Internal Server Error"
 
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseTimeParam parses a query string value into a time. Values without a
+// timezone are interpreted in the server's local time (Asia/Jakarta).
+// An empty value returns nil.
+func ParseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, errors.New("invalid time format, use RFC3339 or YYYY-MM-DD")
+}
+
+// ParseDurationParam parses a duration query value. Besides Go durations
+// such as "72h" it accepts days ("30d"), weeks ("2w") and a bare number of
+// days ("30"). An empty value returns def.
+func ParseDurationParam(value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	unit := time.Duration(0)
+	number := value
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit, number = 24*time.Hour, strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		unit, number = 7*24*time.Hour, strings.TrimSuffix(value, "w")
+	default:
+		if n, err := strconv.Atoi(value); err == nil {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, errors.New("invalid duration, use e.g. 30d, 2w or 72h")
+		}
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 {
+		return 0, errors.New("invalid duration, use e.g. 30d, 2w or 72h")
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,22 @@
+package helper
+
+import "gorm.io/gorm"
+
+// TxManager runs a unit of work inside a single database transaction.
+// Repositories join the transaction through their WithTx method.
+type TxManager interface {
+	WithTransaction(fn func(tx *gorm.DB) error) error
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{db: db}
+}
+
+// WithTransaction commits when fn returns nil and rolls back on error or panic.
+func (m *txManager) WithTransaction(fn func(tx *gorm.DB) error) error {
+	return m.db.Transaction(fn)
+}
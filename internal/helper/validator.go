@@ -0,0 +1,162 @@
+package helper
+
+import (
+	"fleet-monitor/internal/model"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// Indonesian license plates, e.g. B 1234 CD, AB 123 X, D 1 A
+	platePattern = regexp.MustCompile(`^[A-Z]{1,2} ?[0-9]{1,4}( ?[A-Z]{1,3})?$`)
+	// SIM numbers: 12-16 digits, optionally grouped with spaces or hyphens
+	simPattern = regexp.MustCompile(`^[0-9]{4}([ -]?[0-9]{4})([ -]?[0-9]{4,8})$`)
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	// Report JSON field names instead of Go struct field names
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	v.RegisterValidation("plate_no", func(fl validator.FieldLevel) bool {
+		return platePattern.MatchString(strings.ToUpper(strings.TrimSpace(fl.Field().String())))
+	})
+	v.RegisterValidation("sim_no", func(fl validator.FieldLevel) bool {
+		return simPattern.MatchString(strings.TrimSpace(fl.Field().String()))
+	})
+
+	return v
+}
+
+// ValidationError carries the field-level errors of a failed validation.
+type ValidationError struct {
+	Fields []model.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.Field+" "+f.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateStruct checks s against its validate tags and returns a *ValidationError on failure.
+func ValidateStruct(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	fields := make([]model.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, model.FieldError{
+			Field:   fieldPath(fe),
+			Tag:     fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// ValidateVar checks a single value against tag and reports failures under
+// field, for rules that depend on more than the request body.
+func ValidateVar(field string, value interface{}, tag string) error {
+	err := validate.Var(value, tag)
+	if err == nil {
+		return nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	fields := make([]model.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, model.FieldError{
+			Field:   field,
+			Tag:     fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// fieldPath strips the root struct name from the namespace, e.g. CarRequest.license_plate -> license_plate.
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", fe.Param())
+	case "gtfield":
+		return fmt.Sprintf("must be greater than %s", snakeCase(fe.Param()))
+	case "latitude":
+		return "must be a valid latitude"
+	case "longitude":
+		return "must be a valid longitude"
+	case "plate_no":
+		return "must be a valid Indonesian license plate (e.g. B 1234 CD)"
+	case "sim_no":
+		return "must be a valid SIM number (12-16 digits)"
+	default:
+		return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+	}
+}
+
+// snakeCase turns a Go field name used as a tag parameter (e.g. StartTime)
+// into its JSON spelling (start_time).
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package model
+
+import "time"
+
+type AlertRuleRequest struct {
+	Name       string  `json:"name" validate:"required,max=100"`
+	Type       string  `json:"type" validate:"required,oneof=SPEEDING STALE_GPS LONG_TRIP UNAUTHORIZED_MOVEMENT GEOFENCE_BREACH"`
+	Severity   string  `json:"severity" validate:"required,oneof=INFO WARNING CRITICAL"`
+	Threshold  float64 `json:"threshold" validate:"min=0"`
+	CarID      *int64  `json:"car_id"`
+	GeofenceID *int64  `json:"geofence_id"`
+	Transition string  `json:"transition" validate:"omitempty,oneof=ENTER EXIT"`
+	Active     *bool   `json:"active"`
+}
+
+type AlertRuleResponse struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	Severity     string    `json:"severity"`
+	Threshold    float64   `json:"threshold"`
+	CarID        *int64    `json:"car_id"`
+	LicensePlate string    `json:"license_plate,omitempty"`
+	GeofenceID   *int64    `json:"geofence_id"`
+	GeofenceName string    `json:"geofence_name,omitempty"`
+	Transition   string    `json:"transition"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type AlertRuleListParams struct {
+	Page   int
+	Limit  int
+	Type   string
+	Active *bool
+}
+
+type AlertResolveRequest struct {
+	Note string `json:"note"`
+}
+
+type AlertResponse struct {
+	ID             int64      `json:"id"`
+	RuleID         *int64     `json:"rule_id"`
+	RuleName       string     `json:"rule_name,omitempty"`
+	CarID          int64      `json:"car_id"`
+	LicensePlate   string     `json:"license_plate,omitempty"`
+	TripID         *int64     `json:"trip_id"`
+	GeofenceID     *int64     `json:"geofence_id"`
+	Type           string     `json:"type"`
+	Severity       string     `json:"severity"`
+	Message        string     `json:"message"`
+	Value          *float64   `json:"value"`
+	Lat            *float64   `json:"lat"`
+	Lng            *float64   `json:"lng"`
+	Status         string     `json:"status"`
+	TriggeredAt    time.Time  `json:"triggered_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	AcknowledgedBy *int64     `json:"acknowledged_by"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	ResolvedBy     *int64     `json:"resolved_by"`
+	ResolutionNote string     `json:"resolution_note"`
+}
+
+type AlertListParams struct {
+	Page     int
+	Limit    int
+	CarID    int64
+	Type     string
+	Severity string
+	Status   string
+	From     *time.Time
+	To       *time.Time
+}
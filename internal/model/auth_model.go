@@ -6,9 +6,10 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"`
-	User      UserResponse `json:"user"`
+	Token     string          `json:"token"`
+	ExpiresAt int64           `json:"expires_at"`
+	User      UserResponse    `json:"user"`
+	Driver    *DriverResponse `json:"driver,omitempty"`
 }
 
 type UserResponse struct {
@@ -20,5 +21,10 @@ type UserResponse struct {
 type RegisterRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=100"`
 	Password string `json:"password" validate:"required,min=6"`
-	Role     string `json:"role" validate:"omitempty,oneof=admin operator"`
+	Role     string `json:"role" validate:"omitempty,oneof=admin operator manager driver"`
+}
+
+type MeResponse struct {
+	User   UserResponse    `json:"user"`
+	Driver *DriverResponse `json:"driver,omitempty"`
 }
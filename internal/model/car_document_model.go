@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// CarDocumentRequest is sent as multipart/form-data together with an optional "file" part.
+type CarDocumentRequest struct {
+	Type       string `json:"type" form:"type" validate:"required,oneof=STNK PAJAK KIR INSURANCE"`
+	Number     string `json:"number" form:"number" validate:"omitempty,max=100"`
+	IssueDate  string `json:"issue_date" form:"issue_date" validate:"omitempty,datetime=2006-01-02"`
+	ExpiryDate string `json:"expiry_date" form:"expiry_date" validate:"required,datetime=2006-01-02"`
+	Notes      string `json:"notes" form:"notes"`
+}
+
+type CarDocumentResponse struct {
+	ID              int64        `json:"id"`
+	CarID           int64        `json:"car_id"`
+	Car             *CarResponse `json:"car,omitempty"`
+	Type            string       `json:"type"`
+	Number          string       `json:"number"`
+	IssueDate       *time.Time   `json:"issue_date"`
+	ExpiryDate      time.Time    `json:"expiry_date"`
+	DaysToExpiry    int          `json:"days_to_expiry"`
+	Expired         bool         `json:"expired"`
+	FileName        string       `json:"file_name,omitempty"`
+	FileContentType string       `json:"file_content_type,omitempty"`
+	FileURL         string       `json:"file_url,omitempty"`
+	Notes           string       `json:"notes"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
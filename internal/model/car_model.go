@@ -3,32 +3,54 @@ package model
 import "time"
 
 type CarRequest struct {
-	LicensePlate string `json:"license_plate" validate:"required,max=20"`
+	LicensePlate string `json:"license_plate" validate:"required,max=20,plate_no"`
 	Brand        string `json:"brand" validate:"required,max=50"`
 	Model        string `json:"model" validate:"required,max=50"`
 	Year         int    `json:"year" validate:"omitempty,min=1900,max=2100"`
 	Status       string `json:"status" validate:"omitempty,oneof=AVAILABLE IN_USE MAINTENANCE"`
+	// RequiresApproval makes checkout depend on an approved trip request
+	RequiresApproval bool `json:"requires_approval"`
 }
 
 type CarResponse struct {
-	ID              int64           `json:"id"`
-	LicensePlate    string          `json:"license_plate"`
-	Brand           string          `json:"brand"`
-	Model           string          `json:"model"`
-	Year            int             `json:"year"`
-	Status          string          `json:"status"`
-	CurrentDriverID *int64          `json:"current_driver_id"`
-	CurrentDriver   *DriverResponse `json:"current_driver,omitempty"`
-	LastLat         *float64        `json:"last_lat"`
-	LastLng         *float64        `json:"last_lng"`
-	LastUpdateLoc   *time.Time      `json:"last_update_loc"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
+	ID               int64           `json:"id"`
+	LicensePlate     string          `json:"license_plate"`
+	Brand            string          `json:"brand"`
+	Model            string          `json:"model"`
+	Year             int             `json:"year"`
+	Status           string          `json:"status"`
+	CurrentDriverID  *int64          `json:"current_driver_id"`
+	CurrentDriver    *DriverResponse `json:"current_driver,omitempty"`
+	LastLat          *float64        `json:"last_lat"`
+	LastLng          *float64        `json:"last_lng"`
+	LastUpdateLoc    *time.Time      `json:"last_update_loc"`
+	CurrentKm        int             `json:"current_km"`
+	RequiresApproval bool            `json:"requires_approval"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
 }
 
 type UpdateLocationRequest struct {
 	Lat float64 `json:"lat" validate:"required,latitude"`
 	Lng float64 `json:"lng" validate:"required,longitude"`
+	// Device data is optional; without recorded_at the point is stamped on arrival
+	RecordedAt *time.Time `json:"recorded_at"`
+	Speed      *float64   `json:"speed" validate:"omitempty,min=0"`           // km/h
+	Heading    *int       `json:"heading" validate:"omitempty,min=0,max=359"` // degrees from north
+	Accuracy   *float64   `json:"accuracy" validate:"omitempty,min=0"`        // meter
+	Battery    *int       `json:"battery" validate:"omitempty,min=0,max=100"` // percent
+}
+
+// BatchLocationRequest uploads points buffered by a device while offline.
+type BatchLocationRequest struct {
+	Points []UpdateLocationRequest `json:"points" validate:"required,min=1,max=500,dive"`
+}
+
+type BatchLocationResponse struct {
+	Received   int `json:"received"`
+	Accepted   int `json:"accepted"`
+	Duplicates int `json:"duplicates"`
+	Rejected   int `json:"rejected"` // recorded_at too far in the future
 }
 
 type CarListParams struct {
@@ -37,3 +59,21 @@ type CarListParams struct {
 	Status string `query:"status"`
 	Search string `query:"search"`
 }
+
+type CarLocationResponse struct {
+	ID         int64     `json:"id"`
+	CarID      int64     `json:"car_id"`
+	Lat        float64   `json:"lat"`
+	Lng        float64   `json:"lng"`
+	Speed      *float64  `json:"speed"`
+	Heading    *int      `json:"heading"`
+	Accuracy   *float64  `json:"accuracy"`
+	Battery    *int      `json:"battery"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type CarLocationListParams struct {
+	From  *time.Time `query:"from"`
+	To    *time.Time `query:"to"`
+	Limit int        `query:"limit"`
+}
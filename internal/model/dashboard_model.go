@@ -1,13 +1,17 @@
 package model
 
 type DashboardSummary struct {
-	TotalCars       int64           `json:"total_cars"`
-	AvailableCars   int64           `json:"available_cars"`
-	InUseCars       int64           `json:"in_use_cars"`
-	MaintenanceCars int64           `json:"maintenance_cars"`
-	TotalDrivers    int64           `json:"total_drivers"`
-	ActiveDrivers   int64           `json:"active_drivers"`
-	RecentTrips     []TripResponse  `json:"recent_trips"`
+	TotalCars         int64          `json:"total_cars"`
+	AvailableCars     int64          `json:"available_cars"`
+	InUseCars         int64          `json:"in_use_cars"`
+	MaintenanceCars   int64          `json:"maintenance_cars"`
+	TotalDrivers      int64          `json:"total_drivers"`
+	ActiveDrivers     int64          `json:"active_drivers"`
+	ExpiredDocuments  int64          `json:"expired_documents"`
+	ExpiringDocuments int64          `json:"expiring_documents"` // Within the next 30 days
+	FlaggedOdometer   int64          `json:"flagged_odometer"`   // Odometer readings not yet reviewed
+	OpenAlerts        int64          `json:"open_alerts"`        // Alerts not yet resolved
+	RecentTrips       []TripResponse `json:"recent_trips"`
 }
 
 type ActivityItem struct {
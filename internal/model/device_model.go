@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+type DeviceRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// IMEI is required for hardware trackers and ignored for HTTP devices
+	IMEI     string `json:"imei" validate:"omitempty,numeric,min=15,max=17"`
+	Protocol string `json:"protocol" validate:"required,oneof=GT06 TELTONIKA HTTP"`
+	CarID    *int64 `json:"car_id"`
+	Active   bool   `json:"active"`
+}
+
+type DeviceResponse struct {
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	IMEI       *string      `json:"imei"`
+	Protocol   string       `json:"protocol"`
+	CarID      *int64       `json:"car_id"`
+	Car        *CarResponse `json:"car,omitempty"`
+	Active     bool         `json:"active"`
+	LastSeenAt *time.Time   `json:"last_seen_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+type DeviceListParams struct {
+	Page     int
+	Limit    int
+	CarID    int64
+	Protocol string
+	Active   *bool
+	Search   string
+}
+
+type DeviceAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type DeviceAPIKeyResponse struct {
+	ID         int64      `json:"id"`
+	DeviceID   int64      `json:"device_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	CreatedBy  *int64     `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	// Key is the plaintext key, returned only once when it is created
+	Key string `json:"key,omitempty"`
+}
+
+// DeviceIdentity is what an authenticated device API key grants: pushing
+// locations for one car.
+type DeviceIdentity struct {
+	DeviceID int64
+	KeyID    int64
+	CarID    int64
+}
+
+// TrackerPosition is one fix decoded from a tracker protocol frame.
+type TrackerPosition struct {
+	IMEI       string
+	Lat        float64
+	Lng        float64
+	RecordedAt time.Time
+	SpeedKmh   float64
+	Heading    int
+	Satellites int
+	Valid      bool // false when the tracker had no GPS fix
+}
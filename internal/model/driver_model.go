@@ -2,15 +2,22 @@ package model
 
 import "time"
 
+// DriverRequest creates or updates a driver. On update an absent UserID keeps
+// the linked account; UnlinkUser removes it. LicenseNumber is checked against
+// the SIM format by the usecase, and on update only when it changes, so
+// drivers recorded with older numbers can still be edited.
 type DriverRequest struct {
 	Name          string `json:"name" validate:"required,max=100"`
 	PhoneNumber   string `json:"phone_number" validate:"omitempty,max=20"`
 	LicenseNumber string `json:"license_number" validate:"omitempty,max=50"`
 	Status        string `json:"status" validate:"omitempty,oneof=ACTIVE OFF_DUTY"`
+	UserID        *int64 `json:"user_id"`
+	UnlinkUser    bool   `json:"unlink_user" validate:"excluded_with=UserID"`
 }
 
 type DriverResponse struct {
 	ID            int64     `json:"id"`
+	UserID        *int64    `json:"user_id"`
 	Name          string    `json:"name"`
 	PhoneNumber   string    `json:"phone_number"`
 	LicenseNumber string    `json:"license_number"`
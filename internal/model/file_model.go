@@ -0,0 +1,18 @@
+package model
+
+import "io"
+
+// FileUpload is an uploaded file handed from the delivery layer to a usecase.
+type FileUpload struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+// FileDownload is a stored file returned to the delivery layer. The caller closes Content.
+type FileDownload struct {
+	Filename    string
+	ContentType string
+	Content     io.ReadCloser
+}
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+// FuelLogRequest is sent as JSON, or as multipart/form-data together with an
+// optional "receipt" part. DriverID defaults to the trip's driver.
+type FuelLogRequest struct {
+	CarID         int64   `json:"car_id" form:"car_id" validate:"required"`
+	DriverID      *int64  `json:"driver_id" form:"driver_id"`
+	TripID        *int64  `json:"trip_id" form:"trip_id"`
+	FilledAt      string  `json:"filled_at" form:"filled_at" validate:"required"`
+	Liters        float64 `json:"liters" form:"liters" validate:"gt=0"`
+	PricePerLiter float64 `json:"price_per_liter" form:"price_per_liter" validate:"min=0"`
+	OdometerKm    int     `json:"odometer_km" form:"odometer_km" validate:"gt=0"`
+	Station       string  `json:"station" form:"station" validate:"max=100"`
+	FullTank      bool    `json:"full_tank" form:"full_tank"`
+	Notes         string  `json:"notes" form:"notes"`
+}
+
+type FuelLogResponse struct {
+	ID                 int64        `json:"id"`
+	CarID              int64        `json:"car_id"`
+	Car                *CarResponse `json:"car,omitempty"`
+	DriverID           *int64       `json:"driver_id"`
+	DriverName         string       `json:"driver_name,omitempty"`
+	TripID             *int64       `json:"trip_id"`
+	FilledAt           time.Time    `json:"filled_at"`
+	Liters             float64      `json:"liters"`
+	PricePerLiter      float64      `json:"price_per_liter"`
+	TotalCost          float64      `json:"total_cost"`
+	OdometerKm         int          `json:"odometer_km"`
+	Station            string       `json:"station"`
+	FullTank           bool         `json:"full_tank"`
+	ReceiptName        string       `json:"receipt_name,omitempty"`
+	ReceiptURL         string       `json:"receipt_url,omitempty"`
+	Notes              string       `json:"notes"`
+	DistanceKm         *int         `json:"distance_km"`
+	IntervalLiters     *float64     `json:"interval_liters"`
+	KmPerLiter         *float64     `json:"km_per_liter"`
+	BaselineKmPerLiter *float64     `json:"baseline_km_per_liter"`
+	Flagged            bool         `json:"flagged"`
+	FlagReason         string       `json:"flag_reason"`
+	CreatedBy          *int64       `json:"created_by"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+}
+
+type FuelLogListParams struct {
+	Page     int
+	Limit    int
+	CarID    int64
+	DriverID int64
+	TripID   int64
+	Flagged  *bool
+	From     *time.Time
+	To       *time.Time
+}
+
+// FuelEfficiencyParams limits the report to fills in [From, To).
+type FuelEfficiencyParams struct {
+	CarID int64
+	From  *time.Time
+	To    *time.Time
+}
+
+// FuelEfficiencyItem sums a car's fills. KmPerLiter only counts measured
+// intervals between full-tank fills and is nil when there are none;
+// CostPerKm is the average price per litre spread over that efficiency.
+type FuelEfficiencyItem struct {
+	CarID          int64      `json:"car_id"`
+	LicensePlate   string     `json:"license_plate"`
+	Fills          int64      `json:"fills"`
+	Liters         float64    `json:"liters"`
+	TotalCost      float64    `json:"total_cost"`
+	MeasuredKm     int64      `json:"measured_km"`
+	MeasuredLiters float64    `json:"measured_liters"`
+	KmPerLiter     *float64   `json:"km_per_liter"`
+	CostPerKm      *float64   `json:"cost_per_km"`
+	FlaggedFills   int64      `json:"flagged_fills"`
+	LastFilledAt   *time.Time `json:"last_filled_at"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type GeofenceRequest struct {
+	Name        string          `json:"name" validate:"required,max=100"`
+	Description string          `json:"description"`
+	Type        string          `json:"type" validate:"required,oneof=CIRCLE POLYGON"`
+	Geometry    json.RawMessage `json:"geometry" validate:"required"`
+	RadiusM     float64         `json:"radius_m" validate:"min=0"`
+	Active      *bool           `json:"active"`
+}
+
+type GeofenceResponse struct {
+	ID          int64           `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Type        string          `json:"type"`
+	Geometry    json.RawMessage `json:"geometry"`
+	RadiusM     float64         `json:"radius_m,omitempty"`
+	Active      bool            `json:"active"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type GeofenceListParams struct {
+	Page   int   `query:"page"`
+	Limit  int   `query:"limit"`
+	Active *bool `query:"active"`
+}
+
+type GeofenceEventResponse struct {
+	ID           int64     `json:"id"`
+	GeofenceID   int64     `json:"geofence_id"`
+	GeofenceName string    `json:"geofence_name,omitempty"`
+	CarID        int64     `json:"car_id"`
+	LicensePlate string    `json:"license_plate,omitempty"`
+	Type         string    `json:"type"`
+	Lat          float64   `json:"lat"`
+	Lng          float64   `json:"lng"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+type GeofenceEventListParams struct {
+	Page       int
+	Limit      int
+	CarID      int64
+	GeofenceID int64
+	Type       string
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+type InspectionRequest struct {
+	FuelLevel   *int                   `json:"fuel_level" validate:"omitempty,min=0,max=100"`
+	DamageNotes string                 `json:"damage_notes"`
+	Items       []InspectionItemResult `json:"items" validate:"dive"`
+}
+
+type InspectionItemResult struct {
+	Code  string `json:"code" validate:"required"`
+	OK    bool   `json:"ok"`
+	Notes string `json:"notes"`
+}
+
+type ChecklistItemRequest struct {
+	Code      string `json:"code" validate:"required,max=50"`
+	Label     string `json:"label" validate:"required,max=150"`
+	Stage     string `json:"stage" validate:"required,oneof=CHECKOUT CHECKIN BOTH"`
+	Required  bool   `json:"required"`
+	OnFail    string `json:"on_fail" validate:"omitempty,oneof=IGNORE BLOCK MAINTENANCE"`
+	Active    *bool  `json:"active"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type ChecklistItemResponse struct {
+	ID        int64  `json:"id"`
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+	Stage     string `json:"stage"`
+	Required  bool   `json:"required"`
+	OnFail    string `json:"on_fail"`
+	Active    bool   `json:"active"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type TripInspectionResponse struct {
+	ID            int64                        `json:"id"`
+	TripID        int64                        `json:"trip_id"`
+	Stage         string                       `json:"stage"`
+	FuelLevel     *int                         `json:"fuel_level"`
+	DamageNotes   string                       `json:"damage_notes"`
+	Passed        bool                         `json:"passed"`
+	MaintenanceID *int64                       `json:"maintenance_id"`
+	Items         []TripInspectionItemResponse `json:"items"`
+	CreatedAt     time.Time                    `json:"created_at"`
+}
+
+type TripInspectionItemResponse struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+	OK    bool   `json:"ok"`
+	Notes string `json:"notes"`
+}
+
+type InspectionPhotoResponse struct {
+	ID          int64     `json:"id"`
+	TripID      int64     `json:"trip_id"`
+	Stage       string    `json:"stage"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type TripInspectionSummary struct {
+	Inspections []TripInspectionResponse  `json:"inspections"`
+	Photos      []InspectionPhotoResponse `json:"photos"`
+}
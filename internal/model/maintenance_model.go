@@ -2,27 +2,89 @@ package model
 
 import "time"
 
+// MaintenanceRequest creates or edits a work order. Status only applies on
+// create: SCHEDULED by default, IN_PROGRESS to start right away, or
+// COMPLETED to record a service that already happened.
+//
+// With items the cost is computed from them and Cost is ignored. On update,
+// leaving Items out keeps the current items; an empty list removes them.
+//
+// The workshop is picked by WorkshopID. WorkshopName is still accepted and is
+// matched to a workshop ignoring case and spacing; an unknown name adds one.
 type MaintenanceRequest struct {
-	CarID        int64     `json:"car_id" validate:"required"`
-	ServiceDate  time.Time `json:"service_date" validate:"required"`
-	Description  string    `json:"description" validate:"required"`
-	Cost         float64   `json:"cost" validate:"min=0"`
-	WorkshopName string    `json:"workshop_name"`
+	CarID        int64                    `json:"car_id" validate:"required"`
+	ServiceDate  time.Time                `json:"service_date" validate:"required"`
+	Description  string                   `json:"description" validate:"required"`
+	Cost         float64                  `json:"cost" validate:"min=0"`
+	WorkshopID   *int64                   `json:"workshop_id"`
+	WorkshopName string                   `json:"workshop_name" validate:"max=100"`
+	PlanID       *int64                   `json:"plan_id"`
+	OdometerKm   *int                     `json:"odometer_km" validate:"omitempty,min=0"`
+	Status       string                   `json:"status" validate:"omitempty,oneof=SCHEDULED IN_PROGRESS COMPLETED"`
+	Items        []MaintenanceItemRequest `json:"items" validate:"omitempty,dive"`
+}
+
+// MaintenanceItemRequest is one line of a work order. A PartID takes the part
+// from the garage's own stock; its part number, name and, when UnitPrice is
+// zero, its unit cost come from the catalog.
+type MaintenanceItemRequest struct {
+	Type        string  `json:"type" validate:"required,oneof=PART LABOUR OTHER"`
+	PartID      *int64  `json:"part_id"`
+	PartNumber  string  `json:"part_number" validate:"max=50"`
+	Description string  `json:"description" validate:"required_without=PartID,max=255"`
+	Quantity    float64 `json:"quantity" validate:"gt=0"`
+	UnitPrice   float64 `json:"unit_price" validate:"min=0"`
+	TaxRate     float64 `json:"tax_rate" validate:"min=0,max=100"`
+}
+
+// MaintenanceCompleteRequest closes a work order. Cost replaces the estimate
+// when given and the work order has no items; OdometerKm is the workshop's
+// reading.
+type MaintenanceCompleteRequest struct {
+	Cost       *float64 `json:"cost" validate:"omitempty,min=0"`
+	OdometerKm *int     `json:"odometer_km" validate:"omitempty,min=0"`
 }
 
 type MaintenanceResponse struct {
-	ID           int64        `json:"id"`
-	CarID        int64        `json:"car_id"`
-	Car          *CarResponse `json:"car,omitempty"`
-	ServiceDate  time.Time    `json:"service_date"`
-	Description  string       `json:"description"`
-	Cost         float64      `json:"cost"`
-	WorkshopName string       `json:"workshop_name"`
-	CreatedAt    time.Time    `json:"created_at"`
+	ID           int64                     `json:"id"`
+	CarID        int64                     `json:"car_id"`
+	Car          *CarResponse              `json:"car,omitempty"`
+	ServiceDate  time.Time                 `json:"service_date"`
+	Description  string                    `json:"description"`
+	Cost         float64                   `json:"cost"`
+	PartsCost    float64                   `json:"parts_cost"`
+	LabourCost   float64                   `json:"labour_cost"`
+	OtherCost    float64                   `json:"other_cost"`
+	TaxAmount    float64                   `json:"tax_amount"`
+	WorkshopID   *int64                    `json:"workshop_id"`
+	WorkshopName string                    `json:"workshop_name"`
+	PlanID       *int64                    `json:"plan_id"`
+	Status       string                    `json:"status"`
+	StartedAt    *time.Time                `json:"started_at"`
+	CompletedAt  *time.Time                `json:"completed_at"`
+	CancelledAt  *time.Time                `json:"cancelled_at"`
+	Items        []MaintenanceItemResponse `json:"items"`
+	CreatedAt    time.Time                 `json:"created_at"`
+}
+
+type MaintenanceItemResponse struct {
+	ID          int64   `json:"id"`
+	Type        string  `json:"type"`
+	PartID      *int64  `json:"part_id"`
+	PartNumber  string  `json:"part_number"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	TaxRate     float64 `json:"tax_rate"`
+	Subtotal    float64 `json:"subtotal"`
+	TaxAmount   float64 `json:"tax_amount"`
+	Total       float64 `json:"total"`
 }
 
 type MaintenanceListParams struct {
-	Page   int   `query:"page"`
-	Limit  int   `query:"limit"`
-	CarID  int64 `query:"car_id"`
+	Page       int    `query:"page"`
+	Limit      int    `query:"limit"`
+	CarID      int64  `query:"car_id"`
+	Status     string `query:"status"`
+	WorkshopID int64  `query:"workshop_id"`
 }
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+type MaintenancePlanRequest struct {
+	CarID          *int64 `json:"car_id"`
+	Brand          string `json:"brand" validate:"omitempty,max=50"`
+	Model          string `json:"model" validate:"omitempty,max=50"`
+	Name           string `json:"name" validate:"required,max=100"`
+	IntervalKm     *int   `json:"interval_km" validate:"omitempty,min=1"`
+	IntervalMonths *int   `json:"interval_months" validate:"omitempty,min=1"`
+	WarningKm      int    `json:"warning_km" validate:"min=0"`
+	WarningDays    int    `json:"warning_days" validate:"min=0"`
+	Active         *bool  `json:"active"`
+}
+
+type MaintenancePlanResponse struct {
+	ID             int64        `json:"id"`
+	CarID          *int64       `json:"car_id"`
+	Car            *CarResponse `json:"car,omitempty"`
+	Brand          string       `json:"brand"`
+	Model          string       `json:"model"`
+	Name           string       `json:"name"`
+	IntervalKm     *int         `json:"interval_km"`
+	IntervalMonths *int         `json:"interval_months"`
+	WarningKm      int          `json:"warning_km"`
+	WarningDays    int          `json:"warning_days"`
+	Active         bool         `json:"active"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+type MaintenancePlanListParams struct {
+	Page  int   `query:"page"`
+	Limit int   `query:"limit"`
+	CarID int64 `query:"car_id"`
+}
+
+type MaintenanceDueItem struct {
+	PlanID          int64      `json:"plan_id"`
+	PlanName        string     `json:"plan_name"`
+	CarID           int64      `json:"car_id"`
+	LicensePlate    string     `json:"license_plate"`
+	Brand           string     `json:"brand"`
+	Model           string     `json:"model"`
+	Status          string     `json:"status"` // OVERDUE, UPCOMING
+	LastServiceDate *time.Time `json:"last_service_date"`
+	LastServiceKm   *int       `json:"last_service_km"`
+	CurrentKm       *int       `json:"current_km"`
+	DueDate         *time.Time `json:"due_date"`
+	DueKm           *int       `json:"due_km"`
+	DaysRemaining   *int       `json:"days_remaining"`
+	KmRemaining     *int       `json:"km_remaining"`
+}
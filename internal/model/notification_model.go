@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+type NotificationSubscriptionRequest struct {
+	Channel     string   `json:"channel" validate:"required,oneof=EMAIL WEBHOOK TELEGRAM"`
+	Target      string   `json:"target" validate:"required,max=500"`
+	EventTypes  []string `json:"event_types" validate:"required,min=1,dive,oneof=ALERT TRIP_CHECKOUT TRIP_CHECKIN DOCUMENT_EXPIRY"`
+	MinSeverity string   `json:"min_severity" validate:"omitempty,oneof=INFO WARNING CRITICAL"`
+	Active      *bool    `json:"active"`
+}
+
+type NotificationSubscriptionResponse struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Channel     string    `json:"channel"`
+	Target      string    `json:"target"`
+	EventTypes  []string  `json:"event_types"`
+	MinSeverity string    `json:"min_severity"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type NotificationResponse struct {
+	ID             int64      `json:"id"`
+	SubscriptionID *int64     `json:"subscription_id"`
+	Channel        string     `json:"channel"`
+	Target         string     `json:"target"`
+	EventType      string     `json:"event_type"`
+	Subject        string     `json:"subject"`
+	Body           string     `json:"body"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      string     `json:"last_error"`
+	SentAt         *time.Time `json:"sent_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type NotificationListParams struct {
+	Page      int
+	Limit     int
+	UserID    int64
+	EventType string
+	Status    string
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// OdometerReadingRequest records a manual reading, e.g. after replacing the
+// instrument cluster. It overrides the car's current_km.
+type OdometerReadingRequest struct {
+	Km         int        `json:"km" validate:"min=0"`
+	RecordedAt *time.Time `json:"recorded_at"`
+}
+
+// OdometerReviewRequest decides on a flagged reading. Accepting it applies
+// its km to the car; rejecting it discards the value.
+type OdometerReviewRequest struct {
+	Accept *bool  `json:"accept" validate:"required"`
+	Note   string `json:"note" validate:"required"`
+}
+
+type OdometerReadingResponse struct {
+	ID            int64      `json:"id"`
+	CarID         int64      `json:"car_id"`
+	LicensePlate  string     `json:"license_plate,omitempty"`
+	Km            int        `json:"km"`
+	PreviousKm    int        `json:"previous_km"`
+	Source        string     `json:"source"`
+	TripID        *int64     `json:"trip_id"`
+	MaintenanceID *int64     `json:"maintenance_id"`
+	FuelLogID     *int64     `json:"fuel_log_id"`
+	RecordedBy    *int64     `json:"recorded_by"`
+	RecordedAt    time.Time  `json:"recorded_at"`
+	Flagged       bool       `json:"flagged"`
+	FlagReason    string     `json:"flag_reason"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewedBy    *int64     `json:"reviewed_by"`
+	ReviewNote    string     `json:"review_note"`
+	Accepted      *bool      `json:"accepted"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type OdometerListParams struct {
+	Page     int
+	Limit    int
+	CarID    int64
+	Source   string
+	Flagged  *bool
+	Reviewed *bool
+}
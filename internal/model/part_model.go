@@ -0,0 +1,84 @@
+package model
+
+import "time"
+
+type PartRequest struct {
+	PartNumber      string  `json:"part_number" validate:"required,max=50"`
+	Name            string  `json:"name" validate:"required,max=100"`
+	Category        string  `json:"category" validate:"max=50"`
+	Unit            string  `json:"unit" validate:"max=20"`
+	UnitCost        float64 `json:"unit_cost" validate:"min=0"`
+	ReorderLevel    float64 `json:"reorder_level" validate:"min=0"`
+	ReorderQuantity float64 `json:"reorder_quantity" validate:"min=0"`
+}
+
+type PartResponse struct {
+	ID              int64     `json:"id"`
+	PartNumber      string    `json:"part_number"`
+	Name            string    `json:"name"`
+	Category        string    `json:"category"`
+	Unit            string    `json:"unit"`
+	UnitCost        float64   `json:"unit_cost"`
+	Stock           float64   `json:"stock"`
+	ReorderLevel    float64   `json:"reorder_level"`
+	ReorderQuantity float64   `json:"reorder_quantity"`
+	LowStock        bool      `json:"low_stock"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type PartListParams struct {
+	Page     int
+	Limit    int
+	Search   string
+	Category string
+	LowStock bool
+}
+
+// LowStockResponse is a part at or below its reorder level with the quantity
+// to order: the usual reorder quantity, or enough to get back to the reorder
+// level if that is more.
+type LowStockResponse struct {
+	PartResponse
+	SuggestedQuantity float64 `json:"suggested_quantity"`
+	SuggestedCost     float64 `json:"suggested_cost"`
+}
+
+// StockReceiveRequest books stock delivered by a supplier. UnitCost becomes
+// the part's unit cost when given.
+type StockReceiveRequest struct {
+	Quantity  float64  `json:"quantity" validate:"gt=0"`
+	UnitCost  *float64 `json:"unit_cost" validate:"omitempty,min=0"`
+	Reference string   `json:"reference" validate:"max=100"`
+	Note      string   `json:"note"`
+}
+
+// StockAdjustRequest corrects stock after a count. Quantity is the difference
+// to apply, negative for shrinkage.
+type StockAdjustRequest struct {
+	Quantity float64 `json:"quantity" validate:"required"`
+	Note     string  `json:"note" validate:"required"`
+}
+
+type StockMovementResponse struct {
+	ID            int64     `json:"id"`
+	PartID        int64     `json:"part_id"`
+	PartNumber    string    `json:"part_number,omitempty"`
+	Type          string    `json:"type"`
+	Quantity      float64   `json:"quantity"`
+	StockAfter    float64   `json:"stock_after"`
+	UnitCost      *float64  `json:"unit_cost"`
+	MaintenanceID *int64    `json:"maintenance_id"`
+	Reference     string    `json:"reference"`
+	Note          string    `json:"note"`
+	CreatedBy     *int64    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type StockMovementListParams struct {
+	Page          int
+	Limit         int
+	PartID        int64
+	MaintenanceID int64
+	Type          string
+}
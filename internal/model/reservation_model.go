@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+type ReservationRequest struct {
+	CarID     int64     `json:"car_id" validate:"required"`
+	DriverID  *int64    `json:"driver_id"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
+	Purpose   string    `json:"purpose" validate:"required,max=255"`
+	Notes     string    `json:"notes"`
+}
+
+type CancelReservationRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ReservationResponse struct {
+	ID           int64           `json:"id"`
+	CarID        int64           `json:"car_id"`
+	Car          *CarResponse    `json:"car,omitempty"`
+	DriverID     *int64          `json:"driver_id"`
+	Driver       *DriverResponse `json:"driver,omitempty"`
+	RequesterID  *int64          `json:"requester_id"`
+	Requester    string          `json:"requester,omitempty"`
+	StartTime    time.Time       `json:"start_time"`
+	EndTime      time.Time       `json:"end_time"`
+	Purpose      string          `json:"purpose"`
+	Notes        string          `json:"notes"`
+	Status       string          `json:"status"`
+	TripID       *int64          `json:"trip_id"`
+	CancelReason string          `json:"cancel_reason,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// ReservationListParams selects reservations overlapping the From-To window,
+// which is what a booking calendar needs.
+type ReservationListParams struct {
+	CarID    int64
+	DriverID int64
+	Status   string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+}
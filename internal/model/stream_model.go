@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+const (
+	CarEventLocation = "location"
+	CarEventStatus   = "status"
+	CarEventGeofence = "geofence"
+	CarEventAlert    = "alert"
+)
+
+type CarEvent struct {
+	Type           string    `json:"type"` // location, status, geofence, alert
+	CarID          int64     `json:"car_id"`
+	LicensePlate   string    `json:"license_plate"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status,omitempty"` // Status events only
+	Lat            *float64  `json:"lat,omitempty"`
+	Lng            *float64  `json:"lng,omitempty"`
+	GeofenceID     *int64    `json:"geofence_id,omitempty"`
+	GeofenceName   string    `json:"geofence_name,omitempty"`
+	Transition     string    `json:"transition,omitempty"` // ENTER, EXIT
+	AlertID        *int64    `json:"alert_id,omitempty"`
+	AlertType      string    `json:"alert_type,omitempty"`
+	Severity       string    `json:"severity,omitempty"`
+	Message        string    `json:"message,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+type StreamFilter struct {
+	CarIDs   []int64
+	Statuses []string
+}
+
+// Match reports whether the event passes the filter. Empty filter fields match
+// everything. A status event passes when either its old or new status does.
+func (f StreamFilter) Match(event CarEvent) bool {
+	if len(f.CarIDs) > 0 {
+		found := false
+		for _, id := range f.CarIDs {
+			if id == event.CarID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, status := range f.Statuses {
+			if status == event.Status || (event.PreviousStatus != "" && status == event.PreviousStatus) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
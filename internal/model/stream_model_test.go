@@ -0,0 +1,26 @@
+package model
+
+import "testing"
+
+func TestStreamFilterMatch(t *testing.T) {
+	inUse := StreamFilter{Statuses: []string{"IN_USE"}}
+	tests := []struct {
+		name   string
+		filter StreamFilter
+		event  CarEvent
+		want   bool
+	}{
+		{"empty filter", StreamFilter{}, CarEvent{CarID: 1, Status: "AVAILABLE"}, true},
+		{"car matches", StreamFilter{CarIDs: []int64{1, 2}}, CarEvent{CarID: 2}, true},
+		{"car differs", StreamFilter{CarIDs: []int64{1}}, CarEvent{CarID: 2}, false},
+		{"new status matches", inUse, CarEvent{Type: CarEventStatus, Status: "IN_USE", PreviousStatus: "AVAILABLE"}, true},
+		{"leaving the status", inUse, CarEvent{Type: CarEventStatus, Status: "AVAILABLE", PreviousStatus: "IN_USE"}, true},
+		{"neither status", inUse, CarEvent{Type: CarEventStatus, Status: "MAINTENANCE", PreviousStatus: "AVAILABLE"}, false},
+		{"location of another status", inUse, CarEvent{Type: CarEventLocation, Status: "AVAILABLE"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(tt.event); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
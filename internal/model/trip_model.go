@@ -1,18 +1,44 @@
 package model
 
-import "time"
+import (
+	"fleet-monitor/internal/geo"
+	"time"
+)
 
 type CheckoutRequest struct {
-	CarID    int64  `json:"car_id" validate:"required"`
-	DriverID int64  `json:"driver_id" validate:"required"`
-	StartKm  int    `json:"start_km" validate:"min=0"`
-	Notes    string `json:"notes"`
+	CarID      int64              `json:"car_id" validate:"required"`
+	DriverID   int64              `json:"driver_id" validate:"required"`
+	StartKm    int                `json:"start_km" validate:"min=0"`
+	Notes      string             `json:"notes"`
+	Inspection *InspectionRequest `json:"inspection"`
+	// TripRequestID picks the approved request to fulfil; when empty the
+	// latest approved request for the car and driver is used.
+	TripRequestID *int64 `json:"trip_request_id"`
+	// UserID is the user performing the checkout, set by the handler.
+	UserID int64 `json:"-"`
 }
 
 type CheckinRequest struct {
-	TripID int64  `json:"trip_id" validate:"required"`
-	EndKm  int    `json:"end_km" validate:"min=0"`
-	Notes  string `json:"notes"`
+	TripID     int64              `json:"trip_id" validate:"required"`
+	EndKm      int                `json:"end_km" validate:"min=0"`
+	Notes      string             `json:"notes"`
+	Inspection *InspectionRequest `json:"inspection"`
+}
+
+// SelfCheckoutRequest is used by the driver app; the driver comes from the token.
+type SelfCheckoutRequest struct {
+	CarID         int64              `json:"car_id" validate:"required"`
+	StartKm       int                `json:"start_km" validate:"min=0"`
+	Notes         string             `json:"notes"`
+	Inspection    *InspectionRequest `json:"inspection"`
+	TripRequestID *int64             `json:"trip_request_id"`
+}
+
+// SelfCheckinRequest ends the current driver's active trip.
+type SelfCheckinRequest struct {
+	EndKm      int                `json:"end_km" validate:"min=0"`
+	Notes      string             `json:"notes"`
+	Inspection *InspectionRequest `json:"inspection"`
 }
 
 type TripResponse struct {
@@ -26,13 +52,40 @@ type TripResponse struct {
 	StartKm   int             `json:"start_km"`
 	EndKm     *int            `json:"end_km"`
 	Notes     string          `json:"notes"`
-	CreatedAt time.Time       `json:"created_at"`
+	Status    string          `json:"status"`
+	// GPS summary, filled at checkin when the car reported a track
+	DurationSeconds  *int      `json:"duration_seconds"`
+	GpsDistanceKm    *float64  `json:"gps_distance_km"`
+	MovingSeconds    *int      `json:"moving_seconds"`
+	IdleSeconds      *int      `json:"idle_seconds"`
+	MaxSpeedKmh      *float64  `json:"max_speed_kmh"`
+	DistanceMismatch bool      `json:"distance_mismatch"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type TripListParams struct {
-	Page     int   `query:"page"`
-	Limit    int   `query:"limit"`
-	CarID    int64 `query:"car_id"`
-	DriverID int64 `query:"driver_id"`
-	Active   *bool `query:"active"`
+	Page     int    `query:"page"`
+	Limit    int    `query:"limit"`
+	CarID    int64  `query:"car_id"`
+	DriverID int64  `query:"driver_id"`
+	Active   *bool  `query:"active"`
+	Status   string `query:"status"`
+	// DistanceMismatch filters trips whose GPS and odometer distance disagree
+	DistanceMismatch *bool `query:"distance_mismatch"`
+}
+
+// TripRouteFeature is a GeoJSON Feature with the trip's GPS track as a LineString.
+type TripRouteFeature struct {
+	Type       string              `json:"type"`
+	Geometry   geo.Geometry        `json:"geometry"`
+	Properties TripRouteProperties `json:"properties"`
+}
+
+type TripRouteProperties struct {
+	TripID        int64      `json:"trip_id"`
+	CarID         int64      `json:"car_id"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       *time.Time `json:"end_time"`
+	Points        int        `json:"points"`
+	GpsDistanceKm float64    `json:"gps_distance_km"`
 }
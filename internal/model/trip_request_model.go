@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+type TripRequestRequest struct {
+	CarID        int64      `json:"car_id" validate:"required"`
+	DriverID     int64      `json:"driver_id" validate:"required"`
+	Purpose      string     `json:"purpose" validate:"required,max=255"`
+	Destination  string     `json:"destination" validate:"max=255"`
+	PlannedStart *time.Time `json:"planned_start"`
+	PlannedEnd   *time.Time `json:"planned_end"`
+}
+
+// SelfTripRequestRequest is used by the driver app; the driver comes from the token.
+type SelfTripRequestRequest struct {
+	CarID        int64      `json:"car_id" validate:"required"`
+	Purpose      string     `json:"purpose" validate:"required,max=255"`
+	Destination  string     `json:"destination" validate:"max=255"`
+	PlannedStart *time.Time `json:"planned_start"`
+	PlannedEnd   *time.Time `json:"planned_end"`
+}
+
+type TripRequestDecisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type TripRequestResponse struct {
+	ID           int64           `json:"id"`
+	CarID        int64           `json:"car_id"`
+	Car          *CarResponse    `json:"car,omitempty"`
+	DriverID     int64           `json:"driver_id"`
+	Driver       *DriverResponse `json:"driver,omitempty"`
+	RequesterID  *int64          `json:"requester_id"`
+	Requester    string          `json:"requester,omitempty"`
+	Purpose      string          `json:"purpose"`
+	Destination  string          `json:"destination"`
+	PlannedStart *time.Time      `json:"planned_start"`
+	PlannedEnd   *time.Time      `json:"planned_end"`
+	Status       string          `json:"status"`
+	ApproverID   *int64          `json:"approver_id"`
+	Approver     string          `json:"approver,omitempty"`
+	ApprovedAt   *time.Time      `json:"approved_at"`
+	ApprovalNote string          `json:"approval_note,omitempty"`
+	RejectedAt   *time.Time      `json:"rejected_at"`
+	RejectReason string          `json:"reject_reason,omitempty"`
+	CancelledAt  *time.Time      `json:"cancelled_at"`
+	CancelReason string          `json:"cancel_reason,omitempty"`
+	TripID       *int64          `json:"trip_id"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+type TripRequestListParams struct {
+	Page     int    `query:"page"`
+	Limit    int    `query:"limit"`
+	Status   string `query:"status"`
+	CarID    int64  `query:"car_id"`
+	DriverID int64  `query:"driver_id"`
+}
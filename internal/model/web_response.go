@@ -1,10 +1,17 @@
 package model
 
 type WebResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool         `json:"success"`
+	Message string       `json:"message,omitempty"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
 }
 
 type PaginationResponse struct {
@@ -31,3 +38,12 @@ func ErrorResponse(message string, err string) WebResponse {
 		Error:   err,
 	}
 }
+
+func ValidationErrorResponse(message string, fields []FieldError) WebResponse {
+	return WebResponse{
+		Success: false,
+		Message: "Validation failed",
+		Error:   message,
+		Errors:  fields,
+	}
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookRequest struct {
+	Name       string   `json:"name" validate:"required,max=100"`
+	URL        string   `json:"url" validate:"required,max=500"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=100"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=trip.checked_out trip.checked_in maintenance.created car.status_changed"`
+	Active     *bool    `json:"active"`
+}
+
+// WebhookResponse only carries the secret right after the webhook is created.
+type WebhookResponse struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedBy  *int64    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	DurationMs     *int64          `json:"duration_ms"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	RedeliveryOf   *int64          `json:"redelivery_of"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type WebhookDeliveryListParams struct {
+	Page      int
+	Limit     int
+	WebhookID int64
+	EventType string
+	Status    string
+}
+
+// WebhookEvent is the JSON body POSTed to subscribers. Data holds one of the
+// Webhook*Data types below, depending on Type.
+type WebhookEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type WebhookTripData struct {
+	TripID       int64      `json:"trip_id"`
+	CarID        int64      `json:"car_id"`
+	LicensePlate string     `json:"license_plate"`
+	DriverID     int64      `json:"driver_id"`
+	DriverName   string     `json:"driver_name"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	StartKm      int        `json:"start_km"`
+	EndKm        *int       `json:"end_km,omitempty"`
+	Status       string     `json:"status"`
+}
+
+type WebhookMaintenanceData struct {
+	MaintenanceID int64     `json:"maintenance_id"`
+	CarID         int64     `json:"car_id"`
+	LicensePlate  string    `json:"license_plate"`
+	ServiceDate   time.Time `json:"service_date"`
+	Description   string    `json:"description"`
+	Cost          float64   `json:"cost"`
+	WorkshopName  string    `json:"workshop_name"`
+	PlanID        *int64    `json:"plan_id,omitempty"`
+}
+
+type WebhookCarStatusData struct {
+	CarID          int64  `json:"car_id"`
+	LicensePlate   string `json:"license_plate"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"` // manual, trip_checkout, trip_checkin, maintenance
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+type WorkshopRequest struct {
+	Name        string   `json:"name" validate:"required,max=100"`
+	Address     string   `json:"address"`
+	Lat         *float64 `json:"lat" validate:"omitempty,latitude"`
+	Lng         *float64 `json:"lng" validate:"omitempty,longitude"`
+	ContactName string   `json:"contact_name" validate:"max=100"`
+	Phone       string   `json:"phone" validate:"max=20"`
+	Email       string   `json:"email" validate:"omitempty,email,max=100"`
+	Specialties []string `json:"specialties" validate:"omitempty,dive,required,max=50"`
+	Rating      *float64 `json:"rating" validate:"omitempty,min=0,max=5"`
+}
+
+type WorkshopResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Address     string    `json:"address"`
+	Lat         *float64  `json:"lat"`
+	Lng         *float64  `json:"lng"`
+	ContactName string    `json:"contact_name"`
+	Phone       string    `json:"phone"`
+	Email       string    `json:"email"`
+	Specialties []string  `json:"specialties"`
+	Rating      *float64  `json:"rating"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WorkshopListParams struct {
+	Page      int
+	Limit     int
+	Search    string
+	Specialty string
+}
+
+// WorkshopReportParams limits the report to work orders completed in
+// [From, To).
+type WorkshopReportParams struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// WorkshopReportItem sums a workshop's completed work orders. Turnaround runs
+// from start to completion and is nil when no work order has both.
+type WorkshopReportItem struct {
+	WorkshopID         int64    `json:"workshop_id"`
+	Name               string   `json:"name"`
+	WorkOrders         int64    `json:"work_orders"`
+	TotalSpend         float64  `json:"total_spend"`
+	PartsCost          float64  `json:"parts_cost"`
+	LabourCost         float64  `json:"labour_cost"`
+	OtherCost          float64  `json:"other_cost"`
+	TaxAmount          float64  `json:"tax_amount"`
+	AvgTurnaroundHours *float64 `json:"avg_turnaround_hours"`
+}
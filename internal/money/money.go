@@ -0,0 +1,108 @@
+// Package money does exact arithmetic on currency amounts.
+//
+// Amounts are stored as DECIMAL columns and carried as float64 in entities
+// and JSON, like the rest of the code base. Before any arithmetic they are
+// turned into whole cents so that sums and tax never drift by a fraction of
+// a cent.
+package money
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// ErrOutOfRange is returned for amounts that are not finite or whose cents
+// do not fit in an int64.
+var ErrOutOfRange = errors.New("amount is out of range")
+
+// Cents rounds amount to the nearest cent, half away from zero.
+func Cents(amount float64) (int64, error) {
+	return scale(amount, 100)
+}
+
+// FromCents turns cents back into an amount.
+func FromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// RoundQuantity rounds a quantity to the three decimals it is stored with.
+// Values too large to carry decimals are returned as they are.
+func RoundQuantity(quantity float64) float64 {
+	n, err := scale(quantity, 1000)
+	if err != nil {
+		return quantity
+	}
+	return float64(n) / 1000
+}
+
+// RoundRate rounds a percentage to the two decimals it is stored with.
+// Values too large to carry decimals are returned as they are.
+func RoundRate(rate float64) float64 {
+	n, err := scale(rate, 100)
+	if err != nil {
+		return rate
+	}
+	return float64(n) / 100
+}
+
+// Amount prices quantity units at unitPrice, rounded half away from zero to
+// the cent. Quantity keeps three decimals and unitPrice two.
+func Amount(quantity, unitPrice float64) (int64, error) {
+	milliUnits, err := scale(quantity, 1000)
+	if err != nil {
+		return 0, err
+	}
+	priceCents, err := Cents(unitPrice)
+	if err != nil {
+		return 0, err
+	}
+	return divRound(new(big.Int).Mul(big.NewInt(milliUnits), big.NewInt(priceCents)), big.NewInt(1000))
+}
+
+// Line prices quantity units at unitPrice and applies taxRate percent. The
+// subtotal and the tax are each rounded half away from zero to the cent.
+// Quantity keeps three decimals and taxRate two.
+func Line(quantity, unitPrice, taxRate float64) (subtotal, tax int64, err error) {
+	subtotal, err = Amount(quantity, unitPrice)
+	if err != nil {
+		return 0, 0, err
+	}
+	basisPoints, err := scale(taxRate, 100)
+	if err != nil {
+		return 0, 0, err
+	}
+	tax, err = divRound(new(big.Int).Mul(big.NewInt(subtotal), big.NewInt(basisPoints)), big.NewInt(10000))
+	if err != nil {
+		return 0, 0, err
+	}
+	return subtotal, tax, nil
+}
+
+// scale multiplies v by factor and rounds to an integer. It works from the
+// shortest decimal form of v, so 1.005 is treated as written rather than as
+// the nearest binary fraction, 1.00499...
+func scale(v float64, factor int64) (int64, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	if !ok {
+		return 0, ErrOutOfRange
+	}
+	r.Mul(r, new(big.Rat).SetInt64(factor))
+	return divRound(r.Num(), r.Denom())
+}
+
+// divRound divides n by a positive d, rounding half away from zero.
+func divRound(n, d *big.Int) (int64, error) {
+	quotient, remainder := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(d) >= 0 {
+		if n.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return 0, ErrOutOfRange
+	}
+	return quotient.Int64(), nil
+}
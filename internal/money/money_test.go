@@ -0,0 +1,137 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int64
+		err    error
+	}{
+		{amount: 0, want: 0},
+		{amount: 12.34, want: 1234},
+		{amount: 1.005, want: 101},
+		{amount: 1.004, want: 100},
+		{amount: 2.675, want: 268},
+		{amount: 0.125, want: 13},
+		{amount: -0.125, want: -13},
+		{amount: -1.005, want: -101},
+		{amount: 1e17, err: ErrOutOfRange},
+		{amount: math.Inf(1), err: ErrOutOfRange},
+		{amount: math.NaN(), err: ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		got, err := Cents(tt.amount)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("Cents(%v) = %d, %v; want %d, %v", tt.amount, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestRoundQuantityAndRate(t *testing.T) {
+	quantities := map[float64]float64{
+		1.0005:  1.001,
+		-1.0005: -1.001,
+		2.0004:  2,
+		1e300:   1e300,
+	}
+	for in, want := range quantities {
+		if got := RoundQuantity(in); got != want {
+			t.Errorf("RoundQuantity(%v) = %v, want %v", in, got, want)
+		}
+	}
+	if got := RoundQuantity(math.NaN()); !math.IsNaN(got) {
+		t.Errorf("RoundQuantity(NaN) = %v", got)
+	}
+
+	rates := map[float64]float64{
+		11.005: 11.01,
+		11.004: 11,
+		-0.005: -0.01,
+	}
+	for in, want := range rates {
+		if got := RoundRate(in); got != want {
+			t.Errorf("RoundRate(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestAmount(t *testing.T) {
+	tests := []struct {
+		quantity, unitPrice float64
+		want                int64
+		err                 error
+	}{
+		{quantity: 3, unitPrice: 19.99, want: 5997},
+		{quantity: 0.333, unitPrice: 10, want: 333},
+		{quantity: 1.5, unitPrice: 0.01, want: 2},
+		{quantity: -1.5, unitPrice: 0.01, want: -2},
+		{quantity: -2, unitPrice: 1.25, want: -250},
+		{quantity: 42.5, unitPrice: 10000, want: 42500000},
+		{quantity: 1.0005, unitPrice: 100, want: 10010},
+		{quantity: 1e12, unitPrice: 1e9, err: ErrOutOfRange},
+		{quantity: math.NaN(), unitPrice: 1, err: ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		got, err := Amount(tt.quantity, tt.unitPrice)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("Amount(%v, %v) = %d, %v; want %d, %v", tt.quantity, tt.unitPrice, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		quantity, unitPrice, taxRate float64
+		subtotal, tax                int64
+		err                          error
+	}{
+		{quantity: 1, unitPrice: 100, taxRate: 11, subtotal: 10000, tax: 1100},
+		{quantity: 2.5, unitPrice: 33.33, taxRate: 11, subtotal: 8333, tax: 917},
+		{quantity: 1, unitPrice: 0.05, taxRate: 10, subtotal: 5, tax: 1},
+		{quantity: 1, unitPrice: 0.05, taxRate: 9.99, subtotal: 5, tax: 0},
+		{quantity: -1, unitPrice: 0.05, taxRate: 10, subtotal: -5, tax: -1},
+		{quantity: 3, unitPrice: 1.005, taxRate: 0, subtotal: 303, tax: 0},
+		{quantity: 1e12, unitPrice: 1e9, taxRate: 0, err: ErrOutOfRange},
+		{quantity: 1, unitPrice: 1, taxRate: math.Inf(1), err: ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		subtotal, tax, err := Line(tt.quantity, tt.unitPrice, tt.taxRate)
+		if !errors.Is(err, tt.err) || subtotal != tt.subtotal || tax != tt.tax {
+			t.Errorf("Line(%v, %v, %v) = %d, %d, %v; want %d, %d, %v",
+				tt.quantity, tt.unitPrice, tt.taxRate, subtotal, tax, err, tt.subtotal, tt.tax, tt.err)
+		}
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		n, d int64
+		want int64
+	}{
+		{5, 2, 3},
+		{-5, 2, -3},
+		{4, 2, 2},
+		{7, 3, 2},
+		{-7, 3, -2},
+		{-1, 3, 0},
+		{1, 2, 1},
+		{-1, 2, -1},
+	}
+	for _, tt := range tests {
+		got, err := divRound(big.NewInt(tt.n), big.NewInt(tt.d))
+		if err != nil || got != tt.want {
+			t.Errorf("divRound(%d, %d) = %d, %v; want %d", tt.n, tt.d, got, err, tt.want)
+		}
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 64)
+	if _, err := divRound(huge, big.NewInt(1)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("divRound(2^64, 1) error = %v, want %v", err, ErrOutOfRange)
+	}
+}
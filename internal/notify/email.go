@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type emailNotifier struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// NewEmailNotifier sends plain-text mail through an SMTP server. Credentials
+// are optional; when set, PLAIN auth is used, which net/smtp only allows over
+// TLS or to localhost.
+func NewEmailNotifier(host string, port int, username, password, from string) Notifier {
+	return &emailNotifier{
+		addr:     net.JoinHostPort(host, fmt.Sprint(port)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (n *emailNotifier) Send(ctx context.Context, target string, msg Message) error {
+	if strings.ContainsAny(target, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	body := strings.ReplaceAll(msg.Body, "\n", "\r\n")
+	content := "From: " + n.from + "\r\n" +
+		"To: " + target + "\r\n" +
+		"Subject: " + msg.Subject + "\r\n" +
+		"Date: " + time.Now().Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body + "\r\n"
+
+	// net/smtp has no context support; run it aside and give up on timeout
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.addr, auth, n.from, []string{target}, []byte(content))
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,36 @@
+// Package notify delivers short messages to people outside the web UI. Each
+// channel implements Notifier; the target format depends on the channel.
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ChannelEmail    = "EMAIL"
+	ChannelWebhook  = "WEBHOOK"
+	ChannelTelegram = "TELEGRAM"
+)
+
+// sendTimeout bounds a single delivery attempt.
+const sendTimeout = 15 * time.Second
+
+type Message struct {
+	Event   string
+	Subject string
+	Body    string
+}
+
+// Notifier sends a message to target: an email address, a webhook URL or a
+// Telegram chat ID.
+type Notifier interface {
+	Send(ctx context.Context, target string, msg Message) error
+}
+
+// Send delivers msg with the default timeout.
+func Send(n Notifier, target string, msg Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	return n.Send(ctx, target, msg)
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// DefaultTelegramAPIURL is the public Bot API. Tests and local setups can
+// point the notifier at a stub instead.
+const DefaultTelegramAPIURL = "https://api.telegram.org"
+
+type telegramNotifier struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewTelegramNotifier sends messages through a Telegram bot. The target is
+// the chat ID the bot should write to.
+func NewTelegramNotifier(baseURL, token string) Notifier {
+	if baseURL == "" {
+		baseURL = DefaultTelegramAPIURL
+	}
+	return &telegramNotifier{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: sendTimeout},
+	}
+}
+
+type telegramMessage struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+func (n *telegramNotifier) Send(ctx context.Context, target string, msg Message) error {
+	if target == "" {
+		return errors.New("telegram chat id is empty")
+	}
+
+	text := msg.Body
+	if msg.Subject != "" {
+		text = msg.Subject + "\n\n" + msg.Body
+	}
+	payload, err := json.Marshal(telegramMessage{ChatID: target, Text: text})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.client, n.baseURL+"/bot"+n.token+"/sendMessage", payload)
+}
@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var errPrivateAddress = errors.New("target resolves to a private or local address")
+
+type webhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier POSTs each message as JSON to the target URL. Any 2xx
+// response counts as delivered. Targets are set by ordinary users, so the
+// client refuses to connect to private and local addresses, whatever the
+// host name resolved to, and ignores proxy settings that would hide them.
+func NewWebhookNotifier() Notifier {
+	dialer := &net.Dialer{Timeout: sendTimeout, Control: refusePrivateAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &webhookNotifier{client: &http.Client{Timeout: sendTimeout, Transport: transport}}
+}
+
+// PublicHost reports whether a webhook target host may be used. IP literals
+// and localhost are checked here; other names are checked when connecting.
+func PublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || publicIP(ip)
+}
+
+// nonPublicNets are ranges the net.IP predicates miss: "this network" and the
+// carrier-grade NAT shared space.
+var nonPublicNets = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+}
+
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Event   string    `json:"event"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+func (n *webhookNotifier) Send(ctx context.Context, target string, msg Message) error {
+	payload, err := json.Marshal(webhookPayload{
+		Event:   msg.Event,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		SentAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.client, target, payload)
+}
+
+// postJSON sends payload and turns a non-2xx response into an error that
+// includes the start of the response body. Errors never include the URL,
+// which may carry a secret such as a bot token.
+func postJSON(ctx context.Context, client *http.Client, target string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("responded %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPublicHost(t *testing.T) {
+	tests := map[string]bool{
+		"example.com":     true,
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"localhost":       false,
+		"api.localhost.":  false,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"192.168.1.10":    false,
+		"169.254.169.254": false,
+		"0.0.0.0":         false,
+		"0.1.2.3":         false,
+		"100.64.1.1":      false,
+		"100.127.255.254": false,
+		"100.128.0.1":     true,
+		"::1":             false,
+		"fd00::1":         false,
+	}
+	for host, want := range tests {
+		if got := PublicHost(host); got != want {
+			t.Errorf("PublicHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestWebhookNotifierRefusesLocalAddresses(t *testing.T) {
+	n := NewWebhookNotifier()
+	for _, target := range []string{"http://127.0.0.1:1/hook", "http://localhost:1/hook", "http://[::1]:1/hook"} {
+		if err := n.Send(context.Background(), target, Message{}); !errors.Is(err, errPrivateAddress) {
+			t.Errorf("Send(%s) error = %v, want %v", target, err, errPrivateAddress)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package realtime
+
+import (
+	"fleet-monitor/internal/model"
+	"sync"
+)
+
+// subscriberBuffer is the number of events queued per client before new
+// events are dropped for that client.
+const subscriberBuffer = 64
+
+type Subscription struct {
+	Events <-chan model.CarEvent
+	events chan model.CarEvent
+	filter model.StreamFilter
+}
+
+type Hub interface {
+	Subscribe(filter model.StreamFilter) *Subscription
+	Unsubscribe(sub *Subscription)
+	Publish(event model.CarEvent)
+}
+
+type hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub() Hub {
+	return &hub{subscribers: make(map[*Subscription]struct{})}
+}
+
+func (h *hub) Subscribe(filter model.StreamFilter) *Subscription {
+	events := make(chan model.CarEvent, subscriberBuffer)
+	sub := &Subscription{
+		Events: events,
+		events: events,
+		filter: filter,
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+func (h *hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+	h.mu.Unlock()
+}
+
+// Publish fans the event out to every matching subscriber. It never blocks:
+// a slow client whose buffer is full simply misses the event.
+func (h *hub) Publish(event model.CarEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AlertRepository interface {
+	WithTx(tx *gorm.DB) AlertRepository
+	FindAll(params model.AlertListParams) ([]entity.Alert, int64, error)
+	FindByID(id int64) (*entity.Alert, error)
+	FindByIDForUpdate(id int64) (*entity.Alert, error)
+	CreateIfAbsent(alert *entity.Alert) (bool, error)
+	Update(alert *entity.Alert) error
+	ResolveOpen(carID int64, alertType, note string, resolvedAt time.Time) (int64, error)
+	CountUnresolved() (int64, error)
+}
+
+type alertRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRepository(db *gorm.DB) AlertRepository {
+	return &alertRepository{db: db}
+}
+
+func (r *alertRepository) WithTx(tx *gorm.DB) AlertRepository {
+	return &alertRepository{db: tx}
+}
+
+func (r *alertRepository) FindAll(params model.AlertListParams) ([]entity.Alert, int64, error) {
+	var alerts []entity.Alert
+	var total int64
+
+	query := r.db.Model(&entity.Alert{}).Preload("Rule").Preload("Car")
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+	if params.Severity != "" {
+		query = query.Where("severity = ?", params.Severity)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.From != nil {
+		query = query.Where("triggered_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("triggered_at <= ?", *params.To)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("triggered_at DESC, id DESC").Find(&alerts).Error
+	return alerts, total, err
+}
+
+func (r *alertRepository) FindByID(id int64) (*entity.Alert, error) {
+	var alert entity.Alert
+	err := r.db.Preload("Rule").Preload("Car").First(&alert, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *alertRepository) FindByIDForUpdate(id int64) (*entity.Alert, error) {
+	var alert entity.Alert
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&alert, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// CreateIfAbsent inserts the alert unless its rule already has an unresolved
+// alert for the car, and reports whether a row was written.
+func (r *alertRepository) CreateIfAbsent(alert *entity.Alert) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *alertRepository) Update(alert *entity.Alert) error {
+	return r.db.Save(alert).Error
+}
+
+// ResolveOpen closes every unresolved alert of the given type for the car,
+// e.g. when the condition behind it has cleared.
+func (r *alertRepository) ResolveOpen(carID int64, alertType, note string, resolvedAt time.Time) (int64, error) {
+	result := r.db.Model(&entity.Alert{}).
+		Where("car_id = ? AND type = ? AND status <> ?", carID, alertType, entity.AlertStatusResolved).
+		Updates(map[string]interface{}{
+			"status":          entity.AlertStatusResolved,
+			"resolved_at":     resolvedAt,
+			"resolution_note": note,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CountUnresolved counts alerts that are still open or only acknowledged.
+func (r *alertRepository) CountUnresolved() (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Alert{}).
+		Where("status <> ?", entity.AlertStatusResolved).
+		Count(&count).Error
+	return count, err
+}
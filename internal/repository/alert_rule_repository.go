@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type AlertRuleRepository interface {
+	WithTx(tx *gorm.DB) AlertRuleRepository
+	FindAll(params model.AlertRuleListParams) ([]entity.AlertRule, int64, error)
+	FindActive() ([]entity.AlertRule, error)
+	FindByID(id int64) (*entity.AlertRule, error)
+	Create(rule *entity.AlertRule) error
+	Update(rule *entity.AlertRule) error
+	Delete(id int64) error
+}
+
+type alertRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRuleRepository(db *gorm.DB) AlertRuleRepository {
+	return &alertRuleRepository{db: db}
+}
+
+func (r *alertRuleRepository) WithTx(tx *gorm.DB) AlertRuleRepository {
+	return &alertRuleRepository{db: tx}
+}
+
+func (r *alertRuleRepository) FindAll(params model.AlertRuleListParams) ([]entity.AlertRule, int64, error) {
+	var rules []entity.AlertRule
+	var total int64
+
+	query := r.db.Model(&entity.AlertRule{}).Preload("Car").Preload("Geofence")
+
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+	if params.Active != nil {
+		query = query.Where("active = ?", *params.Active)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("name ASC").Find(&rules).Error
+	return rules, total, err
+}
+
+func (r *alertRuleRepository) FindActive() ([]entity.AlertRule, error) {
+	var rules []entity.AlertRule
+	err := r.db.Preload("Geofence").Where("active = ?", true).Order("id ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *alertRuleRepository) FindByID(id int64) (*entity.AlertRule, error) {
+	var rule entity.AlertRule
+	err := r.db.Preload("Car").Preload("Geofence").First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *alertRuleRepository) Create(rule *entity.AlertRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *alertRuleRepository) Update(rule *entity.AlertRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *alertRuleRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.AlertRule{}, id).Error
+}
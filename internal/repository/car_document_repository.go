@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CarDocumentRepository interface {
+	FindByCarID(carID int64) ([]entity.CarDocument, error)
+	FindByID(id int64) (*entity.CarDocument, error)
+	FindExpiringBefore(until time.Time) ([]entity.CarDocument, error)
+	CountExpiringBetween(from, until time.Time) (int64, error)
+	CountExpiredAt(at time.Time) (int64, error)
+	Create(document *entity.CarDocument) error
+	Update(document *entity.CarDocument) error
+	Delete(id int64) error
+}
+
+type carDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewCarDocumentRepository(db *gorm.DB) CarDocumentRepository {
+	return &carDocumentRepository{db: db}
+}
+
+func (r *carDocumentRepository) FindByCarID(carID int64) ([]entity.CarDocument, error) {
+	var documents []entity.CarDocument
+	err := r.db.Where("car_id = ?", carID).Order("expiry_date ASC").Find(&documents).Error
+	return documents, err
+}
+
+func (r *carDocumentRepository) FindByID(id int64) (*entity.CarDocument, error) {
+	var document entity.CarDocument
+	err := r.db.Preload("Car").First(&document, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindExpiringBefore returns every document, already expired or not, whose expiry date is before until.
+func (r *carDocumentRepository) FindExpiringBefore(until time.Time) ([]entity.CarDocument, error) {
+	var documents []entity.CarDocument
+	err := r.db.Preload("Car").Where("expiry_date < ?", until).Order("expiry_date ASC").Find(&documents).Error
+	return documents, err
+}
+
+func (r *carDocumentRepository) CountExpiringBetween(from, until time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.CarDocument{}).
+		Where("expiry_date >= ? AND expiry_date < ?", from, until).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *carDocumentRepository) CountExpiredAt(at time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.CarDocument{}).Where("expiry_date < ?", at).Count(&count).Error
+	return count, err
+}
+
+func (r *carDocumentRepository) Create(document *entity.CarDocument) error {
+	return r.db.Create(document).Error
+}
+
+func (r *carDocumentRepository) Update(document *entity.CarDocument) error {
+	return r.db.Save(document).Error
+}
+
+func (r *carDocumentRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.CarDocument{}, id).Error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CarLocationRepository interface {
+	WithTx(tx *gorm.DB) CarLocationRepository
+	Create(location *entity.CarLocation) error
+	CreateIfAbsent(location *entity.CarLocation) (bool, error)
+	FindByCarID(carID int64, params model.CarLocationListParams) ([]entity.CarLocation, error)
+	FindTrack(carID int64, from, to time.Time) ([]entity.CarLocation, error)
+}
+
+type carLocationRepository struct {
+	db *gorm.DB
+}
+
+func NewCarLocationRepository(db *gorm.DB) CarLocationRepository {
+	return &carLocationRepository{db: db}
+}
+
+func (r *carLocationRepository) WithTx(tx *gorm.DB) CarLocationRepository {
+	return &carLocationRepository{db: tx}
+}
+
+func (r *carLocationRepository) Create(location *entity.CarLocation) error {
+	return r.db.Create(location).Error
+}
+
+// CreateIfAbsent inserts the point unless the car already has one recorded at
+// the same time, and reports whether a row was written.
+func (r *carLocationRepository) CreateIfAbsent(location *entity.CarLocation) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(location)
+	return result.RowsAffected > 0, result.Error
+}
+
+// maxLocationHistory caps a single location history page.
+const maxLocationHistory = 5000
+
+// FindByCarID returns up to params.Limit points, oldest first. With a from
+// bound the page starts there; without one it is the latest points up to to.
+func (r *carLocationRepository) FindByCarID(carID int64, params model.CarLocationListParams) ([]entity.CarLocation, error) {
+	var locations []entity.CarLocation
+
+	query := r.db.Where("car_id = ?", carID)
+
+	if params.From != nil {
+		query = query.Where("recorded_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("recorded_at <= ?", *params.To)
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 1000
+	}
+	if params.Limit > maxLocationHistory {
+		params.Limit = maxLocationHistory
+	}
+
+	if params.From != nil {
+		err := query.Order("recorded_at ASC, id ASC").Limit(params.Limit).Find(&locations).Error
+		return locations, err
+	}
+
+	if err := query.Order("recorded_at DESC, id DESC").Limit(params.Limit).Find(&locations).Error; err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(locations)-1; i < j; i, j = i+1, j-1 {
+		locations[i], locations[j] = locations[j], locations[i]
+	}
+	return locations, nil
+}
+
+// FindTrack returns every point the car recorded in the window, oldest first.
+// Unlike FindByCarID it is not capped, so callers get the complete track.
+func (r *carLocationRepository) FindTrack(carID int64, from, to time.Time) ([]entity.CarLocation, error) {
+	var locations []entity.CarLocation
+	err := r.db.Where("car_id = ? AND recorded_at BETWEEN ? AND ?", carID, from, to).
+		Order("recorded_at ASC, id ASC").
+		Find(&locations).Error
+	return locations, err
+}
@@ -3,19 +3,25 @@ package repository
 import (
 	"fleet-monitor/internal/entity"
 	"fleet-monitor/internal/model"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CarRepository interface {
+	WithTx(tx *gorm.DB) CarRepository
 	FindAll(params model.CarListParams) ([]entity.Car, int64, error)
 	FindByID(id int64) (*entity.Car, error)
+	FindByIDForUpdate(id int64) (*entity.Car, error)
 	FindByLicensePlate(plate string) (*entity.Car, error)
+	FindByBrandModel(brand, model string) ([]entity.Car, error)
 	Create(car *entity.Car) error
 	Update(car *entity.Car) error
 	Delete(id int64) error
-	UpdateLocation(id int64, lat, lng float64) error
+	UpdateLocation(id int64, lat, lng float64, recordedAt time.Time) error
 	UpdateStatus(id int64, status string, driverID *int64) error
+	UpdateCurrentKm(id int64, km int) error
 	CountByStatus(status string) (int64, error)
 }
 
@@ -27,6 +33,10 @@ func NewCarRepository(db *gorm.DB) CarRepository {
 	return &carRepository{db: db}
 }
 
+func (r *carRepository) WithTx(tx *gorm.DB) CarRepository {
+	return &carRepository{db: tx}
+}
+
 func (r *carRepository) FindAll(params model.CarListParams) ([]entity.Car, int64, error) {
 	var cars []entity.Car
 	var total int64
@@ -64,6 +74,16 @@ func (r *carRepository) FindByID(id int64) (*entity.Car, error) {
 	return &car, nil
 }
 
+// FindByIDForUpdate locks the car row until the surrounding transaction ends.
+func (r *carRepository) FindByIDForUpdate(id int64) (*entity.Car, error) {
+	var car entity.Car
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&car, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &car, nil
+}
+
 func (r *carRepository) FindByLicensePlate(plate string) (*entity.Car, error) {
 	var car entity.Car
 	err := r.db.Where("license_plate = ?", plate).First(&car).Error
@@ -73,6 +93,12 @@ func (r *carRepository) FindByLicensePlate(plate string) (*entity.Car, error) {
 	return &car, nil
 }
 
+func (r *carRepository) FindByBrandModel(brand, model string) ([]entity.Car, error) {
+	var cars []entity.Car
+	err := r.db.Where("brand ILIKE ? AND model ILIKE ?", brand, model).Order("id ASC").Find(&cars).Error
+	return cars, err
+}
+
 func (r *carRepository) Create(car *entity.Car) error {
 	return r.db.Create(car).Error
 }
@@ -85,11 +111,11 @@ func (r *carRepository) Delete(id int64) error {
 	return r.db.Delete(&entity.Car{}, id).Error
 }
 
-func (r *carRepository) UpdateLocation(id int64, lat, lng float64) error {
+func (r *carRepository) UpdateLocation(id int64, lat, lng float64, recordedAt time.Time) error {
 	return r.db.Model(&entity.Car{}).Where("id = ?", id).Updates(map[string]interface{}{
 		"last_lat":        lat,
 		"last_lng":        lng,
-		"last_update_loc": gorm.Expr("NOW()"),
+		"last_update_loc": recordedAt,
 	}).Error
 }
 
@@ -100,6 +126,10 @@ func (r *carRepository) UpdateStatus(id int64, status string, driverID *int64) e
 	}).Error
 }
 
+func (r *carRepository) UpdateCurrentKm(id int64, km int) error {
+	return r.db.Model(&entity.Car{}).Where("id = ?", id).Update("current_km", km).Error
+}
+
 func (r *carRepository) CountByStatus(status string) (int64, error) {
 	var count int64
 	err := r.db.Model(&entity.Car{}).Where("status = ?", status).Count(&count).Error
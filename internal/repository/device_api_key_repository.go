@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DeviceAPIKeyRepository interface {
+	FindByDeviceID(deviceID int64) ([]entity.DeviceAPIKey, error)
+	FindByID(id int64) (*entity.DeviceAPIKey, error)
+	FindByPrefix(prefix string) (*entity.DeviceAPIKey, error)
+	Create(key *entity.DeviceAPIKey) error
+	Update(key *entity.DeviceAPIKey) error
+	TouchLastUsed(id int64, at time.Time) error
+}
+
+type deviceAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceAPIKeyRepository(db *gorm.DB) DeviceAPIKeyRepository {
+	return &deviceAPIKeyRepository{db: db}
+}
+
+func (r *deviceAPIKeyRepository) FindByDeviceID(deviceID int64) ([]entity.DeviceAPIKey, error) {
+	var keys []entity.DeviceAPIKey
+	err := r.db.Where("device_id = ?", deviceID).Order("id DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *deviceAPIKeyRepository) FindByID(id int64) (*entity.DeviceAPIKey, error) {
+	var key entity.DeviceAPIKey
+	err := r.db.First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindByPrefix loads the key with its device for authentication.
+func (r *deviceAPIKeyRepository) FindByPrefix(prefix string) (*entity.DeviceAPIKey, error) {
+	var key entity.DeviceAPIKey
+	err := r.db.Preload("Device").Where("prefix = ?", prefix).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *deviceAPIKeyRepository) Create(key *entity.DeviceAPIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *deviceAPIKeyRepository) Update(key *entity.DeviceAPIKey) error {
+	return r.db.Save(key).Error
+}
+
+func (r *deviceAPIKeyRepository) TouchLastUsed(id int64, at time.Time) error {
+	return r.db.Model(&entity.DeviceAPIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
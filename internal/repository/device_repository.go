@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DeviceRepository interface {
+	FindAll(params model.DeviceListParams) ([]entity.Device, int64, error)
+	FindByID(id int64) (*entity.Device, error)
+	FindByIMEI(imei string) (*entity.Device, error)
+	FindActiveByCarID(carID int64) (*entity.Device, error)
+	Create(device *entity.Device) error
+	Update(device *entity.Device) error
+	Delete(id int64) error
+	TouchLastSeen(id int64, at time.Time) error
+}
+
+type deviceRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceRepository(db *gorm.DB) DeviceRepository {
+	return &deviceRepository{db: db}
+}
+
+func (r *deviceRepository) FindAll(params model.DeviceListParams) ([]entity.Device, int64, error) {
+	var devices []entity.Device
+	var total int64
+
+	query := r.db.Model(&entity.Device{}).Preload("Car")
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.Protocol != "" {
+		query = query.Where("protocol = ?", params.Protocol)
+	}
+	if params.Active != nil {
+		query = query.Where("active = ?", *params.Active)
+	}
+	if params.Search != "" {
+		search := "%" + params.Search + "%"
+		query = query.Where("name ILIKE ? OR imei ILIKE ?", search, search)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("id DESC").Find(&devices).Error
+	return devices, total, err
+}
+
+func (r *deviceRepository) FindByID(id int64) (*entity.Device, error) {
+	var device entity.Device
+	err := r.db.Preload("Car").First(&device, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *deviceRepository) FindByIMEI(imei string) (*entity.Device, error) {
+	var device entity.Device
+	err := r.db.Where("imei = ?", imei).First(&device).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *deviceRepository) FindActiveByCarID(carID int64) (*entity.Device, error) {
+	var device entity.Device
+	err := r.db.Where("car_id = ? AND active = ?", carID, true).First(&device).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *deviceRepository) Create(device *entity.Device) error {
+	return r.db.Create(device).Error
+}
+
+func (r *deviceRepository) Update(device *entity.Device) error {
+	return r.db.Save(device).Error
+}
+
+func (r *deviceRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.Device{}, id).Error
+}
+
+func (r *deviceRepository) TouchLastSeen(id int64, at time.Time) error {
+	return r.db.Model(&entity.Device{}).Where("id = ?", id).UpdateColumn("last_seen_at", at).Error
+}
@@ -5,11 +5,15 @@ import (
 	"fleet-monitor/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DriverRepository interface {
+	WithTx(tx *gorm.DB) DriverRepository
 	FindAll(params model.DriverListParams) ([]entity.Driver, int64, error)
 	FindByID(id int64) (*entity.Driver, error)
+	FindByIDForUpdate(id int64) (*entity.Driver, error)
+	FindByUserID(userID int64) (*entity.Driver, error)
 	Create(driver *entity.Driver) error
 	Update(driver *entity.Driver) error
 	Delete(id int64) error
@@ -26,6 +30,10 @@ func NewDriverRepository(db *gorm.DB) DriverRepository {
 	return &driverRepository{db: db}
 }
 
+func (r *driverRepository) WithTx(tx *gorm.DB) DriverRepository {
+	return &driverRepository{db: tx}
+}
+
 func (r *driverRepository) FindAll(params model.DriverListParams) ([]entity.Driver, int64, error) {
 	var drivers []entity.Driver
 	var total int64
@@ -63,6 +71,25 @@ func (r *driverRepository) FindByID(id int64) (*entity.Driver, error) {
 	return &driver, nil
 }
 
+// FindByIDForUpdate locks the driver row until the surrounding transaction ends.
+func (r *driverRepository) FindByIDForUpdate(id int64) (*entity.Driver, error) {
+	var driver entity.Driver
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&driver, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
+func (r *driverRepository) FindByUserID(userID int64) (*entity.Driver, error) {
+	var driver entity.Driver
+	err := r.db.Where("user_id = ?", userID).First(&driver).Error
+	if err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
 func (r *driverRepository) Create(driver *entity.Driver) error {
 	return r.db.Create(driver).Error
 }
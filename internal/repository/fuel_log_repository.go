@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type FuelLogRepository interface {
+	WithTx(tx *gorm.DB) FuelLogRepository
+	FindAll(params model.FuelLogListParams) ([]entity.FuelLog, int64, error)
+	FindByID(id int64) (*entity.FuelLog, error)
+	FindByCarID(carID int64) ([]entity.FuelLog, error)
+	Create(log *entity.FuelLog) error
+	Update(log *entity.FuelLog) error
+	UpdateAnalysis(log *entity.FuelLog) error
+	Delete(id int64) error
+	Efficiency(params model.FuelEfficiencyParams) ([]model.FuelEfficiencyItem, error)
+}
+
+type fuelLogRepository struct {
+	db *gorm.DB
+}
+
+func NewFuelLogRepository(db *gorm.DB) FuelLogRepository {
+	return &fuelLogRepository{db: db}
+}
+
+func (r *fuelLogRepository) WithTx(tx *gorm.DB) FuelLogRepository {
+	return &fuelLogRepository{db: tx}
+}
+
+func (r *fuelLogRepository) FindAll(params model.FuelLogListParams) ([]entity.FuelLog, int64, error) {
+	var logs []entity.FuelLog
+	var total int64
+
+	query := r.db.Model(&entity.FuelLog{})
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.DriverID > 0 {
+		query = query.Where("driver_id = ?", params.DriverID)
+	}
+	if params.TripID > 0 {
+		query = query.Where("trip_id = ?", params.TripID)
+	}
+	if params.Flagged != nil {
+		query = query.Where("flagged = ?", *params.Flagged)
+	}
+	if params.From != nil {
+		query = query.Where("filled_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("filled_at < ?", *params.To)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Preload("Car").Preload("Driver").Offset(offset).Limit(params.Limit).Order("filled_at DESC, id DESC").Find(&logs).Error
+	return logs, total, err
+}
+
+func (r *fuelLogRepository) FindByID(id int64) (*entity.FuelLog, error) {
+	var log entity.FuelLog
+	err := r.db.Preload("Car").Preload("Driver").First(&log, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// FindByCarID returns all of a car's fills, oldest first.
+func (r *fuelLogRepository) FindByCarID(carID int64) ([]entity.FuelLog, error) {
+	var logs []entity.FuelLog
+	err := r.db.Where("car_id = ?", carID).Order("filled_at ASC, id ASC").Find(&logs).Error
+	return logs, err
+}
+
+func (r *fuelLogRepository) Create(log *entity.FuelLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *fuelLogRepository) Update(log *entity.FuelLog) error {
+	return r.db.Omit("Car", "Driver").Save(log).Error
+}
+
+// UpdateAnalysis saves only the derived efficiency fields.
+func (r *fuelLogRepository) UpdateAnalysis(log *entity.FuelLog) error {
+	return r.db.Model(&entity.FuelLog{}).Where("id = ?", log.ID).Updates(map[string]interface{}{
+		"distance_km":           log.DistanceKm,
+		"interval_liters":       log.IntervalLiters,
+		"km_per_liter":          log.KmPerLiter,
+		"baseline_km_per_liter": log.BaselineKmPerLiter,
+		"flagged":               log.Flagged,
+		"flag_reason":           log.FlagReason,
+	}).Error
+}
+
+func (r *fuelLogRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.FuelLog{}, id).Error
+}
+
+// Efficiency sums fills per car, most fuel first.
+func (r *fuelLogRepository) Efficiency(params model.FuelEfficiencyParams) ([]model.FuelEfficiencyItem, error) {
+	var items []model.FuelEfficiencyItem
+
+	query := r.db.Table("fuel_logs f").
+		Select(`f.car_id, c.license_plate,
+			COUNT(*) AS fills,
+			COALESCE(SUM(f.liters), 0) AS liters,
+			COALESCE(SUM(f.total_cost), 0) AS total_cost,
+			COALESCE(SUM(f.distance_km) FILTER (WHERE f.km_per_liter IS NOT NULL), 0) AS measured_km,
+			COALESCE(SUM(f.interval_liters) FILTER (WHERE f.km_per_liter IS NOT NULL), 0) AS measured_liters,
+			COUNT(*) FILTER (WHERE f.flagged) AS flagged_fills,
+			MAX(f.filled_at) AS last_filled_at`).
+		Joins("JOIN cars c ON c.id = f.car_id")
+
+	if params.CarID > 0 {
+		query = query.Where("f.car_id = ?", params.CarID)
+	}
+	if params.From != nil {
+		query = query.Where("f.filled_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("f.filled_at < ?", *params.To)
+	}
+
+	err := query.Group("f.car_id, c.license_plate").Order("liters DESC").Scan(&items).Error
+	return items, err
+}
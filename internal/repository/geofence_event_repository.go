@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type GeofenceEventRepository interface {
+	WithTx(tx *gorm.DB) GeofenceEventRepository
+	Create(event *entity.GeofenceEvent) error
+	FindAll(params model.GeofenceEventListParams) ([]entity.GeofenceEvent, int64, error)
+	FindLatestByCarID(carID int64) (map[int64]entity.GeofenceEvent, error)
+}
+
+type geofenceEventRepository struct {
+	db *gorm.DB
+}
+
+func NewGeofenceEventRepository(db *gorm.DB) GeofenceEventRepository {
+	return &geofenceEventRepository{db: db}
+}
+
+func (r *geofenceEventRepository) WithTx(tx *gorm.DB) GeofenceEventRepository {
+	return &geofenceEventRepository{db: tx}
+}
+
+func (r *geofenceEventRepository) Create(event *entity.GeofenceEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *geofenceEventRepository) FindAll(params model.GeofenceEventListParams) ([]entity.GeofenceEvent, int64, error) {
+	var events []entity.GeofenceEvent
+	var total int64
+
+	query := r.db.Model(&entity.GeofenceEvent{}).Preload("Geofence").Preload("Car")
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.GeofenceID > 0 {
+		query = query.Where("geofence_id = ?", params.GeofenceID)
+	}
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+	if params.From != nil {
+		query = query.Where("occurred_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("occurred_at <= ?", *params.To)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("occurred_at DESC, id DESC").Find(&events).Error
+	return events, total, err
+}
+
+// FindLatestByCarID returns the most recent event per geofence for the car,
+// keyed by geofence ID. A car is inside a geofence when its latest event is ENTER.
+func (r *geofenceEventRepository) FindLatestByCarID(carID int64) (map[int64]entity.GeofenceEvent, error) {
+	var events []entity.GeofenceEvent
+	err := r.db.Select("DISTINCT ON (geofence_id) *").
+		Where("car_id = ?", carID).
+		Order("geofence_id, occurred_at DESC, id DESC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[int64]entity.GeofenceEvent, len(events))
+	for _, event := range events {
+		latest[event.GeofenceID] = event
+	}
+	return latest, nil
+}
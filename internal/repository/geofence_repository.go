@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type GeofenceRepository interface {
+	WithTx(tx *gorm.DB) GeofenceRepository
+	FindAll(params model.GeofenceListParams) ([]entity.Geofence, int64, error)
+	FindActive() ([]entity.Geofence, error)
+	FindByID(id int64) (*entity.Geofence, error)
+	Create(geofence *entity.Geofence) error
+	Update(geofence *entity.Geofence) error
+	Delete(id int64) error
+}
+
+type geofenceRepository struct {
+	db *gorm.DB
+}
+
+func NewGeofenceRepository(db *gorm.DB) GeofenceRepository {
+	return &geofenceRepository{db: db}
+}
+
+func (r *geofenceRepository) WithTx(tx *gorm.DB) GeofenceRepository {
+	return &geofenceRepository{db: tx}
+}
+
+func (r *geofenceRepository) FindAll(params model.GeofenceListParams) ([]entity.Geofence, int64, error) {
+	var geofences []entity.Geofence
+	var total int64
+
+	query := r.db.Model(&entity.Geofence{})
+
+	if params.Active != nil {
+		query = query.Where("active = ?", *params.Active)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("name ASC").Find(&geofences).Error
+	return geofences, total, err
+}
+
+func (r *geofenceRepository) FindActive() ([]entity.Geofence, error) {
+	var geofences []entity.Geofence
+	err := r.db.Where("active = ?", true).Find(&geofences).Error
+	return geofences, err
+}
+
+func (r *geofenceRepository) FindByID(id int64) (*entity.Geofence, error) {
+	var geofence entity.Geofence
+	err := r.db.First(&geofence, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &geofence, nil
+}
+
+func (r *geofenceRepository) Create(geofence *entity.Geofence) error {
+	return r.db.Create(geofence).Error
+}
+
+func (r *geofenceRepository) Update(geofence *entity.Geofence) error {
+	return r.db.Save(geofence).Error
+}
+
+func (r *geofenceRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.Geofence{}, id).Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type InspectionChecklistRepository interface {
+	WithTx(tx *gorm.DB) InspectionChecklistRepository
+	FindAll() ([]entity.InspectionChecklistItem, error)
+	FindActiveByStage(stage string) ([]entity.InspectionChecklistItem, error)
+	FindByID(id int64) (*entity.InspectionChecklistItem, error)
+	FindByCode(code string) (*entity.InspectionChecklistItem, error)
+	Create(item *entity.InspectionChecklistItem) error
+	Update(item *entity.InspectionChecklistItem) error
+	Delete(id int64) error
+}
+
+type inspectionChecklistRepository struct {
+	db *gorm.DB
+}
+
+func NewInspectionChecklistRepository(db *gorm.DB) InspectionChecklistRepository {
+	return &inspectionChecklistRepository{db: db}
+}
+
+func (r *inspectionChecklistRepository) WithTx(tx *gorm.DB) InspectionChecklistRepository {
+	return &inspectionChecklistRepository{db: tx}
+}
+
+func (r *inspectionChecklistRepository) FindAll() ([]entity.InspectionChecklistItem, error) {
+	var items []entity.InspectionChecklistItem
+	err := r.db.Order("sort_order ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+// FindActiveByStage returns active items for the stage, including items marked BOTH.
+func (r *inspectionChecklistRepository) FindActiveByStage(stage string) ([]entity.InspectionChecklistItem, error) {
+	var items []entity.InspectionChecklistItem
+	err := r.db.Where("active = ? AND stage IN ?", true, []string{stage, entity.InspectionStageBoth}).
+		Order("sort_order ASC, id ASC").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *inspectionChecklistRepository) FindByID(id int64) (*entity.InspectionChecklistItem, error) {
+	var item entity.InspectionChecklistItem
+	err := r.db.First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *inspectionChecklistRepository) FindByCode(code string) (*entity.InspectionChecklistItem, error) {
+	var item entity.InspectionChecklistItem
+	err := r.db.Where("code = ?", code).First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *inspectionChecklistRepository) Create(item *entity.InspectionChecklistItem) error {
+	return r.db.Create(item).Error
+}
+
+func (r *inspectionChecklistRepository) Update(item *entity.InspectionChecklistItem) error {
+	return r.db.Save(item).Error
+}
+
+func (r *inspectionChecklistRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.InspectionChecklistItem{}, id).Error
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type MaintenancePlanRepository interface {
+	FindAll(params model.MaintenancePlanListParams) ([]entity.MaintenancePlan, int64, error)
+	FindByID(id int64) (*entity.MaintenancePlan, error)
+	FindActive() ([]entity.MaintenancePlan, error)
+	Create(plan *entity.MaintenancePlan) error
+	Update(plan *entity.MaintenancePlan) error
+	Delete(id int64) error
+}
+
+type maintenancePlanRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenancePlanRepository(db *gorm.DB) MaintenancePlanRepository {
+	return &maintenancePlanRepository{db: db}
+}
+
+func (r *maintenancePlanRepository) FindAll(params model.MaintenancePlanListParams) ([]entity.MaintenancePlan, int64, error) {
+	var plans []entity.MaintenancePlan
+	var total int64
+
+	query := r.db.Model(&entity.MaintenancePlan{}).Preload("Car")
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("id DESC").Find(&plans).Error
+	return plans, total, err
+}
+
+func (r *maintenancePlanRepository) FindByID(id int64) (*entity.MaintenancePlan, error) {
+	var plan entity.MaintenancePlan
+	err := r.db.Preload("Car").First(&plan, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *maintenancePlanRepository) FindActive() ([]entity.MaintenancePlan, error) {
+	var plans []entity.MaintenancePlan
+	err := r.db.Where("active = ?", true).Order("id ASC").Find(&plans).Error
+	return plans, err
+}
+
+func (r *maintenancePlanRepository) Create(plan *entity.MaintenancePlan) error {
+	return r.db.Create(plan).Error
+}
+
+func (r *maintenancePlanRepository) Update(plan *entity.MaintenancePlan) error {
+	return r.db.Save(plan).Error
+}
+
+func (r *maintenancePlanRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.MaintenancePlan{}, id).Error
+}
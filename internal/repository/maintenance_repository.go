@@ -5,14 +5,20 @@ import (
 	"fleet-monitor/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MaintenanceRepository interface {
+	WithTx(tx *gorm.DB) MaintenanceRepository
 	FindAll(params model.MaintenanceListParams) ([]entity.Maintenance, int64, error)
 	FindByID(id int64) (*entity.Maintenance, error)
+	FindByIDForUpdate(id int64) (*entity.Maintenance, error)
+	CountInProgressByCarID(carID int64) (int64, error)
 	FindByCarID(carID int64) ([]entity.Maintenance, error)
+	FindLastForPlan(carID int64, plan *entity.MaintenancePlan) (*entity.Maintenance, error)
 	Create(maintenance *entity.Maintenance) error
 	Update(maintenance *entity.Maintenance) error
+	ReplaceItems(maintenanceID int64, items []entity.MaintenanceItem) error
 	Delete(id int64) error
 }
 
@@ -24,15 +30,27 @@ func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
 	return &maintenanceRepository{db: db}
 }
 
+func (r *maintenanceRepository) WithTx(tx *gorm.DB) MaintenanceRepository {
+	return &maintenanceRepository{db: tx}
+}
+
 func (r *maintenanceRepository) FindAll(params model.MaintenanceListParams) ([]entity.Maintenance, int64, error) {
 	var maintenances []entity.Maintenance
 	var total int64
 
-	query := r.db.Model(&entity.Maintenance{}).Preload("Car")
+	query := r.db.Model(&entity.Maintenance{}).Preload("Car").Preload("Workshop").Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	})
 
 	if params.CarID > 0 {
 		query = query.Where("car_id = ?", params.CarID)
 	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.WorkshopID > 0 {
+		query = query.Where("workshop_id = ?", params.WorkshopID)
+	}
 
 	query.Count(&total)
 
@@ -50,25 +68,79 @@ func (r *maintenanceRepository) FindAll(params model.MaintenanceListParams) ([]e
 
 func (r *maintenanceRepository) FindByID(id int64) (*entity.Maintenance, error) {
 	var maintenance entity.Maintenance
-	err := r.db.Preload("Car").First(&maintenance, id).Error
+	err := r.db.Preload("Car").Preload("Workshop").Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&maintenance, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &maintenance, nil
 }
 
+// FindByIDForUpdate loads a work order with its items and locks the work
+// order row until the transaction ends.
+func (r *maintenanceRepository) FindByIDForUpdate(id int64) (*entity.Maintenance, error) {
+	var maintenance entity.Maintenance
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&maintenance, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &maintenance, nil
+}
+
+// CountInProgressByCarID counts the work orders currently keeping a car in
+// the workshop.
+func (r *maintenanceRepository) CountInProgressByCarID(carID int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Maintenance{}).
+		Where("car_id = ? AND status = ?", carID, entity.MaintenanceStatusInProgress).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *maintenanceRepository) FindByCarID(carID int64) ([]entity.Maintenance, error) {
 	var maintenances []entity.Maintenance
 	err := r.db.Where("car_id = ?", carID).Order("service_date DESC").Find(&maintenances).Error
 	return maintenances, err
 }
 
+// FindLastForPlan returns the latest completed service of a car that was
+// recorded against the plan.
+func (r *maintenanceRepository) FindLastForPlan(carID int64, plan *entity.MaintenancePlan) (*entity.Maintenance, error) {
+	var maintenance entity.Maintenance
+	err := r.db.Where("car_id = ? AND status = ? AND maintenance_plan_id = ?", carID, entity.MaintenanceStatusCompleted, plan.ID).
+		Order("service_date DESC").
+		First(&maintenance).Error
+	if err != nil {
+		return nil, err
+	}
+	return &maintenance, nil
+}
+
+// Create saves the work order together with its items.
 func (r *maintenanceRepository) Create(maintenance *entity.Maintenance) error {
 	return r.db.Create(maintenance).Error
 }
 
+// Update saves the work order header; items are changed with ReplaceItems.
 func (r *maintenanceRepository) Update(maintenance *entity.Maintenance) error {
-	return r.db.Save(maintenance).Error
+	return r.db.Omit("Items").Save(maintenance).Error
+}
+
+func (r *maintenanceRepository) ReplaceItems(maintenanceID int64, items []entity.MaintenanceItem) error {
+	if err := r.db.Where("maintenance_id = ?", maintenanceID).Delete(&entity.MaintenanceItem{}).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	for i := range items {
+		items[i].ID = 0
+		items[i].MaintenanceID = maintenanceID
+	}
+	return r.db.Create(&items).Error
 }
 
 func (r *maintenanceRepository) Delete(id int64) error {
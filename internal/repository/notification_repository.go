@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	WithTx(tx *gorm.DB) NotificationRepository
+	FindAll(params model.NotificationListParams) ([]entity.Notification, int64, error)
+	FindDueForUpdate(now time.Time, limit int) ([]entity.Notification, error)
+	CreateIfAbsent(notification *entity.Notification) (bool, error)
+	Update(notification *entity.Notification) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) WithTx(tx *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: tx}
+}
+
+func (r *notificationRepository) FindAll(params model.NotificationListParams) ([]entity.Notification, int64, error) {
+	var notifications []entity.Notification
+	var total int64
+
+	query := r.db.Model(&entity.Notification{})
+
+	if params.UserID > 0 {
+		query = query.Where("user_id = ?", params.UserID)
+	}
+	if params.EventType != "" {
+		query = query.Where("event_type = ?", params.EventType)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("created_at DESC, id DESC").Find(&notifications).Error
+	return notifications, total, err
+}
+
+// FindDueForUpdate locks pending notifications whose next attempt is due.
+// Rows locked by another dispatcher are skipped rather than waited for.
+func (r *notificationRepository) FindDueForUpdate(now time.Time, limit int) ([]entity.Notification, error) {
+	var notifications []entity.Notification
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", entity.NotificationStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// CreateIfAbsent inserts the notification unless one with the same
+// subscription and dedupe key exists, and reports whether a row was written.
+func (r *notificationRepository) CreateIfAbsent(notification *entity.Notification) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *notificationRepository) Update(notification *entity.Notification) error {
+	return r.db.Save(notification).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type NotificationSubscriptionRepository interface {
+	WithTx(tx *gorm.DB) NotificationSubscriptionRepository
+	FindByUserID(userID int64) ([]entity.NotificationSubscription, error)
+	FindByID(id int64) (*entity.NotificationSubscription, error)
+	FindActiveByEvent(eventType string) ([]entity.NotificationSubscription, error)
+	Create(subscription *entity.NotificationSubscription) error
+	Update(subscription *entity.NotificationSubscription) error
+	Delete(id int64) error
+}
+
+type notificationSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationSubscriptionRepository(db *gorm.DB) NotificationSubscriptionRepository {
+	return &notificationSubscriptionRepository{db: db}
+}
+
+func (r *notificationSubscriptionRepository) WithTx(tx *gorm.DB) NotificationSubscriptionRepository {
+	return &notificationSubscriptionRepository{db: tx}
+}
+
+func (r *notificationSubscriptionRepository) FindByUserID(userID int64) ([]entity.NotificationSubscription, error) {
+	var subscriptions []entity.NotificationSubscription
+	err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *notificationSubscriptionRepository) FindByID(id int64) (*entity.NotificationSubscription, error) {
+	var subscription entity.NotificationSubscription
+	err := r.db.First(&subscription, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// FindActiveByEvent returns the active subscriptions whose comma separated
+// event list contains eventType.
+func (r *notificationSubscriptionRepository) FindActiveByEvent(eventType string) ([]entity.NotificationSubscription, error) {
+	var subscriptions []entity.NotificationSubscription
+	err := r.db.
+		Where("active = ? AND ',' || event_types || ',' LIKE ?", true, "%,"+eventType+",%").
+		Order("id ASC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *notificationSubscriptionRepository) Create(subscription *entity.NotificationSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+func (r *notificationSubscriptionRepository) Update(subscription *entity.NotificationSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+func (r *notificationSubscriptionRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.NotificationSubscription{}, id).Error
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OdometerRepository interface {
+	WithTx(tx *gorm.DB) OdometerRepository
+	FindAll(params model.OdometerListParams) ([]entity.OdometerReading, int64, error)
+	FindByID(id int64) (*entity.OdometerReading, error)
+	FindByIDForUpdate(id int64) (*entity.OdometerReading, error)
+	FindLatestByCarID(carID int64) (*entity.OdometerReading, error)
+	FindLatestByMaintenanceID(maintenanceID int64) (*entity.OdometerReading, error)
+	Create(reading *entity.OdometerReading) error
+	Update(reading *entity.OdometerReading) error
+	CountUnreviewed() (int64, error)
+}
+
+type odometerRepository struct {
+	db *gorm.DB
+}
+
+func NewOdometerRepository(db *gorm.DB) OdometerRepository {
+	return &odometerRepository{db: db}
+}
+
+func (r *odometerRepository) WithTx(tx *gorm.DB) OdometerRepository {
+	return &odometerRepository{db: tx}
+}
+
+func (r *odometerRepository) FindAll(params model.OdometerListParams) ([]entity.OdometerReading, int64, error) {
+	var readings []entity.OdometerReading
+	var total int64
+
+	query := r.db.Model(&entity.OdometerReading{}).Preload("Car")
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.Source != "" {
+		query = query.Where("source = ?", params.Source)
+	}
+	if params.Flagged != nil {
+		query = query.Where("flagged = ?", *params.Flagged)
+	}
+	if params.Reviewed != nil {
+		if *params.Reviewed {
+			query = query.Where("reviewed_at IS NOT NULL")
+		} else {
+			query = query.Where("reviewed_at IS NULL")
+		}
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("recorded_at DESC, id DESC").Find(&readings).Error
+	return readings, total, err
+}
+
+func (r *odometerRepository) FindByID(id int64) (*entity.OdometerReading, error) {
+	var reading entity.OdometerReading
+	err := r.db.Preload("Car").First(&reading, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+func (r *odometerRepository) FindByIDForUpdate(id int64) (*entity.OdometerReading, error) {
+	var reading entity.OdometerReading
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reading, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+// FindLatestByCarID returns the car's most recently recorded reading.
+func (r *odometerRepository) FindLatestByCarID(carID int64) (*entity.OdometerReading, error) {
+	var reading entity.OdometerReading
+	err := r.db.Where("car_id = ?", carID).Order("recorded_at DESC, id DESC").First(&reading).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+// FindLatestByMaintenanceID returns the last workshop reading recorded with a
+// work order, skipping readings a reviewer rejected.
+func (r *odometerRepository) FindLatestByMaintenanceID(maintenanceID int64) (*entity.OdometerReading, error) {
+	var reading entity.OdometerReading
+	err := r.db.Where("maintenance_id = ? AND accepted IS NOT FALSE", maintenanceID).
+		Order("recorded_at DESC, id DESC").
+		First(&reading).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+func (r *odometerRepository) Create(reading *entity.OdometerReading) error {
+	return r.db.Create(reading).Error
+}
+
+func (r *odometerRepository) Update(reading *entity.OdometerReading) error {
+	return r.db.Save(reading).Error
+}
+
+// CountUnreviewed counts flagged readings nobody has looked at yet.
+func (r *odometerRepository) CountUnreviewed() (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.OdometerReading{}).
+		Where("flagged = ? AND reviewed_at IS NULL", true).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PartRepository interface {
+	WithTx(tx *gorm.DB) PartRepository
+	FindAll(params model.PartListParams) ([]entity.Part, int64, error)
+	FindByID(id int64) (*entity.Part, error)
+	FindByIDForUpdate(id int64) (*entity.Part, error)
+	FindByIDsForUpdate(ids []int64) ([]entity.Part, error)
+	FindByPartNumber(partNumber string) (*entity.Part, error)
+	FindLowStock() ([]entity.Part, error)
+	Create(part *entity.Part) error
+	Update(part *entity.Part) error
+	UpdateStock(id int64, stock float64) error
+	Delete(id int64) error
+}
+
+type partRepository struct {
+	db *gorm.DB
+}
+
+func NewPartRepository(db *gorm.DB) PartRepository {
+	return &partRepository{db: db}
+}
+
+func (r *partRepository) WithTx(tx *gorm.DB) PartRepository {
+	return &partRepository{db: tx}
+}
+
+func (r *partRepository) FindAll(params model.PartListParams) ([]entity.Part, int64, error) {
+	var parts []entity.Part
+	var total int64
+
+	query := r.db.Model(&entity.Part{})
+
+	if params.Search != "" {
+		search := "%" + params.Search + "%"
+		query = query.Where("part_number ILIKE ? OR name ILIKE ?", search, search)
+	}
+	if params.Category != "" {
+		query = query.Where("LOWER(category) = LOWER(?)", params.Category)
+	}
+	if params.LowStock {
+		query = query.Where("reorder_level > 0 AND stock <= reorder_level")
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("part_number ASC").Find(&parts).Error
+	return parts, total, err
+}
+
+func (r *partRepository) FindByID(id int64) (*entity.Part, error) {
+	var part entity.Part
+	err := r.db.First(&part, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
+
+// FindByIDForUpdate locks the part row until the surrounding transaction ends.
+func (r *partRepository) FindByIDForUpdate(id int64) (*entity.Part, error) {
+	var part entity.Part
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&part, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
+
+// FindByIDsForUpdate locks several parts in id order, so that work orders
+// touching the same parts cannot deadlock each other.
+func (r *partRepository) FindByIDsForUpdate(ids []int64) ([]entity.Part, error) {
+	var parts []entity.Part
+	if len(ids) == 0 {
+		return parts, nil
+	}
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Find(&parts).Error
+	return parts, err
+}
+
+func (r *partRepository) FindByPartNumber(partNumber string) (*entity.Part, error) {
+	var part entity.Part
+	err := r.db.Where("part_number = ?", partNumber).First(&part).Error
+	if err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
+
+// FindLowStock returns parts at or below their reorder level, the emptiest
+// first. Parts without a reorder level are never low.
+func (r *partRepository) FindLowStock() ([]entity.Part, error) {
+	var parts []entity.Part
+	err := r.db.Where("reorder_level > 0 AND stock <= reorder_level").
+		Order("stock / reorder_level ASC, part_number ASC").
+		Find(&parts).Error
+	return parts, err
+}
+
+func (r *partRepository) Create(part *entity.Part) error {
+	return r.db.Create(part).Error
+}
+
+// Update saves the catalog fields. Stock is left alone; it only changes
+// through UpdateStock.
+func (r *partRepository) Update(part *entity.Part) error {
+	return r.db.Model(part).Select("part_number", "name", "category", "unit", "unit_cost", "reorder_level", "reorder_quantity", "updated_at").Updates(part).Error
+}
+
+func (r *partRepository) UpdateStock(id int64, stock float64) error {
+	return r.db.Model(&entity.Part{}).Where("id = ?", id).Update("stock", stock).Error
+}
+
+func (r *partRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.Part{}, id).Error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationRepository interface {
+	WithTx(tx *gorm.DB) ReservationRepository
+	FindAll(params model.ReservationListParams) ([]entity.Reservation, error)
+	FindByID(id int64) (*entity.Reservation, error)
+	FindByIDForUpdate(id int64) (*entity.Reservation, error)
+	FindOverlapping(carID int64, driverID *int64, start, end time.Time, excludeID int64) ([]entity.Reservation, error)
+	FindPickupForCar(carID int64, at time.Time, lead time.Duration) (*entity.Reservation, error)
+	Create(reservation *entity.Reservation) error
+	Update(reservation *entity.Reservation) error
+}
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+func (r *reservationRepository) WithTx(tx *gorm.DB) ReservationRepository {
+	return &reservationRepository{db: tx}
+}
+
+func (r *reservationRepository) FindAll(params model.ReservationListParams) ([]entity.Reservation, error) {
+	var reservations []entity.Reservation
+
+	query := r.db.Preload("Car").Preload("Driver").Preload("Requester")
+
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.DriverID > 0 {
+		query = query.Where("driver_id = ?", params.DriverID)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.From != nil {
+		query = query.Where("end_time > ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("start_time < ?", *params.To)
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = 500
+	}
+
+	err := query.Order("start_time ASC").Limit(params.Limit).Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *reservationRepository) FindByID(id int64) (*entity.Reservation, error) {
+	var reservation entity.Reservation
+	err := r.db.Preload("Car").Preload("Driver").Preload("Requester").First(&reservation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *reservationRepository) FindByIDForUpdate(id int64) (*entity.Reservation, error) {
+	var reservation entity.Reservation
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reservation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// FindOverlapping returns confirmed reservations for the car, or for the driver
+// when one is given, whose time range intersects [start, end).
+func (r *reservationRepository) FindOverlapping(carID int64, driverID *int64, start, end time.Time, excludeID int64) ([]entity.Reservation, error) {
+	var reservations []entity.Reservation
+
+	query := r.db.Preload("Car").Preload("Driver").
+		Where("status = ?", entity.ReservationStatusConfirmed).
+		Where("start_time < ? AND end_time > ?", end, start)
+
+	if driverID != nil {
+		query = query.Where("(car_id = ? OR driver_id = ?)", carID, *driverID)
+	} else {
+		query = query.Where("car_id = ?", carID)
+	}
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	err := query.Order("start_time ASC").Find(&reservations).Error
+	return reservations, err
+}
+
+// FindPickupForCar returns the confirmed reservation a checkout at the given
+// time would fall into. Pickup may start up to lead before the booked start.
+func (r *reservationRepository) FindPickupForCar(carID int64, at time.Time, lead time.Duration) (*entity.Reservation, error) {
+	var reservation entity.Reservation
+	err := r.db.Preload("Driver").
+		Where("car_id = ? AND status = ?", carID, entity.ReservationStatusConfirmed).
+		Where("start_time <= ? AND end_time > ?", at.Add(lead), at).
+		Order("start_time ASC").
+		First(&reservation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *reservationRepository) Create(reservation *entity.Reservation) error {
+	return r.db.Create(reservation).Error
+}
+
+func (r *reservationRepository) Update(reservation *entity.Reservation) error {
+	return r.db.Save(reservation).Error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type StockMovementRepository interface {
+	WithTx(tx *gorm.DB) StockMovementRepository
+	FindAll(params model.StockMovementListParams) ([]entity.StockMovement, int64, error)
+	IssuedByMaintenance(maintenanceID int64) (map[int64]float64, error)
+	CountByPartID(partID int64) (int64, error)
+	Create(movement *entity.StockMovement) error
+}
+
+type stockMovementRepository struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) StockMovementRepository {
+	return &stockMovementRepository{db: db}
+}
+
+func (r *stockMovementRepository) WithTx(tx *gorm.DB) StockMovementRepository {
+	return &stockMovementRepository{db: tx}
+}
+
+func (r *stockMovementRepository) FindAll(params model.StockMovementListParams) ([]entity.StockMovement, int64, error) {
+	var movements []entity.StockMovement
+	var total int64
+
+	query := r.db.Model(&entity.StockMovement{})
+
+	if params.PartID > 0 {
+		query = query.Where("part_id = ?", params.PartID)
+	}
+	if params.MaintenanceID > 0 {
+		query = query.Where("maintenance_id = ?", params.MaintenanceID)
+	}
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Preload("Part").Offset(offset).Limit(params.Limit).Order("created_at DESC, id DESC").Find(&movements).Error
+	return movements, total, err
+}
+
+// IssuedByMaintenance returns, per part, how much is currently out to the
+// work order: issues less returns.
+func (r *stockMovementRepository) IssuedByMaintenance(maintenanceID int64) (map[int64]float64, error) {
+	var rows []struct {
+		PartID   int64
+		Quantity float64
+	}
+	err := r.db.Model(&entity.StockMovement{}).
+		Select("part_id, -SUM(quantity) AS quantity").
+		Where("maintenance_id = ? AND type IN ?", maintenanceID, []string{entity.StockMovementIssue, entity.StockMovementReturn}).
+		Group("part_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	issued := make(map[int64]float64, len(rows))
+	for _, row := range rows {
+		issued[row.PartID] = row.Quantity
+	}
+	return issued, nil
+}
+
+func (r *stockMovementRepository) CountByPartID(partID int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.StockMovement{}).Where("part_id = ?", partID).Count(&count).Error
+	return count, err
+}
+
+func (r *stockMovementRepository) Create(movement *entity.StockMovement) error {
+	return r.db.Create(movement).Error
+}
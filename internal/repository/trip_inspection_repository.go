@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type TripInspectionRepository interface {
+	WithTx(tx *gorm.DB) TripInspectionRepository
+	Create(inspection *entity.TripInspection) error
+	FindByTripID(tripID int64) ([]entity.TripInspection, error)
+	CreatePhoto(photo *entity.TripInspectionPhoto) error
+	FindPhotosByTripID(tripID int64) ([]entity.TripInspectionPhoto, error)
+	FindPhotoByID(id int64) (*entity.TripInspectionPhoto, error)
+}
+
+type tripInspectionRepository struct {
+	db *gorm.DB
+}
+
+func NewTripInspectionRepository(db *gorm.DB) TripInspectionRepository {
+	return &tripInspectionRepository{db: db}
+}
+
+func (r *tripInspectionRepository) WithTx(tx *gorm.DB) TripInspectionRepository {
+	return &tripInspectionRepository{db: tx}
+}
+
+// Create saves the inspection together with its items.
+func (r *tripInspectionRepository) Create(inspection *entity.TripInspection) error {
+	return r.db.Create(inspection).Error
+}
+
+func (r *tripInspectionRepository) FindByTripID(tripID int64) ([]entity.TripInspection, error) {
+	var inspections []entity.TripInspection
+	err := r.db.Preload("Items").Where("trip_id = ?", tripID).Order("id ASC").Find(&inspections).Error
+	return inspections, err
+}
+
+func (r *tripInspectionRepository) CreatePhoto(photo *entity.TripInspectionPhoto) error {
+	return r.db.Create(photo).Error
+}
+
+func (r *tripInspectionRepository) FindPhotosByTripID(tripID int64) ([]entity.TripInspectionPhoto, error) {
+	var photos []entity.TripInspectionPhoto
+	err := r.db.Where("trip_id = ?", tripID).Order("id ASC").Find(&photos).Error
+	return photos, err
+}
+
+func (r *tripInspectionRepository) FindPhotoByID(id int64) (*entity.TripInspectionPhoto, error) {
+	var photo entity.TripInspectionPhoto
+	err := r.db.First(&photo, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
@@ -6,17 +6,23 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TripRepository interface {
+	WithTx(tx *gorm.DB) TripRepository
 	FindAll(params model.TripListParams) ([]entity.TripLog, int64, error)
 	FindByID(id int64) (*entity.TripLog, error)
+	FindByIDForUpdate(id int64) (*entity.TripLog, error)
+	FindActive() ([]entity.TripLog, error)
 	FindActiveByCarID(carID int64) (*entity.TripLog, error)
 	FindActiveByDriverID(driverID int64) (*entity.TripLog, error)
 	FindRecent(limit int) ([]entity.TripLog, error)
+	FindLastEndKm(carID int64, before *time.Time) (*int, error)
 	Create(trip *entity.TripLog) error
 	Update(trip *entity.TripLog) error
-	EndTrip(id int64, endKm int, notes string) error
+	EndTrip(id int64, endKm int, notes string, endTime time.Time) error
+	UpdateTrackSummary(trip *entity.TripLog) error
 }
 
 type tripRepository struct {
@@ -27,6 +33,10 @@ func NewTripRepository(db *gorm.DB) TripRepository {
 	return &tripRepository{db: db}
 }
 
+func (r *tripRepository) WithTx(tx *gorm.DB) TripRepository {
+	return &tripRepository{db: tx}
+}
+
 func (r *tripRepository) FindAll(params model.TripListParams) ([]entity.TripLog, int64, error) {
 	var trips []entity.TripLog
 	var total int64
@@ -41,11 +51,17 @@ func (r *tripRepository) FindAll(params model.TripListParams) ([]entity.TripLog,
 	}
 	if params.Active != nil {
 		if *params.Active {
-			query = query.Where("end_time IS NULL")
+			query = query.Where("status = ?", entity.TripStatusInProgress)
 		} else {
-			query = query.Where("end_time IS NOT NULL")
+			query = query.Where("status <> ?", entity.TripStatusInProgress)
 		}
 	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.DistanceMismatch != nil {
+		query = query.Where("distance_mismatch = ?", *params.DistanceMismatch)
+	}
 
 	query.Count(&total)
 
@@ -70,9 +86,25 @@ func (r *tripRepository) FindByID(id int64) (*entity.TripLog, error) {
 	return &trip, nil
 }
 
+// FindByIDForUpdate locks the trip row until the surrounding transaction ends.
+func (r *tripRepository) FindByIDForUpdate(id int64) (*entity.TripLog, error) {
+	var trip entity.TripLog
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&trip, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &trip, nil
+}
+
+func (r *tripRepository) FindActive() ([]entity.TripLog, error) {
+	var trips []entity.TripLog
+	err := r.db.Preload("Car").Where("status = ?", entity.TripStatusInProgress).Order("start_time ASC").Find(&trips).Error
+	return trips, err
+}
+
 func (r *tripRepository) FindActiveByCarID(carID int64) (*entity.TripLog, error) {
 	var trip entity.TripLog
-	err := r.db.Where("car_id = ? AND end_time IS NULL", carID).First(&trip).Error
+	err := r.db.Where("car_id = ? AND status = ?", carID, entity.TripStatusInProgress).First(&trip).Error
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +113,7 @@ func (r *tripRepository) FindActiveByCarID(carID int64) (*entity.TripLog, error)
 
 func (r *tripRepository) FindActiveByDriverID(driverID int64) (*entity.TripLog, error) {
 	var trip entity.TripLog
-	err := r.db.Where("driver_id = ? AND end_time IS NULL", driverID).First(&trip).Error
+	err := r.db.Where("driver_id = ? AND status = ?", driverID, entity.TripStatusInProgress).First(&trip).Error
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +126,21 @@ func (r *tripRepository) FindRecent(limit int) ([]entity.TripLog, error) {
 	return trips, err
 }
 
+// FindLastEndKm returns the odometer of the car's latest finished trip,
+// optionally only counting trips that ended before the given time.
+func (r *tripRepository) FindLastEndKm(carID int64, before *time.Time) (*int, error) {
+	var trip entity.TripLog
+	query := r.db.Where("car_id = ? AND end_time IS NOT NULL AND end_km IS NOT NULL", carID)
+	if before != nil {
+		query = query.Where("end_time <= ?", *before)
+	}
+	err := query.Order("end_time DESC").First(&trip).Error
+	if err != nil {
+		return nil, err
+	}
+	return trip.EndKm, nil
+}
+
 func (r *tripRepository) Create(trip *entity.TripLog) error {
 	return r.db.Create(trip).Error
 }
@@ -102,11 +149,18 @@ func (r *tripRepository) Update(trip *entity.TripLog) error {
 	return r.db.Save(trip).Error
 }
 
-func (r *tripRepository) EndTrip(id int64, endKm int, notes string) error {
-	now := time.Now()
+func (r *tripRepository) EndTrip(id int64, endKm int, notes string, endTime time.Time) error {
 	return r.db.Model(&entity.TripLog{}).Where("id = ?", id).Updates(map[string]interface{}{
-		"end_time": now,
+		"end_time": endTime,
 		"end_km":   endKm,
+		"status":   entity.TripStatusCompleted,
 		"notes":    gorm.Expr("CONCAT(notes, ?::text)", "\n"+notes),
 	}).Error
 }
+
+// UpdateTrackSummary stores the GPS summary computed at checkin.
+func (r *tripRepository) UpdateTrackSummary(trip *entity.TripLog) error {
+	return r.db.Model(trip).
+		Select("gps_distance_km", "moving_seconds", "idle_seconds", "max_speed_kmh", "distance_mismatch").
+		Updates(trip).Error
+}
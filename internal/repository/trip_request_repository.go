@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TripRequestRepository interface {
+	WithTx(tx *gorm.DB) TripRequestRepository
+	FindAll(params model.TripRequestListParams) ([]entity.TripRequest, int64, error)
+	FindByID(id int64) (*entity.TripRequest, error)
+	FindByIDForUpdate(id int64) (*entity.TripRequest, error)
+	FindApprovedForUpdate(carID, driverID int64) (*entity.TripRequest, error)
+	FindByTripID(tripID int64) (*entity.TripRequest, error)
+	Create(request *entity.TripRequest) error
+	Update(request *entity.TripRequest) error
+}
+
+type tripRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewTripRequestRepository(db *gorm.DB) TripRequestRepository {
+	return &tripRequestRepository{db: db}
+}
+
+func (r *tripRequestRepository) WithTx(tx *gorm.DB) TripRequestRepository {
+	return &tripRequestRepository{db: tx}
+}
+
+func (r *tripRequestRepository) FindAll(params model.TripRequestListParams) ([]entity.TripRequest, int64, error) {
+	var requests []entity.TripRequest
+	var total int64
+
+	query := r.db.Model(&entity.TripRequest{}).
+		Preload("Car").Preload("Driver").Preload("Requester").Preload("Approver")
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.CarID > 0 {
+		query = query.Where("car_id = ?", params.CarID)
+	}
+	if params.DriverID > 0 {
+		query = query.Where("driver_id = ?", params.DriverID)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("id DESC").Find(&requests).Error
+	return requests, total, err
+}
+
+func (r *tripRequestRepository) FindByID(id int64) (*entity.TripRequest, error) {
+	var request entity.TripRequest
+	err := r.db.Preload("Car").Preload("Driver").Preload("Requester").Preload("Approver").First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindByIDForUpdate locks the request row until the surrounding transaction ends.
+func (r *tripRequestRepository) FindByIDForUpdate(id int64) (*entity.TripRequest, error) {
+	var request entity.TripRequest
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindApprovedForUpdate locks the oldest approved request for the car and driver.
+func (r *tripRequestRepository) FindApprovedForUpdate(carID, driverID int64) (*entity.TripRequest, error) {
+	var request entity.TripRequest
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("car_id = ? AND driver_id = ? AND status = ?", carID, driverID, entity.TripRequestStatusApproved).
+		Order("id ASC").
+		First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *tripRequestRepository) FindByTripID(tripID int64) (*entity.TripRequest, error) {
+	var request entity.TripRequest
+	err := r.db.Where("trip_id = ?", tripID).First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *tripRequestRepository) Create(request *entity.TripRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *tripRequestRepository) Update(request *entity.TripRequest) error {
+	return r.db.Save(request).Error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookDeliveryRepository interface {
+	WithTx(tx *gorm.DB) WebhookDeliveryRepository
+	FindAll(params model.WebhookDeliveryListParams) ([]entity.WebhookDelivery, int64, error)
+	FindByID(id int64) (*entity.WebhookDelivery, error)
+	FindDueForUpdate(now time.Time, limit int) ([]entity.WebhookDelivery, error)
+	Create(delivery *entity.WebhookDelivery) error
+	Update(delivery *entity.WebhookDelivery) error
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) WithTx(tx *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: tx}
+}
+
+func (r *webhookDeliveryRepository) FindAll(params model.WebhookDeliveryListParams) ([]entity.WebhookDelivery, int64, error) {
+	var deliveries []entity.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&entity.WebhookDelivery{})
+
+	if params.WebhookID > 0 {
+		query = query.Where("webhook_id = ?", params.WebhookID)
+	}
+	if params.EventType != "" {
+		query = query.Where("event_type = ?", params.EventType)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("created_at DESC, id DESC").Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *webhookDeliveryRepository) FindByID(id int64) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	err := r.db.First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDueForUpdate locks pending deliveries whose next attempt is due. Rows
+// locked by another dispatcher are skipped rather than waited for.
+func (r *webhookDeliveryRepository) FindDueForUpdate(now time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", entity.WebhookDeliveryStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookDeliveryRepository) Create(delivery *entity.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// Update saves every field. Unlike Save it never inserts, so a delivery
+// removed together with its webhook is not brought back.
+func (r *webhookDeliveryRepository) Update(delivery *entity.WebhookDelivery) error {
+	return r.db.Model(delivery).Select("*").Updates(delivery).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	WithTx(tx *gorm.DB) WebhookRepository
+	FindAll() ([]entity.Webhook, error)
+	FindByID(id int64) (*entity.Webhook, error)
+	FindActiveByEvent(eventType string) ([]entity.Webhook, error)
+	Create(webhook *entity.Webhook) error
+	Update(webhook *entity.Webhook) error
+	Delete(id int64) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) WithTx(tx *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: tx}
+}
+
+func (r *webhookRepository) FindAll() ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	err := r.db.Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) FindByID(id int64) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	err := r.db.First(&webhook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// FindActiveByEvent returns the active webhooks whose comma separated event
+// list contains eventType.
+func (r *webhookRepository) FindActiveByEvent(eventType string) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	err := r.db.
+		Where("active = ? AND ',' || event_types || ',' LIKE ?", true, "%,"+eventType+",%").
+		Order("id ASC").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) Create(webhook *entity.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *webhookRepository) Update(webhook *entity.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *webhookRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.Webhook{}, id).Error
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkshopRepository interface {
+	WithTx(tx *gorm.DB) WorkshopRepository
+	FindAll(params model.WorkshopListParams) ([]entity.Workshop, int64, error)
+	FindByID(id int64) (*entity.Workshop, error)
+	FindByName(name string) (*entity.Workshop, error)
+	FindOrCreateByName(name string) (*entity.Workshop, error)
+	Create(workshop *entity.Workshop) error
+	Update(workshop *entity.Workshop) error
+	Delete(id int64) error
+	CountMaintenances(id int64) (int64, error)
+	Report(params model.WorkshopReportParams) ([]model.WorkshopReportItem, error)
+}
+
+type workshopRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkshopRepository(db *gorm.DB) WorkshopRepository {
+	return &workshopRepository{db: db}
+}
+
+func (r *workshopRepository) WithTx(tx *gorm.DB) WorkshopRepository {
+	return &workshopRepository{db: tx}
+}
+
+func (r *workshopRepository) FindAll(params model.WorkshopListParams) ([]entity.Workshop, int64, error) {
+	var workshops []entity.Workshop
+	var total int64
+
+	query := r.db.Model(&entity.Workshop{})
+
+	if params.Search != "" {
+		search := "%" + params.Search + "%"
+		query = query.Where("name ILIKE ? OR address ILIKE ? OR contact_name ILIKE ?", search, search, search)
+	}
+	if params.Specialty != "" {
+		query = query.Where("',' || LOWER(specialties) || ',' LIKE ?", "%,"+params.Specialty+",%")
+	}
+
+	query.Count(&total)
+
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	err := query.Offset(offset).Limit(params.Limit).Order("name ASC").Find(&workshops).Error
+	return workshops, total, err
+}
+
+func (r *workshopRepository) FindByID(id int64) (*entity.Workshop, error) {
+	var workshop entity.Workshop
+	err := r.db.First(&workshop, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workshop, nil
+}
+
+// FindByName matches a normalised name regardless of case.
+func (r *workshopRepository) FindByName(name string) (*entity.Workshop, error) {
+	var workshop entity.Workshop
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&workshop).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workshop, nil
+}
+
+// FindOrCreateByName returns the workshop with the normalised name, adding a
+// bare one if none exists. Concurrent callers end up with the same row.
+func (r *workshopRepository) FindOrCreateByName(name string) (*entity.Workshop, error) {
+	workshop := &entity.Workshop{Name: name}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(workshop).Error; err != nil {
+		return nil, err
+	}
+	return r.FindByName(name)
+}
+
+func (r *workshopRepository) Create(workshop *entity.Workshop) error {
+	return r.db.Create(workshop).Error
+}
+
+func (r *workshopRepository) Update(workshop *entity.Workshop) error {
+	return r.db.Save(workshop).Error
+}
+
+func (r *workshopRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.Workshop{}, id).Error
+}
+
+func (r *workshopRepository) CountMaintenances(id int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Maintenance{}).Where("workshop_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// Report sums completed work orders per workshop, biggest spend first.
+// Turnaround only counts work orders that were started before completion;
+// services recorded after the fact have no meaningful duration.
+func (r *workshopRepository) Report(params model.WorkshopReportParams) ([]model.WorkshopReportItem, error) {
+	var items []model.WorkshopReportItem
+
+	query := r.db.Table("maintenances m").
+		Select(`w.id AS workshop_id, w.name,
+			COUNT(*) AS work_orders,
+			COALESCE(SUM(m.cost), 0) AS total_spend,
+			COALESCE(SUM(m.parts_cost), 0) AS parts_cost,
+			COALESCE(SUM(m.labour_cost), 0) AS labour_cost,
+			COALESCE(SUM(m.other_cost), 0) AS other_cost,
+			COALESCE(SUM(m.tax_amount), 0) AS tax_amount,
+			AVG(EXTRACT(EPOCH FROM (m.completed_at - m.started_at)) / 3600)
+				FILTER (WHERE m.completed_at > m.started_at) AS avg_turnaround_hours`).
+		Joins("JOIN workshops w ON w.id = m.workshop_id").
+		Where("m.status = ?", entity.MaintenanceStatusCompleted)
+
+	if params.From != nil {
+		query = query.Where("m.completed_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("m.completed_at < ?", *params.To)
+	}
+
+	err := query.Group("w.id, w.name").Order("total_spend DESC, w.name ASC").Scan(&items).Error
+	return items, err
+}
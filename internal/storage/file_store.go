@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore keeps uploaded files. Implementations return an opaque key from
+// Save that is later used to Open or Delete the file.
+type FileStore interface {
+	Save(folder string, filename string, content io.Reader) (string, error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+type localFileStore struct {
+	baseDir string
+}
+
+// NewLocalFileStore stores files on the local disk below baseDir.
+func NewLocalFileStore(baseDir string) FileStore {
+	return &localFileStore{baseDir: baseDir}
+}
+
+func (s *localFileStore) Save(folder string, filename string, content io.Reader) (string, error) {
+	name, err := randomName(filepath.Ext(filename))
+	if err != nil {
+		return "", err
+	}
+
+	key := filepath.ToSlash(filepath.Join(folder, name))
+	path, err := s.resolve(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, content); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *localFileStore) Open(key string) (io.ReadCloser, error) {
+	path, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *localFileStore) Delete(key string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// resolve maps a key to a path and refuses keys that escape baseDir.
+func (s *localFileStore) resolve(key string) (string, error) {
+	base, err := filepath.Abs(s.baseDir)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(base, filepath.FromSlash(key))
+	if path != base && !strings.HasPrefix(path, base+string(filepath.Separator)) {
+		return "", errors.New("invalid file key")
+	}
+	return path, nil
+}
+
+func randomName(ext string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf) + strings.ToLower(ext), nil
+}
@@ -0,0 +1,198 @@
+package tracker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fleet-monitor/internal/model"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// GT06 message types handled by the server. Others are read and ignored.
+const (
+	gt06Login     = 0x01
+	gt06Location  = 0x12
+	gt06Heartbeat = 0x13
+	gt06Alarm     = 0x16
+	gt06Location2 = 0x22
+)
+
+type gt06Frame struct {
+	protocol byte
+	info     []byte
+	serial   uint16
+}
+
+// serveGT06 expects a login frame first, then acknowledges heartbeats and
+// alarms and forwards every located fix to the sink.
+func serveGT06(r *bufio.Reader, w io.Writer, sink Sink) error {
+	frame, err := readGT06Frame(r)
+	if err != nil {
+		return err
+	}
+	if frame.protocol != gt06Login {
+		return fmt.Errorf("expected login frame, got 0x%02x", frame.protocol)
+	}
+	imei, err := decodeGT06IMEI(frame.info)
+	if err != nil {
+		return err
+	}
+	if err := sink.Authorize(ProtocolGT06, imei); err != nil {
+		return fmt.Errorf("login rejected for %s: %w", imei, err)
+	}
+	if _, err := w.Write(gt06Reply(frame.protocol, frame.serial)); err != nil {
+		return err
+	}
+
+	for {
+		frame, err := readGT06Frame(r)
+		if err != nil {
+			return err
+		}
+
+		switch frame.protocol {
+		case gt06Location, gt06Location2, gt06Alarm:
+			pos, err := decodeGT06Position(frame.info)
+			if err != nil {
+				return err
+			}
+			pos.IMEI = imei
+			if pos.Valid {
+				reportPosition(sink, ProtocolGT06, pos)
+			}
+		}
+
+		// Location frames are fire-and-forget; the rest wait for an ack
+		switch frame.protocol {
+		case gt06Heartbeat, gt06Alarm, gt06Login:
+			if _, err := w.Write(gt06Reply(frame.protocol, frame.serial)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readGT06Frame reads one frame: start bits 0x7878 (1-byte length) or 0x7979
+// (2-byte length), protocol number, information, serial, CRC and 0x0D0A.
+// The length counts protocol number through CRC.
+func readGT06Frame(r *bufio.Reader) (gt06Frame, error) {
+	start := make([]byte, 2)
+	if _, err := io.ReadFull(r, start); err != nil {
+		return gt06Frame{}, err
+	}
+
+	var length int
+	var header []byte
+	switch {
+	case start[0] == 0x78 && start[1] == 0x78:
+		b, err := r.ReadByte()
+		if err != nil {
+			return gt06Frame{}, err
+		}
+		length = int(b)
+		header = []byte{b}
+	case start[0] == 0x79 && start[1] == 0x79:
+		b := make([]byte, 2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return gt06Frame{}, err
+		}
+		length = int(binary.BigEndian.Uint16(b))
+		header = b
+	default:
+		return gt06Frame{}, fmt.Errorf("invalid start bits %x", start)
+	}
+	if length < 5 {
+		return gt06Frame{}, fmt.Errorf("frame length %d too short", length)
+	}
+
+	body := make([]byte, length+2)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return gt06Frame{}, err
+	}
+	if body[length] != 0x0D || body[length+1] != 0x0A {
+		return gt06Frame{}, errors.New("missing frame stop bits")
+	}
+
+	payload := body[:length-2]
+	crc := binary.BigEndian.Uint16(body[length-2 : length])
+	if crcITU(append(header, payload...)) != crc {
+		return gt06Frame{}, errors.New("frame checksum mismatch")
+	}
+
+	return gt06Frame{
+		protocol: payload[0],
+		info:     payload[1 : len(payload)-2],
+		serial:   binary.BigEndian.Uint16(payload[len(payload)-2:]),
+	}, nil
+}
+
+// decodeGT06IMEI reads the 8-byte BCD terminal ID of a login frame. The
+// 15-digit IMEI is padded with a leading zero.
+func decodeGT06IMEI(info []byte) (string, error) {
+	if len(info) < 8 {
+		return "", errors.New("login frame too short")
+	}
+	digits := fmt.Sprintf("%x", info[:8])
+	return strings.TrimPrefix(digits, "0"), nil
+}
+
+// decodeGT06Position reads the GPS block shared by location and alarm frames:
+// UTC date and time, satellites, latitude and longitude in 1/1,800,000
+// degree, speed in km/h, and a word holding the fix flag, hemispheres and course.
+func decodeGT06Position(info []byte) (model.TrackerPosition, error) {
+	if len(info) < 18 {
+		return model.TrackerPosition{}, errors.New("location frame too short")
+	}
+
+	recordedAt := time.Date(
+		2000+int(info[0]), time.Month(info[1]), int(info[2]),
+		int(info[3]), int(info[4]), int(info[5]), 0, time.UTC,
+	)
+	lat := float64(binary.BigEndian.Uint32(info[7:11])) / 1800000
+	lng := float64(binary.BigEndian.Uint32(info[11:15])) / 1800000
+	flags := binary.BigEndian.Uint16(info[16:18])
+
+	if flags&0x0400 == 0 {
+		lat = -lat
+	}
+	if flags&0x0800 != 0 {
+		lng = -lng
+	}
+
+	return model.TrackerPosition{
+		Lat:        lat,
+		Lng:        lng,
+		RecordedAt: recordedAt,
+		SpeedKmh:   float64(info[15]),
+		Heading:    int(flags & 0x03FF),
+		Satellites: int(info[6] & 0x0F),
+		Valid:      flags&0x1000 != 0,
+	}, nil
+}
+
+// gt06Reply builds the acknowledgement the tracker expects for logins,
+// heartbeats and alarms: the same protocol number and serial, no information.
+func gt06Reply(protocol byte, serial uint16) []byte {
+	frame := []byte{0x78, 0x78, 0x05, protocol, byte(serial >> 8), byte(serial)}
+	crc := crcITU(frame[2:])
+	return append(frame, byte(crc>>8), byte(crc), 0x0D, 0x0A)
+}
+
+// crcITU is the CRC-16/X-25 checksum GT06 frames carry.
+func crcITU(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return ^crc
+}
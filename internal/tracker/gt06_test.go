@@ -0,0 +1,152 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fleet-monitor/internal/model"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Frames captured from the GT06 protocol manual.
+const (
+	gt06LoginHex     = "78780D01012345678901234500018CDD0D0A"
+	gt06HeartbeatHex = "78780A134004040001000FDCEE0D0A"
+	gt06LocationHex  = "78781F120B081D112E10CF027AC7EB0C46584900148F01CC00287D001FB8000380810D0A"
+)
+
+func hexReader(t *testing.T, frames ...string) *bufio.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, frame := range frames {
+		b, err := hex.DecodeString(frame)
+		if err != nil {
+			t.Fatalf("bad test frame %s: %v", frame, err)
+		}
+		buf.Write(b)
+	}
+	return bufio.NewReader(&buf)
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestReadGT06Frame(t *testing.T) {
+	tests := []struct {
+		name     string
+		frame    string
+		protocol byte
+		info     string
+		serial   uint16
+		err      string
+	}{
+		{name: "login", frame: gt06LoginHex, protocol: gt06Login, info: "0123456789012345", serial: 1},
+		{name: "heartbeat", frame: gt06HeartbeatHex, protocol: gt06Heartbeat, info: "4004040001", serial: 0x0F},
+		{name: "location", frame: gt06LocationHex, protocol: gt06Location, info: "0B081D112E10CF027AC7EB0C46584900148F01CC00287D001FB8", serial: 3},
+		{name: "bad crc", frame: "78780D01012345678901234500018CDE0D0A", err: "frame checksum mismatch"},
+		{name: "missing stop bits", frame: "78780D01012345678901234500018CDD0D0B", err: "missing frame stop bits"},
+		{name: "bad start bits", frame: "7877", err: "invalid start bits"},
+		{name: "too short", frame: "7878040100010D0A", err: "too short"},
+		{name: "truncated", frame: "78780D0101234567", err: io.ErrUnexpectedEOF.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := readGT06Frame(hexReader(t, tt.frame))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if frame.protocol != tt.protocol || hex.EncodeToString(frame.info) != strings.ToLower(tt.info) || frame.serial != tt.serial {
+				t.Fatalf("frame = {0x%02x %x %d}, want {0x%02x %s %d}", frame.protocol, frame.info, frame.serial, tt.protocol, tt.info, tt.serial)
+			}
+		})
+	}
+}
+
+func TestDecodeGT06IMEI(t *testing.T) {
+	frame, err := readGT06Frame(hexReader(t, gt06LoginHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imei, err := decodeGT06IMEI(frame.info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imei != "123456789012345" {
+		t.Fatalf("imei = %s", imei)
+	}
+}
+
+func TestDecodeGT06Position(t *testing.T) {
+	frame, err := readGT06Frame(hexReader(t, gt06LocationHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos, err := decodeGT06Position(frame.info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantTime := time.Date(2011, 8, 29, 17, 46, 16, 0, time.UTC)
+	if !pos.RecordedAt.Equal(wantTime) {
+		t.Errorf("recorded at = %s, want %s", pos.RecordedAt, wantTime)
+	}
+	if !closeTo(pos.Lat, 23.1116683) || !closeTo(pos.Lng, 114.409285) {
+		t.Errorf("position = %f,%f", pos.Lat, pos.Lng)
+	}
+	if pos.SpeedKmh != 0 || pos.Heading != 143 || pos.Satellites != 15 || !pos.Valid {
+		t.Errorf("position = %+v", pos)
+	}
+
+	if _, err := decodeGT06Position(frame.info[:17]); err == nil {
+		t.Error("short location frame was accepted")
+	}
+}
+
+func TestGT06Reply(t *testing.T) {
+	reply := hex.EncodeToString(gt06Reply(gt06Login, 1))
+	if want := "787805010001d9dc0d0a"; reply != want {
+		t.Fatalf("reply = %s, want %s", reply, want)
+	}
+}
+
+// rejectingSink accepts every tracker but rejects every position.
+type rejectingSink struct {
+	reported int
+}
+
+func (s *rejectingSink) Authorize(protocol, imei string) error {
+	return nil
+}
+
+func (s *rejectingSink) ReportPosition(pos model.TrackerPosition) error {
+	s.reported++
+	return errors.New("car is not available")
+}
+
+func TestServeGT06KeepsConnectionWhenPositionRejected(t *testing.T) {
+	sink := &rejectingSink{}
+	var out bytes.Buffer
+	err := serveGT06(hexReader(t, gt06LoginHex, gt06LocationHex, gt06HeartbeatHex), &out, sink)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("session ended with %v, want EOF", err)
+	}
+	if sink.reported != 1 {
+		t.Fatalf("%d positions reported, want 1", sink.reported)
+	}
+	want := hex.EncodeToString(gt06Reply(gt06Login, 1)) + hex.EncodeToString(gt06Reply(gt06Heartbeat, 0x0F))
+	if got := hex.EncodeToString(out.Bytes()); got != want {
+		t.Fatalf("replies = %s, want %s", got, want)
+	}
+}
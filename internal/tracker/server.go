@@ -0,0 +1,108 @@
+// Package tracker accepts TCP connections from hardware GPS trackers, decodes
+// their binary protocols and hands the positions to a Sink.
+package tracker
+
+import (
+	"bufio"
+	"errors"
+	"fleet-monitor/internal/model"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"time"
+)
+
+const (
+	ProtocolGT06      = "GT06"
+	ProtocolTeltonika = "TELTONIKA"
+)
+
+// idleTimeout closes connections that stay silent longer than a few missed
+// heartbeats. Both protocols send one every few minutes by default.
+const idleTimeout = 10 * time.Minute
+
+// Sink receives what the trackers report. Authorize is called once per
+// connection with the IMEI from the login frame; an error rejects the tracker.
+type Sink interface {
+	Authorize(protocol, imei string) error
+	ReportPosition(pos model.TrackerPosition) error
+}
+
+// session reads frames from one tracker until the connection fails.
+type session func(r *bufio.Reader, w io.Writer, sink Sink) error
+
+type Server struct {
+	protocol string
+	addr     string
+	serve    session
+	sink     Sink
+}
+
+func NewServer(protocol, addr string, sink Sink) (*Server, error) {
+	var serve session
+	switch protocol {
+	case ProtocolGT06:
+		serve = serveGT06
+	case ProtocolTeltonika:
+		serve = serveTeltonika
+	default:
+		return nil, fmt.Errorf("unsupported tracker protocol %s", protocol)
+	}
+	return &Server{protocol: protocol, addr: addr, serve: serve, sink: sink}, nil
+}
+
+// ListenAndServe accepts trackers until the listener fails. Each connection
+// is served on its own goroutine.
+func (s *Server) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	log.Printf("%s tracker listener started on %s", s.protocol, s.addr)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	rw := &deadlineConn{Conn: conn}
+	err := s.serve(bufio.NewReader(rw), rw, s.sink)
+	if err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("%s tracker %s disconnected: %v", s.protocol, conn.RemoteAddr(), err)
+	}
+}
+
+// reportPosition hands a fix to the sink. A rejected fix is logged and
+// skipped: resending it would fail the same way, and only I/O or protocol
+// errors should drop the connection.
+func reportPosition(sink Sink, protocol string, pos model.TrackerPosition) {
+	if err := sink.ReportPosition(pos); err != nil {
+		log.Printf("%s tracker %s: position at %s rejected: %v", protocol, pos.IMEI, pos.RecordedAt.Format(time.RFC3339), err)
+	}
+}
+
+// deadlineConn pushes the read deadline forward on every read so a tracker
+// is only dropped after idleTimeout of silence.
+type deadlineConn struct {
+	net.Conn
+}
+
+func (c *deadlineConn) Read(p []byte) (int, error) {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+		return 0, err
+	}
+	return c.Conn.Read(p)
+}
@@ -0,0 +1,195 @@
+package tracker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fleet-monitor/internal/model"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	teltonikaCodec8 = 0x08
+	// teltonikaMaxPacket bounds the AVL data length a tracker may announce.
+	teltonikaMaxPacket = 64 * 1024
+)
+
+// serveTeltonika handles the Codec 8 handshake: the tracker sends its IMEI and
+// waits for 0x01, then sends AVL packets, each answered with the number of
+// records accepted. Packets with a bad CRC are answered with zero so the
+// tracker sends them again; positions the sink rejects are still acknowledged.
+func serveTeltonika(r *bufio.Reader, w io.Writer, sink Sink) error {
+	imei, err := readTeltonikaIMEI(r)
+	if err != nil {
+		return err
+	}
+	if err := sink.Authorize(ProtocolTeltonika, imei); err != nil {
+		_, _ = w.Write([]byte{0x00})
+		return fmt.Errorf("login rejected for %s: %w", imei, err)
+	}
+	if _, err := w.Write([]byte{0x01}); err != nil {
+		return err
+	}
+
+	for {
+		positions, err := readTeltonikaPacket(r)
+		if errors.Is(err, errTeltonikaCRC) {
+			if _, err := w.Write(teltonikaAck(0)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, pos := range positions {
+			pos.IMEI = imei
+			if pos.Valid {
+				reportPosition(sink, ProtocolTeltonika, pos)
+			}
+		}
+		if _, err := w.Write(teltonikaAck(len(positions))); err != nil {
+			return err
+		}
+	}
+}
+
+var errTeltonikaCRC = errors.New("packet checksum mismatch")
+
+// readTeltonikaIMEI reads the login packet: a 2-byte length and the IMEI in ASCII.
+func readTeltonikaIMEI(r *bufio.Reader) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", err
+	}
+	length := int(binary.BigEndian.Uint16(header))
+	if length == 0 || length > 20 {
+		return "", fmt.Errorf("invalid IMEI length %d", length)
+	}
+	imei := make([]byte, length)
+	if _, err := io.ReadFull(r, imei); err != nil {
+		return "", err
+	}
+	return string(imei), nil
+}
+
+// readTeltonikaPacket reads one AVL packet: four zero bytes, the data length,
+// the data (codec ID, record count, records, record count again) and a
+// CRC-16/IBM of the data.
+func readTeltonikaPacket(r *bufio.Reader) ([]model.TrackerPosition, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if binary.BigEndian.Uint32(header[:4]) != 0 {
+		return nil, errors.New("invalid packet preamble")
+	}
+	length := binary.BigEndian.Uint32(header[4:])
+	if length < 3 || length > teltonikaMaxPacket {
+		return nil, fmt.Errorf("invalid packet length %d", length)
+	}
+
+	data := make([]byte, length+4)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	crc := binary.BigEndian.Uint32(data[length:])
+	data = data[:length]
+	if uint32(crc16IBM(data)) != crc {
+		return nil, errTeltonikaCRC
+	}
+
+	if data[0] != teltonikaCodec8 {
+		return nil, fmt.Errorf("unsupported codec 0x%02x", data[0])
+	}
+	count := int(data[1])
+	if int(data[len(data)-1]) != count {
+		return nil, errors.New("record counts do not match")
+	}
+
+	d := &avlDecoder{data: data[2 : len(data)-1]}
+	positions := make([]model.TrackerPosition, 0, count)
+	for i := 0; i < count; i++ {
+		pos, err := d.record()
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		positions = append(positions, pos)
+	}
+	return positions, nil
+}
+
+// avlDecoder walks the records of a Codec 8 packet.
+type avlDecoder struct {
+	data []byte
+	off  int
+}
+
+func (d *avlDecoder) next(n int) ([]byte, error) {
+	if d.off+n > len(d.data) {
+		return nil, errors.New("record truncated")
+	}
+	b := d.data[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// record decodes one AVL record: timestamp in ms, priority, GPS element
+// (longitude and latitude in 1e-7 degree, altitude, angle, satellites,
+// speed) and the IO element, which is skipped.
+func (d *avlDecoder) record() (model.TrackerPosition, error) {
+	b, err := d.next(8 + 1 + 15)
+	if err != nil {
+		return model.TrackerPosition{}, err
+	}
+
+	pos := model.TrackerPosition{
+		RecordedAt: time.UnixMilli(int64(binary.BigEndian.Uint64(b[0:8]))),
+		Lng:        float64(int32(binary.BigEndian.Uint32(b[9:13]))) / 1e7,
+		Lat:        float64(int32(binary.BigEndian.Uint32(b[13:17]))) / 1e7,
+		Heading:    int(binary.BigEndian.Uint16(b[19:21])),
+		Satellites: int(b[21]),
+		SpeedKmh:   float64(binary.BigEndian.Uint16(b[22:24])),
+	}
+	pos.Valid = pos.Satellites > 0 && (pos.Lat != 0 || pos.Lng != 0)
+
+	// Event IO ID and total IO count, then groups of 1, 2, 4 and 8 byte values
+	if _, err := d.next(2); err != nil {
+		return model.TrackerPosition{}, err
+	}
+	for _, size := range []int{1, 2, 4, 8} {
+		n, err := d.next(1)
+		if err != nil {
+			return model.TrackerPosition{}, err
+		}
+		if _, err := d.next(int(n[0]) * (1 + size)); err != nil {
+			return model.TrackerPosition{}, err
+		}
+	}
+	return pos, nil
+}
+
+func teltonikaAck(records int) []byte {
+	ack := make([]byte, 4)
+	binary.BigEndian.PutUint32(ack, uint32(records))
+	return ack
+}
+
+// crc16IBM is the CRC-16/ARC checksum Teltonika packets carry.
+func crc16IBM(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
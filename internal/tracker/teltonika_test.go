@@ -0,0 +1,113 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Packets captured from the Teltonika Codec 8 documentation and a FMB device.
+const (
+	teltonikaIMEIHex = "000F333536333037303432343431303133"
+	// One record without a fix, with IO elements of every size
+	teltonikaNoFixHex = "000000000000003608010000016B40D8EA30010000000000000000000000000000000105021503010101425E0F01F10000601A014E0000000000000000010000C7CF"
+	// One record with a fix
+	teltonikaFixHex = "000000000000008c08010000013feb55ff74000f0ea850209a690000940000120000001e09010002000300040016014703f0001504c8000c0900730a00460b00501300464306d7440000b5000bb60007422e9f180000cd0386ce000107c700000000f10000601a46000001344800000bb84900000bb84a00000bb84c00000000024e0000000000000000cf00000000000000000100003fca"
+	// Two records without a fix
+	teltonikaTwoRecordsHex = "000000000000004308020000016B40D57B480100000000000000000000000000000001010101000000000000016B40D5C198010000000000000000000000000000000101010101000000020000252C"
+	// teltonikaNoFixHex with the last CRC byte changed
+	teltonikaBadCRCHex = "000000000000003608010000016B40D8EA30010000000000000000000000000000000105021503010101425E0F01F10000601A014E0000000000000000010000C7CE"
+)
+
+func TestReadTeltonikaIMEI(t *testing.T) {
+	imei, err := readTeltonikaIMEI(hexReader(t, teltonikaIMEIHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imei != "356307042441013" {
+		t.Fatalf("imei = %s", imei)
+	}
+
+	if _, err := readTeltonikaIMEI(hexReader(t, "0000")); err == nil {
+		t.Error("empty IMEI was accepted")
+	}
+}
+
+func TestReadTeltonikaPacket(t *testing.T) {
+	type record struct {
+		at         time.Time
+		lat, lng   float64
+		satellites int
+		valid      bool
+	}
+	tests := []struct {
+		name    string
+		packet  string
+		records []record
+		err     string
+	}{
+		{
+			name:    "record without fix",
+			packet:  teltonikaNoFixHex,
+			records: []record{{at: time.UnixMilli(1560161086000)}},
+		},
+		{
+			name:    "record with fix",
+			packet:  teltonikaFixHex,
+			records: []record{{at: time.UnixMilli(1374042849140), lat: 54.6990336, lng: 25.2618832, satellites: 18, valid: true}},
+		},
+		{
+			name:    "two records",
+			packet:  teltonikaTwoRecordsHex,
+			records: []record{{at: time.UnixMilli(1560160861000)}, {at: time.UnixMilli(1560160879000)}},
+		},
+		{name: "bad crc", packet: teltonikaBadCRCHex, err: errTeltonikaCRC.Error()},
+		{name: "bad preamble", packet: "0000000100000036", err: "invalid packet preamble"},
+		{name: "bad length", packet: "0000000000000002", err: "invalid packet length"},
+		{name: "truncated", packet: "00000000000000360801", err: io.ErrUnexpectedEOF.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions, err := readTeltonikaPacket(hexReader(t, tt.packet))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(positions) != len(tt.records) {
+				t.Fatalf("%d records, want %d", len(positions), len(tt.records))
+			}
+			for i, want := range tt.records {
+				pos := positions[i]
+				if !pos.RecordedAt.Equal(want.at) || !closeTo(pos.Lat, want.lat) || !closeTo(pos.Lng, want.lng) ||
+					pos.Satellites != want.satellites || pos.Valid != want.valid {
+					t.Errorf("record %d = %+v, want %+v", i+1, pos, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServeTeltonikaKeepsConnectionWhenPositionRejected(t *testing.T) {
+	sink := &rejectingSink{}
+	var out bytes.Buffer
+	err := serveTeltonika(hexReader(t, teltonikaIMEIHex, teltonikaBadCRCHex, teltonikaFixHex), &out, sink)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("session ended with %v, want EOF", err)
+	}
+	if sink.reported != 1 {
+		t.Fatalf("%d positions reported, want 1", sink.reported)
+	}
+	// Login accepted, bad CRC asks for a resend, the rejected fix is still acknowledged
+	if got, want := hex.EncodeToString(out.Bytes()), "01"+"00000000"+"00000001"; got != want {
+		t.Fatalf("replies = %s, want %s", got, want)
+	}
+}
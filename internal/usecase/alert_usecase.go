@@ -0,0 +1,619 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/geo"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/realtime"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// alertMinSpeedGap is the shortest gap between two fixes used to derive a
+// speed when the device does not report one; shorter gaps are mostly GPS
+// jitter.
+const alertMinSpeedGap = 30 * time.Second
+
+type AlertUsecase interface {
+	GetAll(params model.AlertListParams) ([]model.AlertResponse, int64, error)
+	GetByID(id int64) (*model.AlertResponse, error)
+	Acknowledge(id int64, userID int64) (*model.AlertResponse, error)
+	Resolve(id int64, req model.AlertResolveRequest, userID int64) (*model.AlertResponse, error)
+	GetRules(params model.AlertRuleListParams) ([]model.AlertRuleResponse, int64, error)
+	GetRuleByID(id int64) (*model.AlertRuleResponse, error)
+	CreateRule(req model.AlertRuleRequest) (*model.AlertRuleResponse, error)
+	UpdateRule(id int64, req model.AlertRuleRequest) (*model.AlertRuleResponse, error)
+	DeleteRule(id int64) error
+	RunChecks(now time.Time) error
+}
+
+type alertUsecase struct {
+	alertRepo    repository.AlertRepository
+	ruleRepo     repository.AlertRuleRepository
+	carRepo      repository.CarRepository
+	geofenceRepo repository.GeofenceRepository
+	tripRepo     repository.TripRepository
+	engine       *AlertEngine
+	txManager    helper.TxManager
+	hub          realtime.Hub
+}
+
+func NewAlertUsecase(
+	alertRepo repository.AlertRepository,
+	ruleRepo repository.AlertRuleRepository,
+	carRepo repository.CarRepository,
+	geofenceRepo repository.GeofenceRepository,
+	tripRepo repository.TripRepository,
+	engine *AlertEngine,
+	txManager helper.TxManager,
+	hub realtime.Hub,
+) AlertUsecase {
+	return &alertUsecase{
+		alertRepo:    alertRepo,
+		ruleRepo:     ruleRepo,
+		carRepo:      carRepo,
+		geofenceRepo: geofenceRepo,
+		tripRepo:     tripRepo,
+		engine:       engine,
+		txManager:    txManager,
+		hub:          hub,
+	}
+}
+
+func (u *alertUsecase) GetAll(params model.AlertListParams) ([]model.AlertResponse, int64, error) {
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		return nil, 0, errors.New("from must be before to")
+	}
+
+	alerts, total, err := u.alertRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.AlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		responses = append(responses, toAlertResponse(&alert))
+	}
+	return responses, total, nil
+}
+
+func (u *alertUsecase) GetByID(id int64) (*model.AlertResponse, error) {
+	alert, err := u.alertRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("alert not found")
+		}
+		return nil, err
+	}
+	response := toAlertResponse(alert)
+	return &response, nil
+}
+
+func (u *alertUsecase) Acknowledge(id int64, userID int64) (*model.AlertResponse, error) {
+	return u.transition(id, func(alert *entity.Alert, now time.Time) error {
+		if alert.Status != entity.AlertStatusOpen {
+			return fmt.Errorf("cannot acknowledge an alert that is %s", alert.Status)
+		}
+		alert.Status = entity.AlertStatusAcknowledged
+		alert.AcknowledgedAt = &now
+		if userID > 0 {
+			alert.AcknowledgedBy = &userID
+		}
+		return nil
+	})
+}
+
+func (u *alertUsecase) Resolve(id int64, req model.AlertResolveRequest, userID int64) (*model.AlertResponse, error) {
+	return u.transition(id, func(alert *entity.Alert, now time.Time) error {
+		if alert.Status == entity.AlertStatusResolved {
+			return errors.New("alert already resolved")
+		}
+		alert.Status = entity.AlertStatusResolved
+		alert.ResolvedAt = &now
+		alert.ResolutionNote = req.Note
+		if userID > 0 {
+			alert.ResolvedBy = &userID
+		}
+		return nil
+	})
+}
+
+// transition locks the alert, applies change and saves it.
+func (u *alertUsecase) transition(id int64, change func(alert *entity.Alert, now time.Time) error) (*model.AlertResponse, error) {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		alertRepo := u.alertRepo.WithTx(tx)
+
+		alert, err := alertRepo.FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("alert not found")
+			}
+			return err
+		}
+		if err := change(alert, time.Now()); err != nil {
+			return err
+		}
+		return alertRepo.Update(alert)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetByID(id)
+}
+
+func (u *alertUsecase) GetRules(params model.AlertRuleListParams) ([]model.AlertRuleResponse, int64, error) {
+	rules, total, err := u.ruleRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.AlertRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, toAlertRuleResponse(&rule))
+	}
+	return responses, total, nil
+}
+
+func (u *alertUsecase) GetRuleByID(id int64) (*model.AlertRuleResponse, error) {
+	rule, err := u.findRule(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toAlertRuleResponse(rule)
+	return &response, nil
+}
+
+func (u *alertUsecase) CreateRule(req model.AlertRuleRequest) (*model.AlertRuleResponse, error) {
+	rule := &entity.AlertRule{Active: true}
+	if err := u.applyRuleRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+	return u.GetRuleByID(rule.ID)
+}
+
+func (u *alertUsecase) UpdateRule(id int64, req model.AlertRuleRequest) (*model.AlertRuleResponse, error) {
+	rule, err := u.findRule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyRuleRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	rule.Car = nil
+	rule.Geofence = nil
+	if err := u.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+	return u.GetRuleByID(id)
+}
+
+func (u *alertUsecase) DeleteRule(id int64) error {
+	if _, err := u.findRule(id); err != nil {
+		return err
+	}
+	return u.ruleRepo.Delete(id)
+}
+
+// RunChecks evaluates the rules that depend on time passing rather than on a
+// new position: stale GPS and long trips. It is called by the scheduler.
+func (u *alertUsecase) RunChecks(now time.Time) error {
+	var raised []entity.Alert
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		trips, err := u.tripRepo.WithTx(tx).FindActive()
+		if err != nil {
+			return err
+		}
+
+		for i := range trips {
+			trip := &trips[i]
+			if trip.Car == nil {
+				continue
+			}
+			alerts, err := u.engine.checkTrip(tx, trip.Car, trip, now)
+			if err != nil {
+				return err
+			}
+			raised = append(raised, alerts...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range raised {
+		u.hub.Publish(newAlertEvent(raised[i].Car, &raised[i]))
+	}
+	return nil
+}
+
+func (u *alertUsecase) findRule(id int64) (*entity.AlertRule, error) {
+	rule, err := u.ruleRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("alert rule not found")
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (u *alertUsecase) applyRuleRequest(rule *entity.AlertRule, req model.AlertRuleRequest) error {
+	if req.Type == entity.AlertTypeGeofenceBreach {
+		if req.GeofenceID != nil {
+			if _, err := u.geofenceRepo.FindByID(*req.GeofenceID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.New("geofence not found")
+				}
+				return err
+			}
+		}
+	} else {
+		if req.GeofenceID != nil || req.Transition != "" {
+			return errors.New("geofence_id and transition are only used by GEOFENCE_BREACH rules")
+		}
+		if req.Threshold <= 0 {
+			return errors.New("threshold must be greater than 0")
+		}
+	}
+	if req.CarID != nil {
+		if _, err := u.carRepo.FindByID(*req.CarID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+	}
+
+	rule.Name = req.Name
+	rule.Type = req.Type
+	rule.Severity = req.Severity
+	rule.Threshold = req.Threshold
+	rule.CarID = req.CarID
+	rule.GeofenceID = req.GeofenceID
+	rule.Transition = req.Transition
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+	return nil
+}
+
+// AlertEngine evaluates alert rules, stores the alerts they raise and queues
+// notifications for them. It runs inside the caller's transaction; callers
+// publish the returned alerts once the transaction has committed.
+type AlertEngine struct {
+	ruleRepo  repository.AlertRuleRepository
+	alertRepo repository.AlertRepository
+	outbox    *NotificationOutbox
+}
+
+func NewAlertEngine(ruleRepo repository.AlertRuleRepository, alertRepo repository.AlertRepository, outbox *NotificationOutbox) *AlertEngine {
+	return &AlertEngine{
+		ruleRepo:  ruleRepo,
+		alertRepo: alertRepo,
+		outbox:    outbox,
+	}
+}
+
+// checkLocations evaluates the position based rules for new live points of a
+// car, oldest first. car must still hold the position from before the points
+// so the first speed can be derived from it.
+func (e *AlertEngine) checkLocations(tx *gorm.DB, car *entity.Car, points []entity.CarLocation, crossings []entity.GeofenceEvent) ([]entity.Alert, error) {
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	// A fresh fix clears any stale GPS alert for the car
+	last := points[len(points)-1]
+	if _, err := e.alertRepo.WithTx(tx).ResolveOpen(car.ID, entity.AlertTypeStaleGPS, "GPS signal restored", last.RecordedAt); err != nil {
+		return nil, err
+	}
+
+	rules, err := e.rulesFor(tx, car.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	speeds := pointSpeeds(car, points)
+	var raised []entity.Alert
+	for i := range rules {
+		rule := &rules[i]
+		switch rule.Type {
+		case entity.AlertTypeSpeeding:
+			at, speed := fastestPoint(points, speeds)
+			if at == nil || speed <= rule.Threshold {
+				continue
+			}
+			alert := locationAlert(at, speed)
+			alert.Message = fmt.Sprintf("Speed %.0f km/h exceeds the %.0f km/h limit", speed, rule.Threshold)
+			if err := e.raise(tx, car, rule, alert, &raised); err != nil {
+				return nil, err
+			}
+		case entity.AlertTypeUnauthorizedMovement:
+			if car.Status != entity.CarStatusAvailable && car.Status != entity.CarStatusMaintenance {
+				continue
+			}
+			at, speed := fastestPoint(points, speeds)
+			if at == nil || speed <= rule.Threshold {
+				continue
+			}
+			alert := locationAlert(at, speed)
+			alert.Message = fmt.Sprintf("Car moving at %.0f km/h while %s", speed, car.Status)
+			if err := e.raise(tx, car, rule, alert, &raised); err != nil {
+				return nil, err
+			}
+		case entity.AlertTypeGeofenceBreach:
+			for j := range crossings {
+				crossing := &crossings[j]
+				if rule.GeofenceID != nil && *rule.GeofenceID != crossing.GeofenceID {
+					continue
+				}
+				if rule.Transition != "" && rule.Transition != crossing.Type {
+					continue
+				}
+				alert := &entity.Alert{
+					GeofenceID:  &crossing.GeofenceID,
+					Lat:         &crossing.Lat,
+					Lng:         &crossing.Lng,
+					TriggeredAt: crossing.OccurredAt,
+				}
+				verb := "Entered"
+				if crossing.Type == entity.GeofenceEventExit {
+					verb = "Exited"
+				}
+				name := fmt.Sprintf("#%d", crossing.GeofenceID)
+				if crossing.Geofence != nil {
+					name = crossing.Geofence.Name
+				}
+				alert.Message = fmt.Sprintf("%s geofence %s", verb, name)
+				if err := e.raise(tx, car, rule, alert, &raised); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return raised, nil
+}
+
+// checkTrip evaluates the time based rules for a trip: its duration so far
+// (or in total once ended) and, while it is in progress, how long the car
+// has gone without a GPS fix.
+func (e *AlertEngine) checkTrip(tx *gorm.DB, car *entity.Car, trip *entity.TripLog, now time.Time) ([]entity.Alert, error) {
+	rules, err := e.rulesFor(tx, car.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	end := now
+	if trip.EndTime != nil {
+		end = *trip.EndTime
+	}
+
+	var raised []entity.Alert
+	for i := range rules {
+		rule := &rules[i]
+		switch rule.Type {
+		case entity.AlertTypeLongTrip:
+			hours := end.Sub(trip.StartTime).Hours()
+			if hours <= rule.Threshold {
+				continue
+			}
+			alert := &entity.Alert{
+				TripID:      &trip.ID,
+				Value:       &hours,
+				Lat:         car.LastLat,
+				Lng:         car.LastLng,
+				TriggeredAt: now,
+				Message:     fmt.Sprintf("Trip running for %.1f hours, limit %.0f hours", hours, rule.Threshold),
+			}
+			if err := e.raise(tx, car, rule, alert, &raised); err != nil {
+				return nil, err
+			}
+		case entity.AlertTypeStaleGPS:
+			if trip.Status != entity.TripStatusInProgress {
+				continue
+			}
+			// A car that has not reported since checkout is measured from the checkout
+			since := trip.StartTime
+			if car.LastUpdateLoc != nil && car.LastUpdateLoc.After(since) {
+				since = *car.LastUpdateLoc
+			}
+			minutes := now.Sub(since).Minutes()
+			if minutes <= rule.Threshold {
+				continue
+			}
+			alert := &entity.Alert{
+				TripID:      &trip.ID,
+				Value:       &minutes,
+				Lat:         car.LastLat,
+				Lng:         car.LastLng,
+				TriggeredAt: now,
+				Message:     fmt.Sprintf("No GPS fix for %.0f minutes", minutes),
+			}
+			if err := e.raise(tx, car, rule, alert, &raised); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return raised, nil
+}
+
+// rulesFor returns the active rules that apply to the car.
+func (e *AlertEngine) rulesFor(tx *gorm.DB, carID int64) ([]entity.AlertRule, error) {
+	rules, err := e.ruleRepo.WithTx(tx).FindActive()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := rules[:0]
+	for _, rule := range rules {
+		if rule.CarID == nil || *rule.CarID == carID {
+			matched = append(matched, rule)
+		}
+	}
+	return matched, nil
+}
+
+// raise stores the alert for the rule unless the rule already has an
+// unresolved alert for the car, and appends it to raised when stored.
+func (e *AlertEngine) raise(tx *gorm.DB, car *entity.Car, rule *entity.AlertRule, alert *entity.Alert, raised *[]entity.Alert) error {
+	alert.RuleID = &rule.ID
+	alert.CarID = car.ID
+	alert.Type = rule.Type
+	alert.Severity = rule.Severity
+	alert.Status = entity.AlertStatusOpen
+
+	inserted, err := e.alertRepo.WithTx(tx).CreateIfAbsent(alert)
+	if err != nil || !inserted {
+		return err
+	}
+
+	err = e.outbox.enqueue(tx, notificationEvent{
+		Type:     entity.NotificationEventAlert,
+		Severity: alert.Severity,
+		Subject:  fmt.Sprintf("[%s] %s: %s", alert.Severity, car.LicensePlate, rule.Name),
+		Body:     fmt.Sprintf("%s\nCar: %s\nTime: %s", alert.Message, car.LicensePlate, alert.TriggeredAt.Format("2006-01-02 15:04")),
+	})
+	if err != nil {
+		return err
+	}
+	alert.Car = car
+	alert.Rule = rule
+	*raised = append(*raised, *alert)
+	return nil
+}
+
+// pointSpeeds returns the speed of each point in km/h, or -1 when it is
+// unknown. Device reported speeds are used as is; otherwise the speed is
+// derived from the previous fix when the two are far enough apart in time.
+func pointSpeeds(car *entity.Car, points []entity.CarLocation) []float64 {
+	speeds := make([]float64, len(points))
+
+	var prev *geo.TrackPoint
+	if car.LastLat != nil && car.LastLng != nil && car.LastUpdateLoc != nil {
+		prev = &geo.TrackPoint{Point: geo.Point{Lat: *car.LastLat, Lng: *car.LastLng}, At: *car.LastUpdateLoc}
+	}
+	for i, p := range points {
+		speeds[i] = -1
+		current := geo.TrackPoint{Point: geo.Point{Lat: p.Lat, Lng: p.Lng}, At: p.RecordedAt}
+		if p.Speed != nil {
+			speeds[i] = *p.Speed
+		} else if prev != nil {
+			if gap := current.At.Sub(prev.At); gap >= alertMinSpeedGap {
+				kmh := geo.Distance(prev.Point, current.Point) / gap.Seconds() * 3.6
+				if kmh <= tripMaxSpeedKmh {
+					speeds[i] = kmh
+				}
+			}
+		}
+		prev = &current
+	}
+	return speeds
+}
+
+// fastestPoint returns the point with the highest known speed.
+func fastestPoint(points []entity.CarLocation, speeds []float64) (*entity.CarLocation, float64) {
+	var fastest *entity.CarLocation
+	top := -1.0
+	for i := range points {
+		if speeds[i] > top {
+			fastest = &points[i]
+			top = speeds[i]
+		}
+	}
+	return fastest, top
+}
+
+func locationAlert(location *entity.CarLocation, speed float64) *entity.Alert {
+	lat, lng := location.Lat, location.Lng
+	return &entity.Alert{
+		Value:       &speed,
+		Lat:         &lat,
+		Lng:         &lng,
+		TriggeredAt: location.RecordedAt,
+	}
+}
+
+func newAlertEvent(car *entity.Car, alert *entity.Alert) model.CarEvent {
+	event := model.CarEvent{
+		Type:       model.CarEventAlert,
+		CarID:      alert.CarID,
+		Lat:        alert.Lat,
+		Lng:        alert.Lng,
+		GeofenceID: alert.GeofenceID,
+		AlertID:    &alert.ID,
+		AlertType:  alert.Type,
+		Severity:   alert.Severity,
+		Message:    alert.Message,
+		Timestamp:  alert.TriggeredAt,
+	}
+	if car != nil {
+		event.LicensePlate = car.LicensePlate
+		event.Status = car.Status
+	}
+	return event
+}
+
+func toAlertResponse(alert *entity.Alert) model.AlertResponse {
+	resp := model.AlertResponse{
+		ID:             alert.ID,
+		RuleID:         alert.RuleID,
+		CarID:          alert.CarID,
+		TripID:         alert.TripID,
+		GeofenceID:     alert.GeofenceID,
+		Type:           alert.Type,
+		Severity:       alert.Severity,
+		Message:        alert.Message,
+		Value:          alert.Value,
+		Lat:            alert.Lat,
+		Lng:            alert.Lng,
+		Status:         alert.Status,
+		TriggeredAt:    alert.TriggeredAt,
+		AcknowledgedAt: alert.AcknowledgedAt,
+		AcknowledgedBy: alert.AcknowledgedBy,
+		ResolvedAt:     alert.ResolvedAt,
+		ResolvedBy:     alert.ResolvedBy,
+		ResolutionNote: alert.ResolutionNote,
+	}
+	if alert.Rule != nil {
+		resp.RuleName = alert.Rule.Name
+	}
+	if alert.Car != nil {
+		resp.LicensePlate = alert.Car.LicensePlate
+	}
+	return resp
+}
+
+func toAlertRuleResponse(rule *entity.AlertRule) model.AlertRuleResponse {
+	resp := model.AlertRuleResponse{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		Type:       rule.Type,
+		Severity:   rule.Severity,
+		Threshold:  rule.Threshold,
+		CarID:      rule.CarID,
+		GeofenceID: rule.GeofenceID,
+		Transition: rule.Transition,
+		Active:     rule.Active,
+		CreatedAt:  rule.CreatedAt,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+	if rule.Car != nil {
+		resp.LicensePlate = rule.Car.LicensePlate
+	}
+	if rule.Geofence != nil {
+		resp.GeofenceName = rule.Geofence.Name
+	}
+	return resp
+}
@@ -10,22 +10,30 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type AuthUsecase interface {
 	Login(req model.LoginRequest) (*model.LoginResponse, error)
 	Register(req model.RegisterRequest) (*model.UserResponse, error)
+	Me(userID int64) (*model.MeResponse, error)
 }
 
 type authUsecase struct {
-	userRepo repository.UserRepository
-	config   *config.Config
+	userRepo   repository.UserRepository
+	driverRepo repository.DriverRepository
+	config     *config.Config
 }
 
-func NewAuthUsecase(userRepo repository.UserRepository, cfg *config.Config) AuthUsecase {
+func NewAuthUsecase(
+	userRepo repository.UserRepository,
+	driverRepo repository.DriverRepository,
+	cfg *config.Config,
+) AuthUsecase {
 	return &authUsecase{
-		userRepo: userRepo,
-		config:   cfg,
+		userRepo:   userRepo,
+		driverRepo: driverRepo,
+		config:     cfg,
 	}
 }
 
@@ -62,6 +70,7 @@ func (u *authUsecase) Login(req model.LoginRequest) (*model.LoginResponse, error
 			Username: user.Username,
 			Role:     user.Role,
 		},
+		Driver: u.linkedDriver(user),
 	}, nil
 }
 
@@ -76,9 +85,10 @@ func (u *authUsecase) Register(req model.RegisterRequest) (*model.UserResponse,
 		return nil, errors.New("failed to hash password")
 	}
 
+	// Without an explicit role the account gets the fewest permissions
 	role := req.Role
 	if role == "" {
-		role = "admin"
+		role = entity.UserRoleDriver
 	}
 
 	user := &entity.User{
@@ -97,3 +107,44 @@ func (u *authUsecase) Register(req model.RegisterRequest) (*model.UserResponse,
 		Role:     user.Role,
 	}, nil
 }
+
+func (u *authUsecase) Me(userID int64) (*model.MeResponse, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	return &model.MeResponse{
+		User: model.UserResponse{
+			ID:       user.ID,
+			Username: user.Username,
+			Role:     user.Role,
+		},
+		Driver: u.linkedDriver(user),
+	}, nil
+}
+
+// linkedDriver returns the driver profile of a driver account, or nil when
+// the user is not a driver or has not been linked yet.
+func (u *authUsecase) linkedDriver(user *entity.User) *model.DriverResponse {
+	if user.Role != entity.UserRoleDriver {
+		return nil
+	}
+	driver, err := u.driverRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil
+	}
+	return &model.DriverResponse{
+		ID:            driver.ID,
+		UserID:        driver.UserID,
+		Name:          driver.Name,
+		PhoneNumber:   driver.PhoneNumber,
+		LicenseNumber: driver.LicenseNumber,
+		Status:        driver.Status,
+		CreatedAt:     driver.CreatedAt,
+		UpdatedAt:     driver.UpdatedAt,
+	}
+}
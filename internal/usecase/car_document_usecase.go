@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"fleet-monitor/internal/storage"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var allowedDocumentFiles = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+type CarDocumentUsecase interface {
+	GetByCarID(carID int64) ([]model.CarDocumentResponse, error)
+	GetByID(carID, id int64) (*model.CarDocumentResponse, error)
+	Create(carID int64, req model.CarDocumentRequest, file *model.FileUpload) (*model.CarDocumentResponse, error)
+	Update(carID, id int64, req model.CarDocumentRequest, file *model.FileUpload) (*model.CarDocumentResponse, error)
+	Delete(carID, id int64) error
+	GetFile(carID, id int64) (*model.FileDownload, error)
+	GetExpiring(within time.Duration) ([]model.CarDocumentResponse, error)
+}
+
+type carDocumentUsecase struct {
+	documentRepo repository.CarDocumentRepository
+	carRepo      repository.CarRepository
+	fileStore    storage.FileStore
+}
+
+func NewCarDocumentUsecase(
+	documentRepo repository.CarDocumentRepository,
+	carRepo repository.CarRepository,
+	fileStore storage.FileStore,
+) CarDocumentUsecase {
+	return &carDocumentUsecase{
+		documentRepo: documentRepo,
+		carRepo:      carRepo,
+		fileStore:    fileStore,
+	}
+}
+
+func (u *carDocumentUsecase) GetByCarID(carID int64) ([]model.CarDocumentResponse, error) {
+	if err := u.ensureCar(carID); err != nil {
+		return nil, err
+	}
+
+	documents, err := u.documentRepo.FindByCarID(carID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := []model.CarDocumentResponse{}
+	for _, doc := range documents {
+		responses = append(responses, u.toResponse(&doc, now))
+	}
+	return responses, nil
+}
+
+func (u *carDocumentUsecase) GetByID(carID, id int64) (*model.CarDocumentResponse, error) {
+	document, err := u.find(carID, id)
+	if err != nil {
+		return nil, err
+	}
+	response := u.toResponse(document, time.Now())
+	return &response, nil
+}
+
+func (u *carDocumentUsecase) Create(carID int64, req model.CarDocumentRequest, file *model.FileUpload) (*model.CarDocumentResponse, error) {
+	if err := u.ensureCar(carID); err != nil {
+		return nil, err
+	}
+
+	document := &entity.CarDocument{CarID: carID}
+	if err := u.applyRequest(document, req); err != nil {
+		return nil, err
+	}
+	if file != nil {
+		if err := u.storeFile(document, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.documentRepo.Create(document); err != nil {
+		if document.FileKey != "" {
+			u.fileStore.Delete(document.FileKey)
+		}
+		return nil, err
+	}
+
+	document, _ = u.documentRepo.FindByID(document.ID)
+	response := u.toResponse(document, time.Now())
+	return &response, nil
+}
+
+func (u *carDocumentUsecase) Update(carID, id int64, req model.CarDocumentRequest, file *model.FileUpload) (*model.CarDocumentResponse, error) {
+	document, err := u.find(carID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyRequest(document, req); err != nil {
+		return nil, err
+	}
+
+	previousKey := document.FileKey
+	if file != nil {
+		if err := u.storeFile(document, file); err != nil {
+			return nil, err
+		}
+	}
+
+	document.Car = nil
+	if err := u.documentRepo.Update(document); err != nil {
+		if file != nil {
+			u.fileStore.Delete(document.FileKey)
+		}
+		return nil, err
+	}
+
+	// Only drop the replaced file once the new one is referenced
+	if file != nil && previousKey != "" {
+		u.fileStore.Delete(previousKey)
+	}
+
+	document, _ = u.documentRepo.FindByID(id)
+	response := u.toResponse(document, time.Now())
+	return &response, nil
+}
+
+func (u *carDocumentUsecase) Delete(carID, id int64) error {
+	document, err := u.find(carID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.documentRepo.Delete(id); err != nil {
+		return err
+	}
+	if document.FileKey != "" {
+		u.fileStore.Delete(document.FileKey)
+	}
+	return nil
+}
+
+func (u *carDocumentUsecase) GetFile(carID, id int64) (*model.FileDownload, error) {
+	document, err := u.find(carID, id)
+	if err != nil {
+		return nil, err
+	}
+	if document.FileKey == "" {
+		return nil, errors.New("document has no attached file")
+	}
+
+	content, err := u.fileStore.Open(document.FileKey)
+	if err != nil {
+		return nil, errors.New("file not found")
+	}
+
+	return &model.FileDownload{
+		Filename:    document.FileName,
+		ContentType: document.FileContentType,
+		Content:     content,
+	}, nil
+}
+
+// GetExpiring lists documents that expire within the given window, including
+// those that have already expired.
+func (u *carDocumentUsecase) GetExpiring(within time.Duration) ([]model.CarDocumentResponse, error) {
+	now := time.Now()
+	documents, err := u.documentRepo.FindExpiringBefore(now.Add(within))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []model.CarDocumentResponse{}
+	for _, doc := range documents {
+		responses = append(responses, u.toResponse(&doc, now))
+	}
+	return responses, nil
+}
+
+func (u *carDocumentUsecase) ensureCar(carID int64) error {
+	_, err := u.carRepo.FindByID(carID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("car not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *carDocumentUsecase) find(carID, id int64) (*entity.CarDocument, error) {
+	document, err := u.documentRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("document not found")
+		}
+		return nil, err
+	}
+	if document.CarID != carID {
+		return nil, errors.New("document not found")
+	}
+	return document, nil
+}
+
+func (u *carDocumentUsecase) applyRequest(document *entity.CarDocument, req model.CarDocumentRequest) error {
+	expiry, err := time.ParseInLocation("2006-01-02", req.ExpiryDate, time.Local)
+	if err != nil {
+		return errors.New("expiry_date must use the YYYY-MM-DD format")
+	}
+
+	var issue *time.Time
+	if req.IssueDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", req.IssueDate, time.Local)
+		if err != nil {
+			return errors.New("issue_date must use the YYYY-MM-DD format")
+		}
+		if t.After(expiry) {
+			return errors.New("issue_date must be before expiry_date")
+		}
+		issue = &t
+	}
+
+	document.Type = req.Type
+	document.Number = req.Number
+	document.IssueDate = issue
+	document.ExpiryDate = expiry
+	document.Notes = req.Notes
+	return nil
+}
+
+func (u *carDocumentUsecase) storeFile(document *entity.CarDocument, file *model.FileUpload) error {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	contentType, ok := allowedDocumentFiles[ext]
+	if !ok {
+		return errors.New("file must be a PDF, JPG or PNG")
+	}
+
+	key, err := u.fileStore.Save(fmt.Sprintf("documents/%d", document.CarID), file.Filename, file.Content)
+	if err != nil {
+		return errors.New("failed to store file")
+	}
+
+	document.FileKey = key
+	document.FileName = filepath.Base(file.Filename)
+	document.FileContentType = contentType
+	return nil
+}
+
+func (u *carDocumentUsecase) toResponse(doc *entity.CarDocument, now time.Time) model.CarDocumentResponse {
+	resp := model.CarDocumentResponse{
+		ID:           doc.ID,
+		CarID:        doc.CarID,
+		Type:         doc.Type,
+		Number:       doc.Number,
+		IssueDate:    doc.IssueDate,
+		ExpiryDate:   doc.ExpiryDate,
+		DaysToExpiry: int(doc.ExpiryDate.Sub(now).Hours() / 24),
+		Expired:      !now.Before(doc.ExpiryDate),
+		Notes:        doc.Notes,
+		CreatedAt:    doc.CreatedAt,
+		UpdatedAt:    doc.UpdatedAt,
+	}
+	if doc.FileKey != "" {
+		resp.FileName = doc.FileName
+		resp.FileContentType = doc.FileContentType
+		resp.FileURL = fmt.Sprintf("/api/cars/%d/documents/%d/file", doc.CarID, doc.ID)
+	}
+	if doc.Car != nil {
+		resp.Car = &model.CarResponse{
+			ID:           doc.Car.ID,
+			LicensePlate: doc.Car.LicensePlate,
+			Brand:        doc.Car.Brand,
+			Model:        doc.Car.Model,
+		}
+	}
+	return resp
+}
@@ -3,12 +3,21 @@ package usecase
 import (
 	"errors"
 	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/geo"
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/realtime"
 	"fleet-monitor/internal/repository"
+	"sort"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// locationMaxClockSkew is how far ahead of the server clock a device
+// timestamp may be before the point is rejected.
+const locationMaxClockSkew = 5 * time.Minute
+
 type CarUsecase interface {
 	GetAll(params model.CarListParams) ([]model.CarResponse, int64, error)
 	GetByID(id int64) (*model.CarResponse, error)
@@ -16,14 +25,41 @@ type CarUsecase interface {
 	Update(id int64, req model.CarRequest) (*model.CarResponse, error)
 	Delete(id int64) error
 	UpdateLocation(id int64, req model.UpdateLocationRequest) error
+	RecordLocations(id int64, points []model.UpdateLocationRequest) (*model.BatchLocationResponse, error)
+	GetLocations(id int64, params model.CarLocationListParams) ([]model.CarLocationResponse, error)
 }
 
 type carUsecase struct {
-	carRepo repository.CarRepository
+	carRepo           repository.CarRepository
+	carLocationRepo   repository.CarLocationRepository
+	geofenceRepo      repository.GeofenceRepository
+	geofenceEventRepo repository.GeofenceEventRepository
+	alerts            *AlertEngine
+	webhooks          *WebhookEmitter
+	txManager         helper.TxManager
+	hub               realtime.Hub
 }
 
-func NewCarUsecase(carRepo repository.CarRepository) CarUsecase {
-	return &carUsecase{carRepo: carRepo}
+func NewCarUsecase(
+	carRepo repository.CarRepository,
+	carLocationRepo repository.CarLocationRepository,
+	geofenceRepo repository.GeofenceRepository,
+	geofenceEventRepo repository.GeofenceEventRepository,
+	alerts *AlertEngine,
+	webhooks *WebhookEmitter,
+	txManager helper.TxManager,
+	hub realtime.Hub,
+) CarUsecase {
+	return &carUsecase{
+		carRepo:           carRepo,
+		carLocationRepo:   carLocationRepo,
+		geofenceRepo:      geofenceRepo,
+		geofenceEventRepo: geofenceEventRepo,
+		alerts:            alerts,
+		webhooks:          webhooks,
+		txManager:         txManager,
+		hub:               hub,
+	}
 }
 
 func (u *carUsecase) GetAll(params model.CarListParams) ([]model.CarResponse, int64, error) {
@@ -63,11 +99,12 @@ func (u *carUsecase) Create(req model.CarRequest) (*model.CarResponse, error) {
 	}
 
 	car := &entity.Car{
-		LicensePlate: req.LicensePlate,
-		Brand:        req.Brand,
-		Model:        req.Model,
-		Year:         req.Year,
-		Status:       status,
+		LicensePlate:     req.LicensePlate,
+		Brand:            req.Brand,
+		Model:            req.Model,
+		Year:             req.Year,
+		Status:           status,
+		RequiresApproval: req.RequiresApproval,
 	}
 
 	if err := u.carRepo.Create(car); err != nil {
@@ -79,33 +116,54 @@ func (u *carUsecase) Create(req model.CarRequest) (*model.CarResponse, error) {
 }
 
 func (u *carUsecase) Update(id int64, req model.CarRequest) (*model.CarResponse, error) {
-	car, err := u.carRepo.FindByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("car not found")
+	var car *entity.Car
+	var previousStatus string
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		carRepo := u.carRepo.WithTx(tx)
+
+		var err error
+		car, err = carRepo.FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	if req.LicensePlate != car.LicensePlate {
-		existing, _ := u.carRepo.FindByLicensePlate(req.LicensePlate)
-		if existing != nil && existing.ID != id {
-			return nil, errors.New("license plate already exists")
+		if req.LicensePlate != car.LicensePlate {
+			existing, _ := carRepo.FindByLicensePlate(req.LicensePlate)
+			if existing != nil && existing.ID != id {
+				return errors.New("license plate already exists")
+			}
 		}
-	}
 
-	car.LicensePlate = req.LicensePlate
-	car.Brand = req.Brand
-	car.Model = req.Model
-	car.Year = req.Year
-	if req.Status != "" {
-		car.Status = req.Status
-	}
+		previousStatus = car.Status
 
-	if err := u.carRepo.Update(car); err != nil {
+		car.LicensePlate = req.LicensePlate
+		car.Brand = req.Brand
+		car.Model = req.Model
+		car.Year = req.Year
+		car.RequiresApproval = req.RequiresApproval
+		if req.Status != "" {
+			car.Status = req.Status
+		}
+
+		if err := carRepo.Update(car); err != nil {
+			return err
+		}
+		return u.webhooks.carStatusChanged(tx, car, previousStatus, car.Status, carStatusReasonManual)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	if car.Status != previousStatus {
+		u.hub.Publish(newStatusEvent(car, previousStatus, car.Status))
+	}
+
+	// Reload with the current driver
+	car, _ = u.carRepo.FindByID(id)
 	response := u.toResponse(car)
 	return &response, nil
 }
@@ -122,30 +180,243 @@ func (u *carUsecase) Delete(id int64) error {
 }
 
 func (u *carUsecase) UpdateLocation(id int64, req model.UpdateLocationRequest) error {
+	result, err := u.RecordLocations(id, []model.UpdateLocationRequest{req})
+	if err != nil {
+		return err
+	}
+	if result.Rejected > 0 {
+		return errors.New("recorded_at is too far in the future")
+	}
+	return nil
+}
+
+// RecordLocations stores points in device-time order, skipping any the car
+// already has for the same timestamp. Points older than the car's last known
+// position only fill in history: they do not move the car or trigger
+// geofence events, so a late upload cannot rewind the live state.
+func (u *carUsecase) RecordLocations(id int64, points []model.UpdateLocationRequest) (*model.BatchLocationResponse, error) {
+	now := time.Now()
+	result := &model.BatchLocationResponse{Received: len(points)}
+
+	locations := make([]entity.CarLocation, 0, len(points))
+	seen := make(map[int64]bool, len(points))
+	for _, p := range points {
+		// Postgres keeps microseconds; compare at the precision that gets stored
+		recordedAt := now.Truncate(time.Microsecond)
+		if p.RecordedAt != nil {
+			recordedAt = p.RecordedAt.Local().Truncate(time.Microsecond)
+		}
+		if recordedAt.After(now.Add(locationMaxClockSkew)) {
+			result.Rejected++
+			continue
+		}
+		if seen[recordedAt.UnixNano()] {
+			result.Duplicates++
+			continue
+		}
+		seen[recordedAt.UnixNano()] = true
+
+		locations = append(locations, entity.CarLocation{
+			CarID:      id,
+			Lat:        p.Lat,
+			Lng:        p.Lng,
+			Speed:      p.Speed,
+			Heading:    p.Heading,
+			Accuracy:   p.Accuracy,
+			Battery:    p.Battery,
+			RecordedAt: recordedAt,
+		})
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].RecordedAt.Before(locations[j].RecordedAt)
+	})
+
+	var car *entity.Car
+	var latest *entity.CarLocation
+	var live []entity.CarLocation
+	var crossings []entity.GeofenceEvent
+	var raised []entity.Alert
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		// Lock the car so concurrent updates see each other's geofence events
+		var err error
+		car, err = u.carRepo.WithTx(tx).FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+
+		locationRepo := u.carLocationRepo.WithTx(tx)
+		for i := range locations {
+			location := &locations[i]
+			inserted, err := locationRepo.CreateIfAbsent(location)
+			if err != nil {
+				return err
+			}
+			if !inserted {
+				result.Duplicates++
+				continue
+			}
+			result.Accepted++
+
+			if car.LastUpdateLoc != nil && !location.RecordedAt.After(*car.LastUpdateLoc) {
+				continue
+			}
+			events, err := u.detectGeofenceCrossings(tx, id, geo.Point{Lat: location.Lat, Lng: location.Lng}, location.RecordedAt)
+			if err != nil {
+				return err
+			}
+			crossings = append(crossings, events...)
+			live = append(live, *location)
+			latest = location
+		}
+		if latest == nil {
+			return nil
+		}
+
+		raised, err = u.alerts.checkLocations(tx, car, live, crossings)
+		if err != nil {
+			return err
+		}
+
+		// Keep last known position on the car in sync
+		return u.carRepo.WithTx(tx).UpdateLocation(id, latest.Lat, latest.Lng, latest.RecordedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if latest != nil {
+		u.hub.Publish(model.CarEvent{
+			Type:         model.CarEventLocation,
+			CarID:        car.ID,
+			LicensePlate: car.LicensePlate,
+			Status:       car.Status,
+			Lat:          &latest.Lat,
+			Lng:          &latest.Lng,
+			Timestamp:    latest.RecordedAt,
+		})
+	}
+	for i := range crossings {
+		crossing := &crossings[i]
+		u.hub.Publish(model.CarEvent{
+			Type:         model.CarEventGeofence,
+			CarID:        car.ID,
+			LicensePlate: car.LicensePlate,
+			Status:       car.Status,
+			Lat:          &crossing.Lat,
+			Lng:          &crossing.Lng,
+			GeofenceID:   &crossing.GeofenceID,
+			GeofenceName: crossing.Geofence.Name,
+			Transition:   crossing.Type,
+			Timestamp:    crossing.OccurredAt,
+		})
+	}
+	for i := range raised {
+		u.hub.Publish(newAlertEvent(car, &raised[i]))
+	}
+	return result, nil
+}
+
+// detectGeofenceCrossings compares the new position with the car's last known
+// state for every active geofence and records an ENTER or EXIT event when it
+// changed. A car first seen outside a geofence records nothing.
+func (u *carUsecase) detectGeofenceCrossings(tx *gorm.DB, carID int64, p geo.Point, at time.Time) ([]entity.GeofenceEvent, error) {
+	geofences, err := u.geofenceRepo.WithTx(tx).FindActive()
+	if err != nil || len(geofences) == 0 {
+		return nil, err
+	}
+
+	eventRepo := u.geofenceEventRepo.WithTx(tx)
+	latest, err := eventRepo.FindLatestByCarID(carID)
+	if err != nil {
+		return nil, err
+	}
+
+	var crossings []entity.GeofenceEvent
+	for i := range geofences {
+		geofence := &geofences[i]
+
+		last, seen := latest[geofence.ID]
+		wasInside := seen && last.Type == entity.GeofenceEventEnter
+		inside := geofenceContains(geofence, p)
+		if inside == wasInside {
+			continue
+		}
+
+		event := entity.GeofenceEvent{
+			GeofenceID: geofence.ID,
+			CarID:      carID,
+			Type:       entity.GeofenceEventExit,
+			Lat:        p.Lat,
+			Lng:        p.Lng,
+			OccurredAt: at,
+		}
+		if inside {
+			event.Type = entity.GeofenceEventEnter
+		}
+		if err := eventRepo.Create(&event); err != nil {
+			return nil, err
+		}
+		event.Geofence = geofence
+		crossings = append(crossings, event)
+	}
+	return crossings, nil
+}
+
+func (u *carUsecase) GetLocations(id int64, params model.CarLocationListParams) ([]model.CarLocationResponse, error) {
 	_, err := u.carRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("car not found")
+			return nil, errors.New("car not found")
 		}
-		return err
+		return nil, err
+	}
+
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	locations, err := u.carLocationRepo.FindByCarID(id, params)
+	if err != nil {
+		return nil, err
 	}
-	return u.carRepo.UpdateLocation(id, req.Lat, req.Lng)
+
+	responses := []model.CarLocationResponse{}
+	for _, loc := range locations {
+		responses = append(responses, model.CarLocationResponse{
+			ID:         loc.ID,
+			CarID:      loc.CarID,
+			Lat:        loc.Lat,
+			Lng:        loc.Lng,
+			Speed:      loc.Speed,
+			Heading:    loc.Heading,
+			Accuracy:   loc.Accuracy,
+			Battery:    loc.Battery,
+			RecordedAt: loc.RecordedAt,
+		})
+	}
+	return responses, nil
 }
 
 func (u *carUsecase) toResponse(car *entity.Car) model.CarResponse {
 	resp := model.CarResponse{
-		ID:              car.ID,
-		LicensePlate:    car.LicensePlate,
-		Brand:           car.Brand,
-		Model:           car.Model,
-		Year:            car.Year,
-		Status:          car.Status,
-		CurrentDriverID: car.CurrentDriverID,
-		LastLat:         car.LastLat,
-		LastLng:         car.LastLng,
-		LastUpdateLoc:   car.LastUpdateLoc,
-		CreatedAt:       car.CreatedAt,
-		UpdatedAt:       car.UpdatedAt,
+		ID:               car.ID,
+		LicensePlate:     car.LicensePlate,
+		Brand:            car.Brand,
+		Model:            car.Model,
+		Year:             car.Year,
+		Status:           car.Status,
+		CurrentDriverID:  car.CurrentDriverID,
+		LastLat:          car.LastLat,
+		LastLng:          car.LastLng,
+		LastUpdateLoc:    car.LastUpdateLoc,
+		CurrentKm:        car.CurrentKm,
+		RequiresApproval: car.RequiresApproval,
+		CreatedAt:        car.CreatedAt,
+		UpdatedAt:        car.UpdatedAt,
 	}
 	if car.CurrentDriver != nil {
 		resp.CurrentDriver = &model.DriverResponse{
@@ -158,3 +429,17 @@ func (u *carUsecase) toResponse(car *entity.Car) model.CarResponse {
 	}
 	return resp
 }
+
+// newStatusEvent builds the realtime event published when a car changes status.
+func newStatusEvent(car *entity.Car, previous, status string) model.CarEvent {
+	return model.CarEvent{
+		Type:           model.CarEventStatus,
+		CarID:          car.ID,
+		LicensePlate:   car.LicensePlate,
+		Status:         status,
+		PreviousStatus: previous,
+		Lat:            car.LastLat,
+		Lng:            car.LastLng,
+		Timestamp:      time.Now(),
+	}
+}
@@ -4,27 +4,40 @@ import (
 	"fleet-monitor/internal/entity"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/repository"
+	"time"
 )
 
+// documentExpiryWindow is how far ahead the dashboard warns about expiring documents.
+const documentExpiryWindow = 30 * 24 * time.Hour
+
 type DashboardUsecase interface {
 	GetSummary() (*model.DashboardSummary, error)
 }
 
 type dashboardUsecase struct {
-	carRepo    repository.CarRepository
-	driverRepo repository.DriverRepository
-	tripRepo   repository.TripRepository
+	carRepo      repository.CarRepository
+	driverRepo   repository.DriverRepository
+	tripRepo     repository.TripRepository
+	documentRepo repository.CarDocumentRepository
+	odometerRepo repository.OdometerRepository
+	alertRepo    repository.AlertRepository
 }
 
 func NewDashboardUsecase(
 	carRepo repository.CarRepository,
 	driverRepo repository.DriverRepository,
 	tripRepo repository.TripRepository,
+	documentRepo repository.CarDocumentRepository,
+	odometerRepo repository.OdometerRepository,
+	alertRepo repository.AlertRepository,
 ) DashboardUsecase {
 	return &dashboardUsecase{
-		carRepo:    carRepo,
-		driverRepo: driverRepo,
-		tripRepo:   tripRepo,
+		carRepo:      carRepo,
+		driverRepo:   driverRepo,
+		tripRepo:     tripRepo,
+		documentRepo: documentRepo,
+		odometerRepo: odometerRepo,
+		alertRepo:    alertRepo,
 	}
 }
 
@@ -37,6 +50,12 @@ func (u *dashboardUsecase) GetSummary() (*model.DashboardSummary, error) {
 	totalDrivers, _ := u.driverRepo.Count()
 	activeDrivers, _ := u.driverRepo.CountByStatus(entity.DriverStatusActive)
 
+	now := time.Now()
+	expiredDocuments, _ := u.documentRepo.CountExpiredAt(now)
+	expiringDocuments, _ := u.documentRepo.CountExpiringBetween(now, now.Add(documentExpiryWindow))
+	flaggedOdometer, _ := u.odometerRepo.CountUnreviewed()
+	openAlerts, _ := u.alertRepo.CountUnresolved()
+
 	recentTrips, _ := u.tripRepo.FindRecent(5)
 	var tripResponses []model.TripResponse
 	for _, trip := range recentTrips {
@@ -68,12 +87,16 @@ func (u *dashboardUsecase) GetSummary() (*model.DashboardSummary, error) {
 	}
 
 	return &model.DashboardSummary{
-		TotalCars:       totalCars,
-		AvailableCars:   availableCars,
-		InUseCars:       inUseCars,
-		MaintenanceCars: maintenanceCars,
-		TotalDrivers:    totalDrivers,
-		ActiveDrivers:   activeDrivers,
-		RecentTrips:     tripResponses,
+		TotalCars:         totalCars,
+		AvailableCars:     availableCars,
+		InUseCars:         inUseCars,
+		MaintenanceCars:   maintenanceCars,
+		TotalDrivers:      totalDrivers,
+		ActiveDrivers:     activeDrivers,
+		ExpiredDocuments:  expiredDocuments,
+		ExpiringDocuments: expiringDocuments,
+		FlaggedOdometer:   flaggedOdometer,
+		OpenAlerts:        openAlerts,
+		RecentTrips:       tripResponses,
 	}, nil
 }
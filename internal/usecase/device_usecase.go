@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Device API keys look like fmk_<prefix>_<secret>. The prefix finds the key
+// row; the whole key is compared against its stored hash.
+const (
+	deviceKeyScheme = "fmk"
+	// deviceKeyTouchInterval limits how often last_used_at is written for a
+	// key that pushes a location every few seconds.
+	deviceKeyTouchInterval = time.Minute
+)
+
+// DeviceUsecase manages GPS devices and their API keys. It is also the sink
+// for the tracker listeners, mapping each IMEI to its car and recording
+// positions through the same pipeline as the location endpoint.
+type DeviceUsecase interface {
+	GetAll(params model.DeviceListParams) ([]model.DeviceResponse, int64, error)
+	GetByID(id int64) (*model.DeviceResponse, error)
+	Create(req model.DeviceRequest) (*model.DeviceResponse, error)
+	Update(id int64, req model.DeviceRequest) (*model.DeviceResponse, error)
+	Delete(id int64) error
+	GetKeys(deviceID int64) ([]model.DeviceAPIKeyResponse, error)
+	CreateKey(deviceID int64, req model.DeviceAPIKeyRequest, userID int64) (*model.DeviceAPIKeyResponse, error)
+	RevokeKey(deviceID, keyID int64) (*model.DeviceAPIKeyResponse, error)
+	AuthenticateKey(rawKey string) (*model.DeviceIdentity, error)
+	Authorize(protocol, imei string) error
+	ReportPosition(pos model.TrackerPosition) error
+}
+
+type deviceUsecase struct {
+	deviceRepo repository.DeviceRepository
+	keyRepo    repository.DeviceAPIKeyRepository
+	carRepo    repository.CarRepository
+	carUsecase CarUsecase
+}
+
+func NewDeviceUsecase(
+	deviceRepo repository.DeviceRepository,
+	keyRepo repository.DeviceAPIKeyRepository,
+	carRepo repository.CarRepository,
+	carUsecase CarUsecase,
+) DeviceUsecase {
+	return &deviceUsecase{
+		deviceRepo: deviceRepo,
+		keyRepo:    keyRepo,
+		carRepo:    carRepo,
+		carUsecase: carUsecase,
+	}
+}
+
+func (u *deviceUsecase) GetAll(params model.DeviceListParams) ([]model.DeviceResponse, int64, error) {
+	devices, total, err := u.deviceRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.DeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		responses = append(responses, u.toResponse(&device))
+	}
+	return responses, total, nil
+}
+
+func (u *deviceUsecase) GetByID(id int64) (*model.DeviceResponse, error) {
+	device, err := u.deviceRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("device not found")
+		}
+		return nil, err
+	}
+	response := u.toResponse(device)
+	return &response, nil
+}
+
+func (u *deviceUsecase) Create(req model.DeviceRequest) (*model.DeviceResponse, error) {
+	device := &entity.Device{}
+	if err := u.apply(device, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.deviceRepo.Create(device); err != nil {
+		return nil, err
+	}
+
+	device, _ = u.deviceRepo.FindByID(device.ID)
+	response := u.toResponse(device)
+	return &response, nil
+}
+
+func (u *deviceUsecase) Update(id int64, req model.DeviceRequest) (*model.DeviceResponse, error) {
+	device, err := u.deviceRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("device not found")
+		}
+		return nil, err
+	}
+
+	if err := u.apply(device, req); err != nil {
+		return nil, err
+	}
+
+	device.Car = nil
+	if err := u.deviceRepo.Update(device); err != nil {
+		return nil, err
+	}
+
+	device, _ = u.deviceRepo.FindByID(id)
+	response := u.toResponse(device)
+	return &response, nil
+}
+
+func (u *deviceUsecase) Delete(id int64) error {
+	_, err := u.deviceRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("device not found")
+		}
+		return err
+	}
+	return u.deviceRepo.Delete(id)
+}
+
+func (u *deviceUsecase) GetKeys(deviceID int64) ([]model.DeviceAPIKeyResponse, error) {
+	if _, err := u.findDevice(deviceID); err != nil {
+		return nil, err
+	}
+
+	keys, err := u.keyRepo.FindByDeviceID(deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.DeviceAPIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		responses = append(responses, toDeviceAPIKeyResponse(&key))
+	}
+	return responses, nil
+}
+
+// CreateKey issues a new key. The plaintext is only part of this response;
+// afterwards only its hash is known.
+func (u *deviceUsecase) CreateKey(deviceID int64, req model.DeviceAPIKeyRequest, userID int64) (*model.DeviceAPIKeyResponse, error) {
+	if _, err := u.findDevice(deviceID); err != nil {
+		return nil, err
+	}
+
+	prefixBytes := make([]byte, 4)
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, err
+	}
+	prefix := hex.EncodeToString(prefixBytes)
+	rawKey := deviceKeyScheme + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	key := &entity.DeviceAPIKey{
+		DeviceID: deviceID,
+		Name:     req.Name,
+		Prefix:   prefix,
+		KeyHash:  hashDeviceKey(rawKey),
+	}
+	if userID > 0 {
+		key.CreatedBy = &userID
+	}
+	if err := u.keyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	response := toDeviceAPIKeyResponse(key)
+	response.Key = rawKey
+	return &response, nil
+}
+
+func (u *deviceUsecase) RevokeKey(deviceID, keyID int64) (*model.DeviceAPIKeyResponse, error) {
+	key, err := u.keyRepo.FindByID(keyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("api key not found")
+		}
+		return nil, err
+	}
+	if key.DeviceID != deviceID {
+		return nil, errors.New("api key not found")
+	}
+	if key.RevokedAt != nil {
+		return nil, errors.New("api key already revoked")
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := u.keyRepo.Update(key); err != nil {
+		return nil, err
+	}
+
+	response := toDeviceAPIKeyResponse(key)
+	return &response, nil
+}
+
+// AuthenticateKey resolves a raw key to the car it may push locations for.
+// Revoked keys, inactive devices and unassigned devices are rejected with
+// the same error so callers learn nothing about which keys exist.
+func (u *deviceUsecase) AuthenticateKey(rawKey string) (*model.DeviceIdentity, error) {
+	invalid := errors.New("invalid api key")
+
+	// The base64url secret may itself contain "_".
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != deviceKeyScheme {
+		return nil, invalid
+	}
+	key, err := u.keyRepo.FindByPrefix(parts[1])
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashDeviceKey(rawKey))) != 1 {
+		return nil, invalid
+	}
+	if key.RevokedAt != nil || key.Device == nil || !key.Device.Active || key.Device.CarID == nil {
+		return nil, invalid
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= deviceKeyTouchInterval {
+		if err := u.keyRepo.TouchLastUsed(key.ID, now); err != nil {
+			return nil, err
+		}
+		if err := u.deviceRepo.TouchLastSeen(key.DeviceID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.DeviceIdentity{
+		DeviceID: key.DeviceID,
+		KeyID:    key.ID,
+		CarID:    *key.Device.CarID,
+	}, nil
+}
+
+// Authorize accepts a tracker login only for an active device of the same
+// protocol that is assigned to a car.
+func (u *deviceUsecase) Authorize(protocol, imei string) error {
+	device, err := u.findTracker(imei)
+	if err != nil {
+		return err
+	}
+	if device.Protocol != protocol {
+		return errors.New("device is registered with another protocol")
+	}
+	return u.deviceRepo.TouchLastSeen(device.ID, time.Now())
+}
+
+func (u *deviceUsecase) ReportPosition(pos model.TrackerPosition) error {
+	device, err := u.findTracker(pos.IMEI)
+	if err != nil {
+		return err
+	}
+
+	recordedAt := pos.RecordedAt
+	speed := pos.SpeedKmh
+	heading := pos.Heading % 360
+	req := model.UpdateLocationRequest{
+		Lat:        pos.Lat,
+		Lng:        pos.Lng,
+		RecordedAt: &recordedAt,
+		Speed:      &speed,
+		Heading:    &heading,
+	}
+	if err := u.carUsecase.UpdateLocation(*device.CarID, req); err != nil {
+		return err
+	}
+	return u.deviceRepo.TouchLastSeen(device.ID, time.Now())
+}
+
+func (u *deviceUsecase) findDevice(id int64) (*entity.Device, error) {
+	device, err := u.deviceRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("device not found")
+		}
+		return nil, err
+	}
+	return device, nil
+}
+
+// findTracker looks the device up on every call so deactivating or
+// reassigning it takes effect without reconnecting the tracker.
+func (u *deviceUsecase) findTracker(imei string) (*entity.Device, error) {
+	device, err := u.deviceRepo.FindByIMEI(imei)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("unknown device")
+		}
+		return nil, err
+	}
+	if !device.Active {
+		return nil, errors.New("device is inactive")
+	}
+	if device.CarID == nil {
+		return nil, errors.New("device is not assigned to a car")
+	}
+	return device, nil
+}
+
+func (u *deviceUsecase) apply(device *entity.Device, req model.DeviceRequest) error {
+	var imei *string
+	if req.Protocol != entity.DeviceProtocolHTTP {
+		if req.IMEI == "" {
+			return errors.New("imei is required for tracker devices")
+		}
+		existing, err := u.deviceRepo.FindByIMEI(req.IMEI)
+		if err == nil && existing.ID != device.ID {
+			return errors.New("imei already registered")
+		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		imei = &req.IMEI
+	}
+
+	if req.CarID != nil {
+		if _, err := u.carRepo.FindByID(*req.CarID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+		// One active tracker per car, otherwise two devices fight over its position
+		if req.Active {
+			other, err := u.deviceRepo.FindActiveByCarID(*req.CarID)
+			if err == nil && other.ID != device.ID {
+				return errors.New("car already has an active device")
+			} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+	}
+
+	device.Name = req.Name
+	device.IMEI = imei
+	device.Protocol = req.Protocol
+	device.CarID = req.CarID
+	device.Active = req.Active
+	return nil
+}
+
+func (u *deviceUsecase) toResponse(device *entity.Device) model.DeviceResponse {
+	resp := model.DeviceResponse{
+		ID:         device.ID,
+		Name:       device.Name,
+		IMEI:       device.IMEI,
+		Protocol:   device.Protocol,
+		CarID:      device.CarID,
+		Active:     device.Active,
+		LastSeenAt: device.LastSeenAt,
+		CreatedAt:  device.CreatedAt,
+		UpdatedAt:  device.UpdatedAt,
+	}
+	if device.Car != nil {
+		resp.Car = &model.CarResponse{
+			ID:           device.Car.ID,
+			LicensePlate: device.Car.LicensePlate,
+			Brand:        device.Car.Brand,
+			Model:        device.Car.Model,
+			Status:       device.Car.Status,
+		}
+	}
+	return resp
+}
+
+func hashDeviceKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func toDeviceAPIKeyResponse(key *entity.DeviceAPIKey) model.DeviceAPIKeyResponse {
+	return model.DeviceAPIKeyResponse{
+		ID:         key.ID,
+		DeviceID:   key.DeviceID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		CreatedBy:  key.CreatedBy,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
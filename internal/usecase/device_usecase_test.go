@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakeDeviceRepo struct {
+	devices map[int64]*entity.Device
+}
+
+func (r *fakeDeviceRepo) FindAll(params model.DeviceListParams) ([]entity.Device, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *fakeDeviceRepo) FindByID(id int64) (*entity.Device, error) {
+	device, ok := r.devices[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return device, nil
+}
+
+func (r *fakeDeviceRepo) FindByIMEI(imei string) (*entity.Device, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDeviceRepo) FindActiveByCarID(carID int64) (*entity.Device, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDeviceRepo) Create(device *entity.Device) error { return nil }
+func (r *fakeDeviceRepo) Update(device *entity.Device) error { return nil }
+func (r *fakeDeviceRepo) Delete(id int64) error              { return nil }
+
+func (r *fakeDeviceRepo) TouchLastSeen(id int64, at time.Time) error { return nil }
+
+type fakeDeviceAPIKeyRepo struct {
+	devices *fakeDeviceRepo
+	keys    []*entity.DeviceAPIKey
+}
+
+func (r *fakeDeviceAPIKeyRepo) FindByDeviceID(deviceID int64) ([]entity.DeviceAPIKey, error) {
+	return nil, nil
+}
+
+func (r *fakeDeviceAPIKeyRepo) FindByID(id int64) (*entity.DeviceAPIKey, error) {
+	for _, key := range r.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDeviceAPIKeyRepo) FindByPrefix(prefix string) (*entity.DeviceAPIKey, error) {
+	for _, key := range r.keys {
+		if key.Prefix == prefix {
+			found := *key
+			found.Device = r.devices.devices[key.DeviceID]
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDeviceAPIKeyRepo) Create(key *entity.DeviceAPIKey) error {
+	key.ID = int64(len(r.keys) + 1)
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *fakeDeviceAPIKeyRepo) Update(key *entity.DeviceAPIKey) error { return nil }
+
+func (r *fakeDeviceAPIKeyRepo) TouchLastUsed(id int64, at time.Time) error { return nil }
+
+func newTestDeviceUsecase() (*deviceUsecase, *fakeDeviceAPIKeyRepo) {
+	carID := int64(7)
+	devices := &fakeDeviceRepo{devices: map[int64]*entity.Device{
+		1: {ID: 1, Protocol: entity.DeviceProtocolHTTP, CarID: &carID, Active: true},
+	}}
+	keys := &fakeDeviceAPIKeyRepo{devices: devices}
+	return &deviceUsecase{deviceRepo: devices, keyRepo: keys}, keys
+}
+
+func TestDeviceKeyRoundTrip(t *testing.T) {
+	u, _ := newTestDeviceUsecase()
+
+	// About half of all secrets contain "_"; create enough keys to be sure
+	// both shapes are authenticated.
+	withUnderscore := 0
+	for i := 0; i < 64; i++ {
+		created, err := u.CreateKey(1, model.DeviceAPIKeyRequest{Name: "phone"}, 0)
+		if err != nil {
+			t.Fatalf("CreateKey: %v", err)
+		}
+		secret := strings.SplitN(created.Key, "_", 3)[2]
+		if strings.Contains(secret, "_") {
+			withUnderscore++
+		}
+
+		identity, err := u.AuthenticateKey(created.Key)
+		if err != nil {
+			t.Fatalf("AuthenticateKey(%q): %v", created.Key, err)
+		}
+		if identity.DeviceID != 1 || identity.CarID != 7 || identity.KeyID != created.ID {
+			t.Fatalf("AuthenticateKey(%q) = %+v", created.Key, identity)
+		}
+	}
+	if withUnderscore == 0 {
+		t.Fatal("no generated secret contained an underscore")
+	}
+}
+
+func TestAuthenticateKeySecretWithUnderscore(t *testing.T) {
+	u, keys := newTestDeviceUsecase()
+	rawKey := "fmk_0a1b2c3d_ab_cd_ef-gh"
+	keys.Create(&entity.DeviceAPIKey{DeviceID: 1, Prefix: "0a1b2c3d", KeyHash: hashDeviceKey(rawKey)})
+
+	if _, err := u.AuthenticateKey(rawKey); err != nil {
+		t.Fatalf("AuthenticateKey(%q): %v", rawKey, err)
+	}
+	for _, bad := range []string{
+		"fmk_0a1b2c3d_ab_cd_ef-gX",
+		"fmk_0a1b2c3d",
+		"xyz_0a1b2c3d_ab_cd_ef-gh",
+		"fmk_ffffffff_ab_cd_ef-gh",
+	} {
+		if _, err := u.AuthenticateKey(bad); err == nil || err.Error() != "invalid api key" {
+			t.Errorf("AuthenticateKey(%q) error = %v, want invalid api key", bad, err)
+		}
+	}
+}
+
+func TestAuthenticateKeyRevoked(t *testing.T) {
+	u, _ := newTestDeviceUsecase()
+	created, err := u.CreateKey(1, model.DeviceAPIKeyRequest{Name: "phone"}, 0)
+	if err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+	if _, err := u.RevokeKey(1, created.ID); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+	if _, err := u.AuthenticateKey(created.Key); err == nil {
+		t.Fatal("revoked key was accepted")
+	}
+}
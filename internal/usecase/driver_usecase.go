@@ -3,6 +3,7 @@ package usecase
 import (
 	"errors"
 	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
 	"fleet-monitor/internal/repository"
 
@@ -12,6 +13,7 @@ import (
 type DriverUsecase interface {
 	GetAll(params model.DriverListParams) ([]model.DriverResponse, int64, error)
 	GetByID(id int64) (*model.DriverResponse, error)
+	GetByUserID(userID int64) (*model.DriverResponse, error)
 	Create(req model.DriverRequest) (*model.DriverResponse, error)
 	Update(id int64, req model.DriverRequest) (*model.DriverResponse, error)
 	Delete(id int64) error
@@ -19,10 +21,17 @@ type DriverUsecase interface {
 
 type driverUsecase struct {
 	driverRepo repository.DriverRepository
+	userRepo   repository.UserRepository
 }
 
-func NewDriverUsecase(driverRepo repository.DriverRepository) DriverUsecase {
-	return &driverUsecase{driverRepo: driverRepo}
+func NewDriverUsecase(
+	driverRepo repository.DriverRepository,
+	userRepo repository.UserRepository,
+) DriverUsecase {
+	return &driverUsecase{
+		driverRepo: driverRepo,
+		userRepo:   userRepo,
+	}
 }
 
 func (u *driverUsecase) GetAll(params model.DriverListParams) ([]model.DriverResponse, int64, error) {
@@ -50,7 +59,26 @@ func (u *driverUsecase) GetByID(id int64) (*model.DriverResponse, error) {
 	return &response, nil
 }
 
+func (u *driverUsecase) GetByUserID(userID int64) (*model.DriverResponse, error) {
+	driver, err := u.driverRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("account is not linked to a driver")
+		}
+		return nil, err
+	}
+	response := u.toResponse(driver)
+	return &response, nil
+}
+
 func (u *driverUsecase) Create(req model.DriverRequest) (*model.DriverResponse, error) {
+	if err := validateLicenseNumber(req.LicenseNumber); err != nil {
+		return nil, err
+	}
+	if err := u.validateUserLink(req.UserID, 0); err != nil {
+		return nil, err
+	}
+
 	status := req.Status
 	if status == "" {
 		status = entity.DriverStatusOffDuty
@@ -61,6 +89,7 @@ func (u *driverUsecase) Create(req model.DriverRequest) (*model.DriverResponse,
 		PhoneNumber:   req.PhoneNumber,
 		LicenseNumber: req.LicenseNumber,
 		Status:        status,
+		UserID:        req.UserID,
 	}
 
 	if err := u.driverRepo.Create(driver); err != nil {
@@ -80,9 +109,23 @@ func (u *driverUsecase) Update(id int64, req model.DriverRequest) (*model.Driver
 		return nil, err
 	}
 
+	if req.LicenseNumber != driver.LicenseNumber {
+		if err := validateLicenseNumber(req.LicenseNumber); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.validateUserLink(req.UserID, id); err != nil {
+		return nil, err
+	}
+
 	driver.Name = req.Name
 	driver.PhoneNumber = req.PhoneNumber
 	driver.LicenseNumber = req.LicenseNumber
+	if req.UserID != nil {
+		driver.UserID = req.UserID
+	} else if req.UnlinkUser {
+		driver.UserID = nil
+	}
 	if req.Status != "" {
 		driver.Status = req.Status
 	}
@@ -106,9 +149,35 @@ func (u *driverUsecase) Delete(id int64) error {
 	return u.driverRepo.Delete(id)
 }
 
+// validateUserLink checks that userID belongs to a driver account that is not
+// already linked to a driver other than driverID.
+func (u *driverUsecase) validateUserLink(userID *int64, driverID int64) error {
+	if userID == nil {
+		return nil
+	}
+
+	user, err := u.userRepo.FindByID(*userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+	if user.Role != entity.UserRoleDriver {
+		return errors.New("user must have the driver role")
+	}
+
+	linked, err := u.driverRepo.FindByUserID(*userID)
+	if err == nil && linked.ID != driverID {
+		return errors.New("user is already linked to another driver")
+	}
+	return nil
+}
+
 func (u *driverUsecase) toResponse(driver *entity.Driver) model.DriverResponse {
 	return model.DriverResponse{
 		ID:            driver.ID,
+		UserID:        driver.UserID,
 		Name:          driver.Name,
 		PhoneNumber:   driver.PhoneNumber,
 		LicenseNumber: driver.LicenseNumber,
@@ -117,3 +186,7 @@ func (u *driverUsecase) toResponse(driver *entity.Driver) model.DriverResponse {
 		UpdatedAt:     driver.UpdatedAt,
 	}
 }
+
+func validateLicenseNumber(licenseNumber string) error {
+	return helper.ValidateVar("license_number", licenseNumber, "omitempty,sim_no")
+}
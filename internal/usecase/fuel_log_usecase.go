@@ -0,0 +1,521 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/config"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/money"
+	"fleet-monitor/internal/repository"
+	"fleet-monitor/internal/storage"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// minBaselineFills is how many measured fills a car needs before its
+// efficiency is judged against its own baseline.
+const minBaselineFills = 3
+
+var allowedReceiptFiles = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+type FuelLogUsecase interface {
+	GetAll(params model.FuelLogListParams) ([]model.FuelLogResponse, int64, error)
+	GetByID(id int64) (*model.FuelLogResponse, error)
+	Create(req model.FuelLogRequest, receipt *model.FileUpload, userID int64) (*model.FuelLogResponse, error)
+	Update(id int64, req model.FuelLogRequest, receipt *model.FileUpload) (*model.FuelLogResponse, error)
+	Delete(id int64) error
+	GetReceipt(id int64) (*model.FileDownload, error)
+	GetEfficiency(params model.FuelEfficiencyParams) ([]model.FuelEfficiencyItem, error)
+}
+
+type fuelLogUsecase struct {
+	fuelLogRepo repository.FuelLogRepository
+	carRepo     repository.CarRepository
+	driverRepo  repository.DriverRepository
+	tripRepo    repository.TripRepository
+	odometer    *odometerGuard
+	analyzer    *fuelAnalyzer
+	fileStore   storage.FileStore
+	txManager   helper.TxManager
+}
+
+func NewFuelLogUsecase(
+	fuelLogRepo repository.FuelLogRepository,
+	carRepo repository.CarRepository,
+	driverRepo repository.DriverRepository,
+	tripRepo repository.TripRepository,
+	odometerRepo repository.OdometerRepository,
+	fileStore storage.FileStore,
+	txManager helper.TxManager,
+	cfg *config.Config,
+) FuelLogUsecase {
+	return &fuelLogUsecase{
+		fuelLogRepo: fuelLogRepo,
+		carRepo:     carRepo,
+		driverRepo:  driverRepo,
+		tripRepo:    tripRepo,
+		odometer:    newOdometerGuard(odometerRepo, carRepo, cfg),
+		analyzer:    &fuelAnalyzer{deviationPct: float64(cfg.FuelDeviationPct), baselineFills: cfg.FuelBaselineFills},
+		fileStore:   fileStore,
+		txManager:   txManager,
+	}
+}
+
+func (u *fuelLogUsecase) GetAll(params model.FuelLogListParams) ([]model.FuelLogResponse, int64, error) {
+	logs, total, err := u.fuelLogRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.FuelLogResponse, 0, len(logs))
+	for _, log := range logs {
+		responses = append(responses, toFuelLogResponse(&log))
+	}
+	return responses, total, nil
+}
+
+func (u *fuelLogUsecase) GetByID(id int64) (*model.FuelLogResponse, error) {
+	log, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toFuelLogResponse(log)
+	return &response, nil
+}
+
+// Create records a fill together with its odometer reading and re-evaluates
+// the car's efficiency, so a back-dated fill lands in the right interval.
+func (u *fuelLogUsecase) Create(req model.FuelLogRequest, receipt *model.FileUpload, userID int64) (*model.FuelLogResponse, error) {
+	log := &entity.FuelLog{CarID: req.CarID, CreatedBy: &userID}
+	if receipt != nil {
+		if err := u.storeReceipt(log, receipt); err != nil {
+			return nil, err
+		}
+	}
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		car, err := u.carRepo.WithTx(tx).FindByIDForUpdate(req.CarID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+		if err := u.applyRequest(tx, log, req); err != nil {
+			return err
+		}
+
+		if err := u.fuelLogRepo.WithTx(tx).Create(log); err != nil {
+			return err
+		}
+
+		reading := &entity.OdometerReading{
+			Km:         log.OdometerKm,
+			Source:     entity.OdometerSourceFuel,
+			FuelLogID:  &log.ID,
+			RecordedBy: &userID,
+			RecordedAt: log.FilledAt,
+		}
+		if err := u.odometer.record(tx, car, reading, nil); err != nil {
+			return err
+		}
+
+		return u.analyze(tx, car.ID)
+	})
+	if err != nil {
+		if log.ReceiptKey != "" {
+			u.fileStore.Delete(log.ReceiptKey)
+		}
+		return nil, err
+	}
+	return u.GetByID(log.ID)
+}
+
+// Update corrects a fill. The car and odometer cannot change, so the fill
+// stays consistent with the odometer reading taken when it was created;
+// odometer mistakes are dealt with in the odometer review.
+func (u *fuelLogUsecase) Update(id int64, req model.FuelLogRequest, receipt *model.FileUpload) (*model.FuelLogResponse, error) {
+	log, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.CarID != log.CarID {
+		return nil, errors.New("car_id cannot be changed")
+	}
+	if req.OdometerKm != log.OdometerKm {
+		return nil, errors.New("odometer_km cannot be changed")
+	}
+
+	previousKey := log.ReceiptKey
+	if receipt != nil {
+		if err := u.storeReceipt(log, receipt); err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if _, err := u.carRepo.WithTx(tx).FindByIDForUpdate(log.CarID); err != nil {
+			return err
+		}
+		if err := u.applyRequest(tx, log, req); err != nil {
+			return err
+		}
+
+		if err := u.fuelLogRepo.WithTx(tx).Update(log); err != nil {
+			return err
+		}
+		return u.analyze(tx, log.CarID)
+	})
+	if err != nil {
+		if receipt != nil {
+			u.fileStore.Delete(log.ReceiptKey)
+		}
+		return nil, err
+	}
+
+	// Only drop the replaced receipt once the new one is referenced
+	if receipt != nil && previousKey != "" {
+		u.fileStore.Delete(previousKey)
+	}
+	return u.GetByID(id)
+}
+
+func (u *fuelLogUsecase) Delete(id int64) error {
+	log, err := u.find(id)
+	if err != nil {
+		return err
+	}
+
+	err = u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if _, err := u.carRepo.WithTx(tx).FindByIDForUpdate(log.CarID); err != nil {
+			return err
+		}
+		if err := u.fuelLogRepo.WithTx(tx).Delete(id); err != nil {
+			return err
+		}
+		return u.analyze(tx, log.CarID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if log.ReceiptKey != "" {
+		u.fileStore.Delete(log.ReceiptKey)
+	}
+	return nil
+}
+
+func (u *fuelLogUsecase) GetReceipt(id int64) (*model.FileDownload, error) {
+	log, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if log.ReceiptKey == "" {
+		return nil, errors.New("fuel log has no receipt")
+	}
+
+	content, err := u.fileStore.Open(log.ReceiptKey)
+	if err != nil {
+		return nil, errors.New("file not found")
+	}
+
+	return &model.FileDownload{
+		Filename:    log.ReceiptName,
+		ContentType: log.ReceiptContentType,
+		Content:     content,
+	}, nil
+}
+
+func (u *fuelLogUsecase) GetEfficiency(params model.FuelEfficiencyParams) ([]model.FuelEfficiencyItem, error) {
+	items, err := u.fuelLogRepo.Efficiency(params)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.FuelEfficiencyItem{}
+	}
+
+	for i := range items {
+		item := &items[i]
+		item.Liters = money.RoundQuantity(item.Liters)
+		item.MeasuredLiters = money.RoundQuantity(item.MeasuredLiters)
+		if item.MeasuredKm <= 0 || item.MeasuredLiters <= 0 {
+			continue
+		}
+		kmPerLiter := float64(item.MeasuredKm) / item.MeasuredLiters
+		rounded := roundTo(kmPerLiter, 2)
+		item.KmPerLiter = &rounded
+
+		costPerKm := roundTo(item.TotalCost/item.Liters/kmPerLiter, 2)
+		item.CostPerKm = &costPerKm
+	}
+	return items, nil
+}
+
+func (u *fuelLogUsecase) find(id int64) (*entity.FuelLog, error) {
+	log, err := u.fuelLogRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("fuel log not found")
+		}
+		return nil, err
+	}
+	return log, nil
+}
+
+func (u *fuelLogUsecase) applyRequest(tx *gorm.DB, log *entity.FuelLog, req model.FuelLogRequest) error {
+	filledAt, err := helper.ParseTimeParam(req.FilledAt)
+	if err != nil {
+		return errors.New("filled_at must use RFC3339 or YYYY-MM-DD")
+	}
+
+	driverID := req.DriverID
+	if req.TripID != nil {
+		trip, err := u.tripRepo.WithTx(tx).FindByID(*req.TripID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("trip not found")
+			}
+			return err
+		}
+		if trip.CarID != log.CarID {
+			return errors.New("trip does not belong to the car")
+		}
+		if driverID == nil {
+			driverID = &trip.DriverID
+		}
+	}
+	if driverID != nil {
+		if _, err := u.driverRepo.WithTx(tx).FindByID(*driverID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("driver not found")
+			}
+			return err
+		}
+	}
+
+	pricePerLiter, err := money.Cents(req.PricePerLiter)
+	if err != nil {
+		return fmt.Errorf("price_per_liter: %w", err)
+	}
+	cost, err := money.Amount(req.Liters, req.PricePerLiter)
+	if err != nil {
+		return err
+	}
+
+	log.DriverID = driverID
+	log.TripID = req.TripID
+	log.FilledAt = *filledAt
+	log.Liters = money.RoundQuantity(req.Liters)
+	log.PricePerLiter = money.FromCents(pricePerLiter)
+	log.TotalCost = money.FromCents(cost)
+	log.OdometerKm = req.OdometerKm
+	log.Station = strings.TrimSpace(req.Station)
+	log.FullTank = req.FullTank
+	log.Notes = req.Notes
+	log.Car = nil
+	log.Driver = nil
+	return nil
+}
+
+// analyze recomputes efficiency and flags for all of the car's fills and
+// saves the ones that changed. The car must already be locked.
+func (u *fuelLogUsecase) analyze(tx *gorm.DB, carID int64) error {
+	fuelLogRepo := u.fuelLogRepo.WithTx(tx)
+
+	logs, err := fuelLogRepo.FindByCarID(carID)
+	if err != nil {
+		return err
+	}
+	for _, log := range u.analyzer.analyze(logs) {
+		if err := fuelLogRepo.UpdateAnalysis(log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *fuelLogUsecase) storeReceipt(log *entity.FuelLog, file *model.FileUpload) error {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	contentType, ok := allowedReceiptFiles[ext]
+	if !ok {
+		return errors.New("receipt must be a PDF, JPG or PNG")
+	}
+
+	key, err := u.fileStore.Save(fmt.Sprintf("fuel/%d", log.CarID), file.Filename, file.Content)
+	if err != nil {
+		return errors.New("failed to store file")
+	}
+
+	log.ReceiptKey = key
+	log.ReceiptName = filepath.Base(file.Filename)
+	log.ReceiptContentType = contentType
+	return nil
+}
+
+// fuelAnalyzer measures efficiency between full-tank fills. Filling up to
+// full again means everything pumped since the previous full tank, partial
+// fills included, was burnt over the distance driven in between. A car's
+// baseline is the median of its last measured fills that were not flagged,
+// so one suspicious fill does not shift the yardstick for the next.
+type fuelAnalyzer struct {
+	deviationPct  float64
+	baselineFills int
+}
+
+// analyze sets the derived fields of logs, which must be one car's fills in
+// order, and returns the logs whose fields changed.
+func (a *fuelAnalyzer) analyze(logs []entity.FuelLog) []*entity.FuelLog {
+	var changed []*entity.FuelLog
+	var history []float64
+	previousFull := -1
+	var liters float64
+
+	for i := range logs {
+		log := &logs[i]
+		before := *log
+		log.DistanceKm = nil
+		log.IntervalLiters = nil
+		log.KmPerLiter = nil
+		log.BaselineKmPerLiter = nil
+		log.Flagged = false
+		log.FlagReason = ""
+
+		if previousFull >= 0 {
+			liters = money.RoundQuantity(liters + log.Liters)
+		}
+		if log.FullTank {
+			if previousFull >= 0 {
+				a.measure(log, &logs[previousFull], liters, &history)
+			}
+			previousFull = i
+			liters = 0
+		}
+
+		if !sameFuelAnalysis(&before, log) {
+			changed = append(changed, log)
+		}
+	}
+	return changed
+}
+
+func (a *fuelAnalyzer) measure(log, previous *entity.FuelLog, liters float64, history *[]float64) {
+	distance := log.OdometerKm - previous.OdometerKm
+	log.DistanceKm = &distance
+	log.IntervalLiters = &liters
+	if distance <= 0 {
+		log.Flagged = true
+		log.FlagReason = "odometer did not increase since the previous full tank"
+		return
+	}
+
+	kmPerLiter := roundTo(float64(distance)/liters, 2)
+	log.KmPerLiter = &kmPerLiter
+
+	if len(*history) >= minBaselineFills {
+		recent := *history
+		if a.baselineFills > 0 && len(recent) > a.baselineFills {
+			recent = recent[len(recent)-a.baselineFills:]
+		}
+		baseline := roundTo(median(recent), 2)
+		log.BaselineKmPerLiter = &baseline
+
+		deviation := (kmPerLiter - baseline) / baseline * 100
+		switch {
+		case deviation <= -a.deviationPct:
+			log.Flagged = true
+			log.FlagReason = fmt.Sprintf("%.2f km/L is %.0f%% below the car's baseline of %.2f km/L; possible fuel theft", kmPerLiter, -deviation, baseline)
+		case deviation >= a.deviationPct:
+			log.Flagged = true
+			log.FlagReason = fmt.Sprintf("%.2f km/L is %.0f%% above the car's baseline of %.2f km/L; check for a missed fill or a wrong odometer", kmPerLiter, deviation, baseline)
+		}
+	}
+	if !log.Flagged {
+		*history = append(*history, kmPerLiter)
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func sameFuelAnalysis(a, b *entity.FuelLog) bool {
+	return sameInt(a.DistanceKm, b.DistanceKm) &&
+		sameFloat(a.IntervalLiters, b.IntervalLiters) &&
+		sameFloat(a.KmPerLiter, b.KmPerLiter) &&
+		sameFloat(a.BaselineKmPerLiter, b.BaselineKmPerLiter) &&
+		a.Flagged == b.Flagged &&
+		a.FlagReason == b.FlagReason
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameFloat(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func toFuelLogResponse(log *entity.FuelLog) model.FuelLogResponse {
+	resp := model.FuelLogResponse{
+		ID:                 log.ID,
+		CarID:              log.CarID,
+		DriverID:           log.DriverID,
+		TripID:             log.TripID,
+		FilledAt:           log.FilledAt,
+		Liters:             log.Liters,
+		PricePerLiter:      log.PricePerLiter,
+		TotalCost:          log.TotalCost,
+		OdometerKm:         log.OdometerKm,
+		Station:            log.Station,
+		FullTank:           log.FullTank,
+		Notes:              log.Notes,
+		DistanceKm:         log.DistanceKm,
+		IntervalLiters:     log.IntervalLiters,
+		KmPerLiter:         log.KmPerLiter,
+		BaselineKmPerLiter: log.BaselineKmPerLiter,
+		Flagged:            log.Flagged,
+		FlagReason:         log.FlagReason,
+		CreatedBy:          log.CreatedBy,
+		CreatedAt:          log.CreatedAt,
+		UpdatedAt:          log.UpdatedAt,
+	}
+	if log.ReceiptKey != "" {
+		resp.ReceiptName = log.ReceiptName
+		resp.ReceiptURL = fmt.Sprintf("/api/fuel-logs/%d/receipt", log.ID)
+	}
+	if log.Car != nil {
+		resp.Car = &model.CarResponse{
+			ID:           log.Car.ID,
+			LicensePlate: log.Car.LicensePlate,
+			Brand:        log.Car.Brand,
+			Model:        log.Car.Model,
+		}
+	}
+	if log.Driver != nil {
+		resp.DriverName = log.Driver.Name
+	}
+	return resp
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"fleet-monitor/internal/entity"
+	"strings"
+	"testing"
+)
+
+// fills builds one car's fills from (odometer km, liters, full tank) triples.
+func fills(entries ...[3]float64) []entity.FuelLog {
+	logs := make([]entity.FuelLog, len(entries))
+	for i, e := range entries {
+		logs[i] = entity.FuelLog{ID: int64(i + 1), OdometerKm: int(e[0]), Liters: e[1], FullTank: e[2] != 0}
+	}
+	return logs
+}
+
+func TestFuelAnalyzerPartialFills(t *testing.T) {
+	a := &fuelAnalyzer{deviationPct: 25, baselineFills: 10}
+	logs := fills(
+		[3]float64{10000, 40, 1},
+		[3]float64{10200, 15, 0},
+		[3]float64{10500, 20, 1},
+	)
+	a.analyze(logs)
+
+	if logs[0].KmPerLiter != nil || logs[1].KmPerLiter != nil || logs[1].DistanceKm != nil {
+		t.Fatalf("only full tanks after the first are measured: %+v %+v", logs[0], logs[1])
+	}
+	last := logs[2]
+	if last.DistanceKm == nil || *last.DistanceKm != 500 {
+		t.Fatalf("distance = %v, want 500", last.DistanceKm)
+	}
+	// The partial fill's liters count towards the interval it was burnt in
+	if last.IntervalLiters == nil || *last.IntervalLiters != 35 {
+		t.Fatalf("interval liters = %v, want 35", last.IntervalLiters)
+	}
+	if last.KmPerLiter == nil || *last.KmPerLiter != 14.29 {
+		t.Fatalf("km/L = %v, want 14.29", last.KmPerLiter)
+	}
+	if last.BaselineKmPerLiter != nil || last.Flagged {
+		t.Fatalf("no baseline before %d measured fills: %+v", minBaselineFills, last)
+	}
+}
+
+func TestFuelAnalyzerFlagsAgainstMedianBaseline(t *testing.T) {
+	a := &fuelAnalyzer{deviationPct: 25, baselineFills: 10}
+	logs := fills(
+		[3]float64{0, 40, 1},
+		[3]float64{400, 40, 1},  // 10 km/L
+		[3]float64{840, 40, 1},  // 11 km/L
+		[3]float64{1320, 40, 1}, // 12 km/L
+		[3]float64{1560, 40, 1}, // 6 km/L, theft
+		[3]float64{2000, 40, 1}, // 11 km/L
+		[3]float64{2800, 40, 1}, // 20 km/L, missed fill
+	)
+	a.analyze(logs)
+
+	theft := logs[4]
+	if !theft.Flagged || !strings.Contains(theft.FlagReason, "possible fuel theft") {
+		t.Fatalf("6 km/L against 11 km/L was not flagged as theft: %+v", theft)
+	}
+	if theft.BaselineKmPerLiter == nil || *theft.BaselineKmPerLiter != 11 {
+		t.Fatalf("baseline = %v, want the median 11", theft.BaselineKmPerLiter)
+	}
+
+	// The flagged fill stays out of the history: with it the median would be 10.5
+	next := logs[5]
+	if next.Flagged || next.BaselineKmPerLiter == nil || *next.BaselineKmPerLiter != 11 {
+		t.Fatalf("fill after the theft = %+v, want unflagged with baseline 11", next)
+	}
+
+	high := logs[6]
+	if !high.Flagged || !strings.Contains(high.FlagReason, "above the car's baseline") {
+		t.Fatalf("20 km/L was not flagged: %+v", high)
+	}
+}
+
+func TestFuelAnalyzerBaselineWindow(t *testing.T) {
+	a := &fuelAnalyzer{deviationPct: 25, baselineFills: 3}
+	logs := fills(
+		[3]float64{0, 10, 1},
+		[3]float64{80, 10, 1},  // 8 km/L
+		[3]float64{180, 10, 1}, // 10 km/L
+		[3]float64{290, 10, 1}, // 11 km/L
+		[3]float64{410, 10, 1}, // 12 km/L
+		[3]float64{530, 10, 1}, // 12 km/L
+	)
+	a.analyze(logs)
+
+	// Only the last three measured fills (10, 11, 12) make the baseline
+	last := logs[5]
+	if last.BaselineKmPerLiter == nil || *last.BaselineKmPerLiter != 11 {
+		t.Fatalf("baseline = %v, want 11", last.BaselineKmPerLiter)
+	}
+}
+
+func TestFuelAnalyzerOdometerNotIncreasing(t *testing.T) {
+	a := &fuelAnalyzer{deviationPct: 25, baselineFills: 10}
+	logs := fills(
+		[3]float64{5000, 30, 1},
+		[3]float64{5000, 30, 1},
+	)
+	a.analyze(logs)
+
+	log := logs[1]
+	if !log.Flagged || log.FlagReason != "odometer did not increase since the previous full tank" {
+		t.Fatalf("fill = %+v, want flagged for the odometer", log)
+	}
+	if log.DistanceKm == nil || *log.DistanceKm != 0 || log.KmPerLiter != nil {
+		t.Fatalf("fill = %+v, want distance 0 and no km/L", log)
+	}
+}
+
+func TestFuelAnalyzerIsIdempotent(t *testing.T) {
+	a := &fuelAnalyzer{deviationPct: 25, baselineFills: 10}
+	logs := fills(
+		[3]float64{0, 40, 1},
+		[3]float64{400, 40, 1},
+		[3]float64{600, 10, 0},
+		[3]float64{840, 40, 1},
+	)
+	if changed := a.analyze(logs); len(changed) != 2 {
+		t.Fatalf("first run changed %d fills, want 2", len(changed))
+	}
+	if changed := a.analyze(logs); len(changed) != 0 {
+		t.Fatalf("second run changed %d fills, want 0", len(changed))
+	}
+}
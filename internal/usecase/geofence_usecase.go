@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/geo"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type GeofenceUsecase interface {
+	GetAll(params model.GeofenceListParams) ([]model.GeofenceResponse, int64, error)
+	GetByID(id int64) (*model.GeofenceResponse, error)
+	Create(req model.GeofenceRequest) (*model.GeofenceResponse, error)
+	Update(id int64, req model.GeofenceRequest) (*model.GeofenceResponse, error)
+	Delete(id int64) error
+	GetEvents(params model.GeofenceEventListParams) ([]model.GeofenceEventResponse, int64, error)
+}
+
+type geofenceUsecase struct {
+	geofenceRepo repository.GeofenceRepository
+	eventRepo    repository.GeofenceEventRepository
+}
+
+func NewGeofenceUsecase(
+	geofenceRepo repository.GeofenceRepository,
+	eventRepo repository.GeofenceEventRepository,
+) GeofenceUsecase {
+	return &geofenceUsecase{
+		geofenceRepo: geofenceRepo,
+		eventRepo:    eventRepo,
+	}
+}
+
+func (u *geofenceUsecase) GetAll(params model.GeofenceListParams) ([]model.GeofenceResponse, int64, error) {
+	geofences, total, err := u.geofenceRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := []model.GeofenceResponse{}
+	for _, geofence := range geofences {
+		responses = append(responses, u.toResponse(&geofence))
+	}
+	return responses, total, nil
+}
+
+func (u *geofenceUsecase) GetByID(id int64) (*model.GeofenceResponse, error) {
+	geofence, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	response := u.toResponse(geofence)
+	return &response, nil
+}
+
+func (u *geofenceUsecase) Create(req model.GeofenceRequest) (*model.GeofenceResponse, error) {
+	geofence := &entity.Geofence{Active: true}
+	if err := u.applyRequest(geofence, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.geofenceRepo.Create(geofence); err != nil {
+		return nil, err
+	}
+
+	response := u.toResponse(geofence)
+	return &response, nil
+}
+
+func (u *geofenceUsecase) Update(id int64, req model.GeofenceRequest) (*model.GeofenceResponse, error) {
+	geofence, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyRequest(geofence, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.geofenceRepo.Update(geofence); err != nil {
+		return nil, err
+	}
+
+	response := u.toResponse(geofence)
+	return &response, nil
+}
+
+func (u *geofenceUsecase) Delete(id int64) error {
+	if _, err := u.find(id); err != nil {
+		return err
+	}
+	return u.geofenceRepo.Delete(id)
+}
+
+func (u *geofenceUsecase) GetEvents(params model.GeofenceEventListParams) ([]model.GeofenceEventResponse, int64, error) {
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		return nil, 0, errors.New("from must be before to")
+	}
+
+	events, total, err := u.eventRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := []model.GeofenceEventResponse{}
+	for _, event := range events {
+		responses = append(responses, toGeofenceEventResponse(&event))
+	}
+	return responses, total, nil
+}
+
+func (u *geofenceUsecase) find(id int64) (*entity.Geofence, error) {
+	geofence, err := u.geofenceRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("geofence not found")
+		}
+		return nil, err
+	}
+	return geofence, nil
+}
+
+func (u *geofenceUsecase) applyRequest(geofence *entity.Geofence, req model.GeofenceRequest) error {
+	switch req.Type {
+	case entity.GeofenceTypeCircle:
+		if _, err := geo.ParsePoint(req.Geometry); err != nil {
+			return err
+		}
+		if req.RadiusM <= 0 {
+			return errors.New("radius_m is required for a CIRCLE geofence")
+		}
+		geofence.RadiusM = req.RadiusM
+	case entity.GeofenceTypePolygon:
+		if _, err := geo.ParsePolygon(req.Geometry); err != nil {
+			return err
+		}
+		geofence.RadiusM = 0
+	}
+
+	geofence.Name = req.Name
+	geofence.Description = req.Description
+	geofence.Type = req.Type
+	geofence.Geometry = string(req.Geometry)
+	if req.Active != nil {
+		geofence.Active = *req.Active
+	}
+	return nil
+}
+
+func (u *geofenceUsecase) toResponse(geofence *entity.Geofence) model.GeofenceResponse {
+	return model.GeofenceResponse{
+		ID:          geofence.ID,
+		Name:        geofence.Name,
+		Description: geofence.Description,
+		Type:        geofence.Type,
+		Geometry:    json.RawMessage(geofence.Geometry),
+		RadiusM:     geofence.RadiusM,
+		Active:      geofence.Active,
+		CreatedAt:   geofence.CreatedAt,
+		UpdatedAt:   geofence.UpdatedAt,
+	}
+}
+
+// geofenceContains reports whether the point lies inside the geofence. Shapes
+// were validated on save, so a geometry that no longer parses counts as outside.
+func geofenceContains(geofence *entity.Geofence, p geo.Point) bool {
+	switch geofence.Type {
+	case entity.GeofenceTypeCircle:
+		center, err := geo.ParsePoint([]byte(geofence.Geometry))
+		if err != nil {
+			return false
+		}
+		return geo.InCircle(p, center, geofence.RadiusM)
+	case entity.GeofenceTypePolygon:
+		rings, err := geo.ParsePolygon([]byte(geofence.Geometry))
+		if err != nil {
+			return false
+		}
+		return geo.InPolygon(p, rings)
+	}
+	return false
+}
+
+func toGeofenceEventResponse(event *entity.GeofenceEvent) model.GeofenceEventResponse {
+	resp := model.GeofenceEventResponse{
+		ID:         event.ID,
+		GeofenceID: event.GeofenceID,
+		CarID:      event.CarID,
+		Type:       event.Type,
+		Lat:        event.Lat,
+		Lng:        event.Lng,
+		OccurredAt: event.OccurredAt,
+	}
+	if event.Geofence != nil {
+		resp.GeofenceName = event.Geofence.Name
+	}
+	if event.Car != nil {
+		resp.LicensePlate = event.Car.LicensePlate
+	}
+	return resp
+}
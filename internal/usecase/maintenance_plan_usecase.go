@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MaintenancePlanUsecase interface {
+	GetAll(params model.MaintenancePlanListParams) ([]model.MaintenancePlanResponse, int64, error)
+	GetByID(id int64) (*model.MaintenancePlanResponse, error)
+	Create(req model.MaintenancePlanRequest) (*model.MaintenancePlanResponse, error)
+	Update(id int64, req model.MaintenancePlanRequest) (*model.MaintenancePlanResponse, error)
+	Delete(id int64) error
+	GetDue(now time.Time) ([]model.MaintenanceDueItem, error)
+}
+
+type maintenancePlanUsecase struct {
+	planRepo        repository.MaintenancePlanRepository
+	maintenanceRepo repository.MaintenanceRepository
+	carRepo         repository.CarRepository
+	tripRepo        repository.TripRepository
+	odometerRepo    repository.OdometerRepository
+}
+
+func NewMaintenancePlanUsecase(
+	planRepo repository.MaintenancePlanRepository,
+	maintenanceRepo repository.MaintenanceRepository,
+	carRepo repository.CarRepository,
+	tripRepo repository.TripRepository,
+	odometerRepo repository.OdometerRepository,
+) MaintenancePlanUsecase {
+	return &maintenancePlanUsecase{
+		planRepo:        planRepo,
+		maintenanceRepo: maintenanceRepo,
+		carRepo:         carRepo,
+		tripRepo:        tripRepo,
+		odometerRepo:    odometerRepo,
+	}
+}
+
+func (u *maintenancePlanUsecase) GetAll(params model.MaintenancePlanListParams) ([]model.MaintenancePlanResponse, int64, error) {
+	plans, total, err := u.planRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var responses []model.MaintenancePlanResponse
+	for _, plan := range plans {
+		responses = append(responses, u.toResponse(&plan))
+	}
+	return responses, total, nil
+}
+
+func (u *maintenancePlanUsecase) GetByID(id int64) (*model.MaintenancePlanResponse, error) {
+	plan, err := u.planRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("maintenance plan not found")
+		}
+		return nil, err
+	}
+	response := u.toResponse(plan)
+	return &response, nil
+}
+
+func (u *maintenancePlanUsecase) Create(req model.MaintenancePlanRequest) (*model.MaintenancePlanResponse, error) {
+	if err := u.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	plan := &entity.MaintenancePlan{Active: true}
+	u.applyRequest(plan, req)
+
+	if err := u.planRepo.Create(plan); err != nil {
+		return nil, err
+	}
+
+	plan, _ = u.planRepo.FindByID(plan.ID)
+	response := u.toResponse(plan)
+	return &response, nil
+}
+
+func (u *maintenancePlanUsecase) Update(id int64, req model.MaintenancePlanRequest) (*model.MaintenancePlanResponse, error) {
+	plan, err := u.planRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("maintenance plan not found")
+		}
+		return nil, err
+	}
+
+	if err := u.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	u.applyRequest(plan, req)
+	plan.Car = nil
+
+	if err := u.planRepo.Update(plan); err != nil {
+		return nil, err
+	}
+
+	plan, _ = u.planRepo.FindByID(id)
+	response := u.toResponse(plan)
+	return &response, nil
+}
+
+func (u *maintenancePlanUsecase) Delete(id int64) error {
+	_, err := u.planRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("maintenance plan not found")
+		}
+		return err
+	}
+	return u.planRepo.Delete(id)
+}
+
+// GetDue evaluates every active plan against the cars it applies to and
+// returns the overdue and upcoming items, most urgent first.
+func (u *maintenancePlanUsecase) GetDue(now time.Time) ([]model.MaintenanceDueItem, error) {
+	plans, err := u.planRepo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []model.MaintenanceDueItem{}
+	for i := range plans {
+		plan := &plans[i]
+
+		cars, err := u.planCars(plan)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range cars {
+			item := u.evaluate(plan, &cars[j], now)
+			if item.Status != entity.MaintenanceDueOK {
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Status != items[j].Status {
+			return items[i].Status == entity.MaintenanceDueOverdue
+		}
+		return urgency(items[i]) < urgency(items[j])
+	})
+	return items, nil
+}
+
+func (u *maintenancePlanUsecase) planCars(plan *entity.MaintenancePlan) ([]entity.Car, error) {
+	if plan.CarID != nil {
+		car, err := u.carRepo.FindByID(*plan.CarID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []entity.Car{*car}, nil
+	}
+	return u.carRepo.FindByBrandModel(plan.Brand, plan.Model)
+}
+
+func (u *maintenancePlanUsecase) evaluate(plan *entity.MaintenancePlan, car *entity.Car, now time.Time) model.MaintenanceDueItem {
+	item := model.MaintenanceDueItem{
+		PlanID:       plan.ID,
+		PlanName:     plan.Name,
+		CarID:        car.ID,
+		LicensePlate: car.LicensePlate,
+		Brand:        car.Brand,
+		Model:        car.Model,
+	}
+
+	// current_km also follows workshop and manual readings; trips cover cars without one
+	if car.CurrentKm > 0 {
+		currentKm := car.CurrentKm
+		item.CurrentKm = &currentKm
+	} else {
+		item.CurrentKm, _ = u.tripRepo.FindLastEndKm(car.ID, nil)
+	}
+
+	// Without a previous service the interval counts from when the car was registered
+	baseDate := car.CreatedAt
+	baseKm := 0
+	if last, err := u.maintenanceRepo.FindLastForPlan(car.ID, plan); err == nil {
+		serviceDate := last.ServiceDate
+		item.LastServiceDate = &serviceDate
+		baseDate = serviceDate
+		item.LastServiceKm = u.serviceKm(last)
+		if item.LastServiceKm != nil {
+			baseKm = *item.LastServiceKm
+		}
+	}
+
+	item.DueDate, item.DueKm, item.DaysRemaining, item.KmRemaining, item.Status =
+		calculateDue(plan, baseDate, baseKm, item.CurrentKm, now)
+	return item
+}
+
+// serviceKm is the odometer at a service: the workshop reading recorded with
+// the work order, or else the end of the last trip before it.
+func (u *maintenancePlanUsecase) serviceKm(maintenance *entity.Maintenance) *int {
+	if reading, err := u.odometerRepo.FindLatestByMaintenanceID(maintenance.ID); err == nil {
+		km := reading.Km
+		return &km
+	}
+	serviceDate := maintenance.ServiceDate
+	if km, err := u.tripRepo.FindLastEndKm(maintenance.CarID, &serviceDate); err == nil {
+		return km
+	}
+	return nil
+}
+
+// calculateDue applies the plan's km and calendar intervals to the last
+// service baseline. Whichever limit is reached first decides the status.
+func calculateDue(
+	plan *entity.MaintenancePlan,
+	baseDate time.Time,
+	baseKm int,
+	currentKm *int,
+	now time.Time,
+) (dueDate *time.Time, dueKm *int, daysRemaining *int, kmRemaining *int, status string) {
+	status = entity.MaintenanceDueOK
+
+	if plan.IntervalMonths != nil {
+		d := baseDate.AddDate(0, *plan.IntervalMonths, 0)
+		days := int(d.Sub(now).Hours() / 24)
+		dueDate = &d
+		daysRemaining = &days
+
+		if !now.Before(d) {
+			status = entity.MaintenanceDueOverdue
+		} else if days <= plan.WarningDays {
+			status = entity.MaintenanceDueUpcoming
+		}
+	}
+
+	if plan.IntervalKm != nil {
+		k := baseKm + *plan.IntervalKm
+		dueKm = &k
+
+		if currentKm != nil {
+			remaining := k - *currentKm
+			kmRemaining = &remaining
+
+			if remaining <= 0 {
+				status = entity.MaintenanceDueOverdue
+			} else if remaining <= plan.WarningKm && status == entity.MaintenanceDueOK {
+				status = entity.MaintenanceDueUpcoming
+			}
+		}
+	}
+
+	return dueDate, dueKm, daysRemaining, kmRemaining, status
+}
+
+// urgency orders items by how soon they are due; smaller is more urgent.
+func urgency(item model.MaintenanceDueItem) int {
+	score := int(^uint(0) >> 1)
+	if item.DaysRemaining != nil {
+		score = *item.DaysRemaining
+	}
+	// Roughly 100 km per day of normal fleet usage
+	if item.KmRemaining != nil && *item.KmRemaining/100 < score {
+		score = *item.KmRemaining / 100
+	}
+	return score
+}
+
+func (u *maintenancePlanUsecase) validateRequest(req model.MaintenancePlanRequest) error {
+	if req.CarID == nil && (req.Brand == "" || req.Model == "") {
+		return errors.New("either car_id or brand and model is required")
+	}
+	if req.IntervalKm == nil && req.IntervalMonths == nil {
+		return errors.New("at least one of interval_km or interval_months is required")
+	}
+	if req.CarID != nil {
+		if _, err := u.carRepo.FindByID(*req.CarID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *maintenancePlanUsecase) applyRequest(plan *entity.MaintenancePlan, req model.MaintenancePlanRequest) {
+	plan.CarID = req.CarID
+	plan.Brand = req.Brand
+	plan.Model = req.Model
+	plan.Name = req.Name
+	plan.IntervalKm = req.IntervalKm
+	plan.IntervalMonths = req.IntervalMonths
+	plan.WarningKm = req.WarningKm
+	plan.WarningDays = req.WarningDays
+	if req.Active != nil {
+		plan.Active = *req.Active
+	}
+}
+
+func (u *maintenancePlanUsecase) toResponse(plan *entity.MaintenancePlan) model.MaintenancePlanResponse {
+	resp := model.MaintenancePlanResponse{
+		ID:             plan.ID,
+		CarID:          plan.CarID,
+		Brand:          plan.Brand,
+		Model:          plan.Model,
+		Name:           plan.Name,
+		IntervalKm:     plan.IntervalKm,
+		IntervalMonths: plan.IntervalMonths,
+		WarningKm:      plan.WarningKm,
+		WarningDays:    plan.WarningDays,
+		Active:         plan.Active,
+		CreatedAt:      plan.CreatedAt,
+		UpdatedAt:      plan.UpdatedAt,
+	}
+	if plan.Car != nil {
+		resp.Car = &model.CarResponse{
+			ID:           plan.Car.ID,
+			LicensePlate: plan.Car.LicensePlate,
+			Brand:        plan.Car.Brand,
+			Model:        plan.Car.Model,
+		}
+	}
+	return resp
+}
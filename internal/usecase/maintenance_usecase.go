@@ -2,33 +2,68 @@ package usecase
 
 import (
 	"errors"
+	"fleet-monitor/internal/config"
 	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/money"
+	"fleet-monitor/internal/realtime"
 	"fleet-monitor/internal/repository"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// maintenanceTransitions lists the states each work order state may move to.
+var maintenanceTransitions = map[string][]string{
+	entity.MaintenanceStatusScheduled:  {entity.MaintenanceStatusInProgress, entity.MaintenanceStatusCancelled},
+	entity.MaintenanceStatusInProgress: {entity.MaintenanceStatusCompleted, entity.MaintenanceStatusCancelled},
+}
+
 type MaintenanceUsecase interface {
 	GetAll(params model.MaintenanceListParams) ([]model.MaintenanceResponse, int64, error)
 	GetByID(id int64) (*model.MaintenanceResponse, error)
 	Create(req model.MaintenanceRequest) (*model.MaintenanceResponse, error)
 	Update(id int64, req model.MaintenanceRequest) (*model.MaintenanceResponse, error)
 	Delete(id int64) error
+	Start(id int64) (*model.MaintenanceResponse, error)
+	Complete(id int64, req model.MaintenanceCompleteRequest) (*model.MaintenanceResponse, error)
+	Cancel(id int64) (*model.MaintenanceResponse, error)
 }
 
 type maintenanceUsecase struct {
 	maintenanceRepo repository.MaintenanceRepository
 	carRepo         repository.CarRepository
+	workshopRepo    repository.WorkshopRepository
+	odometer        *odometerGuard
+	stock           *stockKeeper
+	webhooks        *WebhookEmitter
+	txManager       helper.TxManager
+	hub             realtime.Hub
 }
 
 func NewMaintenanceUsecase(
 	maintenanceRepo repository.MaintenanceRepository,
 	carRepo repository.CarRepository,
+	workshopRepo repository.WorkshopRepository,
+	partRepo repository.PartRepository,
+	stockMovementRepo repository.StockMovementRepository,
+	odometerRepo repository.OdometerRepository,
+	webhooks *WebhookEmitter,
+	txManager helper.TxManager,
+	hub realtime.Hub,
+	cfg *config.Config,
 ) MaintenanceUsecase {
 	return &maintenanceUsecase{
 		maintenanceRepo: maintenanceRepo,
 		carRepo:         carRepo,
+		workshopRepo:    workshopRepo,
+		odometer:        newOdometerGuard(odometerRepo, carRepo, cfg),
+		stock:           newStockKeeper(partRepo, stockMovementRepo),
+		webhooks:        webhooks,
+		txManager:       txManager,
+		hub:             hub,
 	}
 }
 
@@ -57,31 +92,103 @@ func (u *maintenanceUsecase) GetByID(id int64) (*model.MaintenanceResponse, erro
 	return &response, nil
 }
 
+// Create opens a work order. Only an IN_PROGRESS one takes the car off the
+// road; a SCHEDULED one leaves it available until it is started.
 func (u *maintenanceUsecase) Create(req model.MaintenanceRequest) (*model.MaintenanceResponse, error) {
-	// Verify car exists
-	car, err := u.carRepo.FindByID(req.CarID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("car not found")
-		}
-		return nil, err
+	var car *entity.Car
+	statusChanged := false
+
+	status := req.Status
+	if status == "" {
+		status = entity.MaintenanceStatusScheduled
 	}
 
 	maintenance := &entity.Maintenance{
-		CarID:        req.CarID,
-		ServiceDate:  req.ServiceDate,
-		Description:  req.Description,
-		Cost:         req.Cost,
-		WorkshopName: req.WorkshopName,
+		CarID:       req.CarID,
+		ServiceDate: req.ServiceDate,
+		Description: req.Description,
+		Cost:        req.Cost,
+		PlanID:      req.PlanID,
+		Status:      status,
+	}
+	now := time.Now()
+	switch status {
+	case entity.MaintenanceStatusInProgress:
+		maintenance.StartedAt = &now
+	case entity.MaintenanceStatusCompleted:
+		// A service recorded after the fact finished on its service date
+		completedAt := req.ServiceDate
+		maintenance.CompletedAt = &completedAt
 	}
 
-	if err := u.maintenanceRepo.Create(maintenance); err != nil {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		carRepo := u.carRepo.WithTx(tx)
+
+		var err error
+		car, err = carRepo.FindByIDForUpdate(req.CarID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+		if status == entity.MaintenanceStatusInProgress && car.Status == entity.CarStatusInUse {
+			return errors.New("car is in use")
+		}
+		if err := u.applyWorkshop(tx, maintenance, req); err != nil {
+			return err
+		}
+		items, err := u.stock.resolveItems(tx, req.Items)
+		if err != nil {
+			return err
+		}
+		if err := applyMaintenanceItems(maintenance, items, req.Cost); err != nil {
+			return err
+		}
+
+		if err := u.maintenanceRepo.WithTx(tx).Create(maintenance); err != nil {
+			return err
+		}
+		if err := u.stock.sync(tx, maintenance); err != nil {
+			return err
+		}
+
+		// The workshop's odometer reading goes through the same checks as trips
+		if req.OdometerKm != nil {
+			reading := &entity.OdometerReading{
+				Km:            *req.OdometerKm,
+				Source:        entity.OdometerSourceMaintenance,
+				MaintenanceID: &maintenance.ID,
+				RecordedAt:    req.ServiceDate,
+			}
+			if err := u.odometer.record(tx, car, reading, nil); err != nil {
+				return err
+			}
+		}
+
+		if status == entity.MaintenanceStatusInProgress {
+			statusChanged, err = u.holdCar(tx, car)
+			if err != nil {
+				return err
+			}
+		}
+		return u.webhooks.emit(tx, entity.WebhookEventMaintenanceCreated, model.WebhookMaintenanceData{
+			MaintenanceID: maintenance.ID,
+			CarID:         car.ID,
+			LicensePlate:  car.LicensePlate,
+			ServiceDate:   maintenance.ServiceDate,
+			Description:   maintenance.Description,
+			Cost:          maintenance.Cost,
+			WorkshopName:  maintenance.WorkshopName,
+			PlanID:        maintenance.PlanID,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Optionally update car status to maintenance
-	if car.Status == entity.CarStatusAvailable {
-		u.carRepo.UpdateStatus(req.CarID, entity.CarStatusMaintenance, nil)
+	if statusChanged {
+		u.hub.Publish(newStatusEvent(car, car.Status, entity.CarStatusMaintenance))
 	}
 
 	maintenance, _ = u.maintenanceRepo.FindByID(maintenance.ID)
@@ -90,36 +197,364 @@ func (u *maintenanceUsecase) Create(req model.MaintenanceRequest) (*model.Mainte
 }
 
 func (u *maintenanceUsecase) Update(id int64, req model.MaintenanceRequest) (*model.MaintenanceResponse, error) {
-	maintenance, err := u.maintenanceRepo.FindByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("maintenance not found")
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		maintenanceRepo := u.maintenanceRepo.WithTx(tx)
+
+		maintenance, err := maintenanceRepo.FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("maintenance not found")
+			}
+			return err
+		}
+
+		maintenance.ServiceDate = req.ServiceDate
+		maintenance.Description = req.Description
+		maintenance.PlanID = req.PlanID
+		if err := u.applyWorkshop(tx, maintenance, req); err != nil {
+			return err
+		}
+
+		if req.Items != nil {
+			items, err := u.stock.resolveItems(tx, req.Items)
+			if err != nil {
+				return err
+			}
+			if err := applyMaintenanceItems(maintenance, items, req.Cost); err != nil {
+				return err
+			}
+			if err := maintenanceRepo.ReplaceItems(maintenance.ID, maintenance.Items); err != nil {
+				return err
+			}
+			if err := u.stock.sync(tx, maintenance); err != nil {
+				return err
+			}
+		} else if len(maintenance.Items) == 0 {
+			maintenance.Cost = req.Cost
 		}
+
+		return maintenanceRepo.Update(maintenance)
+	})
+	if err != nil {
 		return nil, err
 	}
+	return u.GetByID(id)
+}
+
+// Delete removes a work order. Deleting one that is in progress releases the
+// car the same way cancelling it would, and its parts go back to stock.
+func (u *maintenanceUsecase) Delete(id int64) error {
+	var car *entity.Car
+	released := false
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		maintenanceRepo := u.maintenanceRepo.WithTx(tx)
+
+		maintenance, err := maintenanceRepo.FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("maintenance not found")
+			}
+			return err
+		}
+		// Parts on a completed order were used and its cost is in the spend report
+		if maintenance.Status == entity.MaintenanceStatusCompleted {
+			return errors.New("completed maintenance cannot be deleted")
+		}
+		car, err = u.carRepo.WithTx(tx).FindByIDForUpdate(maintenance.CarID)
+		if err != nil {
+			return err
+		}
 
-	maintenance.ServiceDate = req.ServiceDate
-	maintenance.Description = req.Description
-	maintenance.Cost = req.Cost
-	maintenance.WorkshopName = req.WorkshopName
+		// Parts issued to the work order go back on the shelf
+		if err := u.stock.returnAll(tx, id, fmt.Sprintf("work order #%d deleted", id)); err != nil {
+			return err
+		}
+		if err := maintenanceRepo.Delete(id); err != nil {
+			return err
+		}
+		if maintenance.Status != entity.MaintenanceStatusInProgress {
+			return nil
+		}
+		released, err = u.releaseCar(tx, car)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-	if err := u.maintenanceRepo.Update(maintenance); err != nil {
+	if released {
+		u.hub.Publish(newStatusEvent(car, car.Status, entity.CarStatusAvailable))
+	}
+	return nil
+}
+
+// Start sends the car to the workshop. It cannot start while the car is out
+// on a trip.
+func (u *maintenanceUsecase) Start(id int64) (*model.MaintenanceResponse, error) {
+	var car *entity.Car
+	statusChanged := false
+
+	err := u.transition(id, entity.MaintenanceStatusInProgress, func(tx *gorm.DB, maintenance *entity.Maintenance, locked *entity.Car) error {
+		car = locked
+		if car.Status == entity.CarStatusInUse {
+			return errors.New("car is in use")
+		}
+
+		now := time.Now()
+		maintenance.Status = entity.MaintenanceStatusInProgress
+		maintenance.StartedAt = &now
+		if err := u.maintenanceRepo.WithTx(tx).Update(maintenance); err != nil {
+			return err
+		}
+
+		var err error
+		statusChanged, err = u.holdCar(tx, car)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	response := u.toResponse(maintenance)
-	return &response, nil
+	if statusChanged {
+		u.hub.Publish(newStatusEvent(car, car.Status, entity.CarStatusMaintenance))
+	}
+	return u.GetByID(id)
 }
 
-func (u *maintenanceUsecase) Delete(id int64) error {
-	_, err := u.maintenanceRepo.FindByID(id)
+// Complete closes a work order. The car becomes available again unless
+// another work order still keeps it in the workshop.
+func (u *maintenanceUsecase) Complete(id int64, req model.MaintenanceCompleteRequest) (*model.MaintenanceResponse, error) {
+	var car *entity.Car
+	released := false
+
+	err := u.transition(id, entity.MaintenanceStatusCompleted, func(tx *gorm.DB, maintenance *entity.Maintenance, locked *entity.Car) error {
+		car = locked
+
+		now := time.Now()
+		maintenance.Status = entity.MaintenanceStatusCompleted
+		maintenance.CompletedAt = &now
+		if req.Cost != nil {
+			if len(maintenance.Items) > 0 {
+				return errors.New("cost is computed from the items")
+			}
+			maintenance.Cost = *req.Cost
+		}
+		if err := u.maintenanceRepo.WithTx(tx).Update(maintenance); err != nil {
+			return err
+		}
+
+		if req.OdometerKm != nil {
+			reading := &entity.OdometerReading{
+				Km:            *req.OdometerKm,
+				Source:        entity.OdometerSourceMaintenance,
+				MaintenanceID: &maintenance.ID,
+				RecordedAt:    now,
+			}
+			if err := u.odometer.record(tx, car, reading, nil); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		released, err = u.releaseCar(tx, car)
+		return err
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("maintenance not found")
+		return nil, err
+	}
+
+	if released {
+		u.hub.Publish(newStatusEvent(car, car.Status, entity.CarStatusAvailable))
+	}
+	return u.GetByID(id)
+}
+
+// Cancel drops a work order that has not been completed, releasing the car
+// if it was in progress and returning its parts to stock.
+func (u *maintenanceUsecase) Cancel(id int64) (*model.MaintenanceResponse, error) {
+	var car *entity.Car
+	released := false
+
+	err := u.transition(id, entity.MaintenanceStatusCancelled, func(tx *gorm.DB, maintenance *entity.Maintenance, locked *entity.Car) error {
+		car = locked
+		wasInProgress := maintenance.Status == entity.MaintenanceStatusInProgress
+
+		now := time.Now()
+		maintenance.Status = entity.MaintenanceStatusCancelled
+		maintenance.CancelledAt = &now
+		if err := u.maintenanceRepo.WithTx(tx).Update(maintenance); err != nil {
+			return err
 		}
+		if err := u.stock.sync(tx, maintenance); err != nil {
+			return err
+		}
+		if !wasInProgress {
+			return nil
+		}
+
+		var err error
+		released, err = u.releaseCar(tx, car)
 		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if released {
+		u.hub.Publish(newStatusEvent(car, car.Status, entity.CarStatusAvailable))
 	}
-	return u.maintenanceRepo.Delete(id)
+	return u.GetByID(id)
+}
+
+// transition locks the work order and then its car, checks that the work
+// order may move to status and hands both to apply, which updates and saves
+// the work order.
+func (u *maintenanceUsecase) transition(id int64, status string, apply func(tx *gorm.DB, maintenance *entity.Maintenance, car *entity.Car) error) error {
+	return u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		maintenance, err := u.maintenanceRepo.WithTx(tx).FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("maintenance not found")
+			}
+			return err
+		}
+		if err := checkMaintenanceTransition(maintenance, status); err != nil {
+			return err
+		}
+
+		car, err := u.carRepo.WithTx(tx).FindByIDForUpdate(maintenance.CarID)
+		if err != nil {
+			return err
+		}
+
+		return apply(tx, maintenance, car)
+	})
+}
+
+// applyWorkshop links the work order to the requested workshop. A free-text
+// name is matched ignoring case and spacing, and added as a new workshop if
+// nothing matches.
+func (u *maintenanceUsecase) applyWorkshop(tx *gorm.DB, maintenance *entity.Maintenance, req model.MaintenanceRequest) error {
+	workshopRepo := u.workshopRepo.WithTx(tx)
+
+	var workshop *entity.Workshop
+	var err error
+	if req.WorkshopID != nil {
+		workshop, err = workshopRepo.FindByID(*req.WorkshopID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("workshop not found")
+			}
+			return err
+		}
+	} else if name := normalizeWorkshopName(req.WorkshopName); name != "" {
+		workshop, err = workshopRepo.FindOrCreateByName(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	maintenance.WorkshopID = nil
+	maintenance.WorkshopName = ""
+	if workshop != nil {
+		maintenance.WorkshopID = &workshop.ID
+		maintenance.WorkshopName = workshop.Name
+	}
+	return nil
+}
+
+// holdCar puts an available car into maintenance and reports whether its
+// status changed.
+func (u *maintenanceUsecase) holdCar(tx *gorm.DB, car *entity.Car) (bool, error) {
+	if car.Status != entity.CarStatusAvailable {
+		return false, nil
+	}
+	if err := u.carRepo.WithTx(tx).UpdateStatus(car.ID, entity.CarStatusMaintenance, nil); err != nil {
+		return false, err
+	}
+	if err := u.webhooks.carStatusChanged(tx, car, car.Status, entity.CarStatusMaintenance, carStatusReasonMaintenance); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseCar makes a car in maintenance available again once no work order
+// is in progress for it, and reports whether its status changed. The work
+// order being closed must already be saved.
+func (u *maintenanceUsecase) releaseCar(tx *gorm.DB, car *entity.Car) (bool, error) {
+	if car.Status != entity.CarStatusMaintenance {
+		return false, nil
+	}
+	open, err := u.maintenanceRepo.WithTx(tx).CountInProgressByCarID(car.ID)
+	if err != nil || open > 0 {
+		return false, err
+	}
+	if err := u.carRepo.WithTx(tx).UpdateStatus(car.ID, entity.CarStatusAvailable, nil); err != nil {
+		return false, err
+	}
+	if err := u.webhooks.carStatusChanged(tx, car, car.Status, entity.CarStatusAvailable, carStatusReasonMaintenance); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// applyMaintenanceItems prices the requested items and derives the work
+// order's cost breakdown from them, in whole cents. Without items the cost
+// stays as entered and there is no breakdown.
+func applyMaintenanceItems(maintenance *entity.Maintenance, reqItems []model.MaintenanceItemRequest, cost float64) error {
+	items := make([]entity.MaintenanceItem, 0, len(reqItems))
+	costs := map[string]int64{}
+	var taxTotal int64
+	for i, req := range reqItems {
+		unitPrice, err := money.Cents(req.UnitPrice)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i+1, err)
+		}
+		subtotal, tax, err := money.Line(req.Quantity, req.UnitPrice, req.TaxRate)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i+1, err)
+		}
+		items = append(items, entity.MaintenanceItem{
+			Type:        req.Type,
+			PartID:      req.PartID,
+			PartNumber:  req.PartNumber,
+			Description: req.Description,
+			Quantity:    money.RoundQuantity(req.Quantity),
+			UnitPrice:   money.FromCents(unitPrice),
+			TaxRate:     money.RoundRate(req.TaxRate),
+			Subtotal:    money.FromCents(subtotal),
+			TaxAmount:   money.FromCents(tax),
+			Total:       money.FromCents(subtotal + tax),
+		})
+		costs[req.Type] += subtotal
+		taxTotal += tax
+	}
+
+	maintenance.Items = items
+	maintenance.PartsCost = money.FromCents(costs[entity.MaintenanceItemTypePart])
+	maintenance.LabourCost = money.FromCents(costs[entity.MaintenanceItemTypeLabour])
+	maintenance.OtherCost = money.FromCents(costs[entity.MaintenanceItemTypeOther])
+	maintenance.TaxAmount = money.FromCents(taxTotal)
+	if len(items) == 0 {
+		maintenance.Cost = cost
+		return nil
+	}
+	total := taxTotal
+	for _, subtotal := range costs {
+		total += subtotal
+	}
+	maintenance.Cost = money.FromCents(total)
+	return nil
+}
+
+func checkMaintenanceTransition(maintenance *entity.Maintenance, to string) error {
+	for _, next := range maintenanceTransitions[maintenance.Status] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot move maintenance from %s to %s", maintenance.Status, to)
 }
 
 func (u *maintenanceUsecase) toResponse(m *entity.Maintenance) model.MaintenanceResponse {
@@ -129,9 +564,39 @@ func (u *maintenanceUsecase) toResponse(m *entity.Maintenance) model.Maintenance
 		ServiceDate:  m.ServiceDate,
 		Description:  m.Description,
 		Cost:         m.Cost,
+		PartsCost:    m.PartsCost,
+		LabourCost:   m.LabourCost,
+		OtherCost:    m.OtherCost,
+		TaxAmount:    m.TaxAmount,
+		WorkshopID:   m.WorkshopID,
 		WorkshopName: m.WorkshopName,
+		PlanID:       m.PlanID,
+		Status:       m.Status,
+		StartedAt:    m.StartedAt,
+		CompletedAt:  m.CompletedAt,
+		CancelledAt:  m.CancelledAt,
+		Items:        make([]model.MaintenanceItemResponse, 0, len(m.Items)),
 		CreatedAt:    m.CreatedAt,
 	}
+	// Follow renames of the workshop
+	if m.Workshop != nil {
+		resp.WorkshopName = m.Workshop.Name
+	}
+	for _, item := range m.Items {
+		resp.Items = append(resp.Items, model.MaintenanceItemResponse{
+			ID:          item.ID,
+			Type:        item.Type,
+			PartID:      item.PartID,
+			PartNumber:  item.PartNumber,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TaxRate:     item.TaxRate,
+			Subtotal:    item.Subtotal,
+			TaxAmount:   item.TaxAmount,
+			Total:       item.Total,
+		})
+	}
 	if m.Car != nil {
 		resp.Car = &model.CarResponse{
 			ID:           m.Car.ID,
@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/config"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/notify"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Delivery tuning. A batch is leased for notificationLease so another
+// dispatcher does not pick it up while it is being sent; failed attempts are
+// retried after notificationRetryBase, doubling up to notificationRetryMax.
+const (
+	notificationBatchSize = 50
+	notificationLease     = 5 * time.Minute
+	notificationRetryBase = time.Minute
+	notificationRetryMax  = time.Hour
+)
+
+type NotificationUsecase interface {
+	GetSubscriptions(userID int64) ([]model.NotificationSubscriptionResponse, error)
+	CreateSubscription(userID int64, req model.NotificationSubscriptionRequest) (*model.NotificationSubscriptionResponse, error)
+	UpdateSubscription(id, userID int64, req model.NotificationSubscriptionRequest) (*model.NotificationSubscriptionResponse, error)
+	DeleteSubscription(id, userID int64) error
+	GetNotifications(params model.NotificationListParams) ([]model.NotificationResponse, int64, error)
+	QueueDocumentExpiry(now time.Time) error
+	Dispatch(now time.Time) error
+}
+
+type notificationUsecase struct {
+	subscriptionRepo repository.NotificationSubscriptionRepository
+	notificationRepo repository.NotificationRepository
+	documentRepo     repository.CarDocumentRepository
+	outbox           *NotificationOutbox
+	notifiers        map[string]notify.Notifier
+	txManager        helper.TxManager
+	maxAttempts      int
+}
+
+// NewNotificationUsecase delivers through notifiers, keyed by channel. A
+// channel without a notifier is treated as not configured.
+func NewNotificationUsecase(
+	subscriptionRepo repository.NotificationSubscriptionRepository,
+	notificationRepo repository.NotificationRepository,
+	documentRepo repository.CarDocumentRepository,
+	outbox *NotificationOutbox,
+	notifiers map[string]notify.Notifier,
+	txManager helper.TxManager,
+	cfg *config.Config,
+) NotificationUsecase {
+	return &notificationUsecase{
+		subscriptionRepo: subscriptionRepo,
+		notificationRepo: notificationRepo,
+		documentRepo:     documentRepo,
+		outbox:           outbox,
+		notifiers:        notifiers,
+		txManager:        txManager,
+		maxAttempts:      cfg.NotificationMaxAttempts,
+	}
+}
+
+func (u *notificationUsecase) GetSubscriptions(userID int64) ([]model.NotificationSubscriptionResponse, error) {
+	subscriptions, err := u.subscriptionRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.NotificationSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, toNotificationSubscriptionResponse(&subscription))
+	}
+	return responses, nil
+}
+
+func (u *notificationUsecase) CreateSubscription(userID int64, req model.NotificationSubscriptionRequest) (*model.NotificationSubscriptionResponse, error) {
+	subscription := &entity.NotificationSubscription{UserID: userID, Active: true}
+	if err := u.applySubscriptionRequest(subscription, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.subscriptionRepo.Create(subscription); err != nil {
+		return nil, err
+	}
+
+	response := toNotificationSubscriptionResponse(subscription)
+	return &response, nil
+}
+
+func (u *notificationUsecase) UpdateSubscription(id, userID int64, req model.NotificationSubscriptionRequest) (*model.NotificationSubscriptionResponse, error) {
+	subscription, err := u.findSubscription(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applySubscriptionRequest(subscription, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.subscriptionRepo.Update(subscription); err != nil {
+		return nil, err
+	}
+
+	response := toNotificationSubscriptionResponse(subscription)
+	return &response, nil
+}
+
+func (u *notificationUsecase) DeleteSubscription(id, userID int64) error {
+	if _, err := u.findSubscription(id, userID); err != nil {
+		return err
+	}
+	return u.subscriptionRepo.Delete(id)
+}
+
+func (u *notificationUsecase) GetNotifications(params model.NotificationListParams) ([]model.NotificationResponse, int64, error) {
+	notifications, total, err := u.notificationRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		responses = append(responses, toNotificationResponse(&notification))
+	}
+	return responses, total, nil
+}
+
+// QueueDocumentExpiry notifies about documents that expire within the
+// dashboard warning window and again once they have expired. Each document
+// and expiry date is only announced once per stage, so it is safe to call
+// repeatedly.
+func (u *notificationUsecase) QueueDocumentExpiry(now time.Time) error {
+	documents, err := u.documentRepo.FindExpiringBefore(now.Add(documentExpiryWindow))
+	if err != nil {
+		return err
+	}
+
+	return u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		for _, document := range documents {
+			plate := fmt.Sprintf("car #%d", document.CarID)
+			if document.Car != nil {
+				plate = document.Car.LicensePlate
+			}
+			expiry := document.ExpiryDate.Format("2006-01-02")
+
+			stage, verb := "expiring", "expires"
+			if !document.ExpiryDate.After(now) {
+				stage, verb = "expired", "expired"
+			}
+			event := notificationEvent{
+				Type:      entity.NotificationEventDocumentExpiry,
+				Subject:   fmt.Sprintf("%s for %s %s on %s", document.Type, plate, verb, expiry),
+				Body:      fmt.Sprintf("Document %s (%s) for %s %s on %s.", document.Type, document.Number, plate, verb, expiry),
+				DedupeKey: fmt.Sprintf("document:%d:%s:%s", document.ID, expiry, stage),
+			}
+			if err := u.outbox.enqueue(tx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Dispatch delivers the notifications that are due. Failures are recorded on
+// the notification and retried with backoff until maxAttempts is reached;
+// they are never returned to the caller that queued them.
+func (u *notificationUsecase) Dispatch(now time.Time) error {
+	var due []entity.Notification
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		notificationRepo := u.notificationRepo.WithTx(tx)
+
+		var err error
+		due, err = notificationRepo.FindDueForUpdate(now, notificationBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range due {
+			due[i].NextAttemptAt = now.Add(notificationLease)
+			if err := notificationRepo.Update(&due[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		u.deliver(&due[i], time.Now())
+		if err := u.notificationRepo.Update(&due[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *notificationUsecase) deliver(notification *entity.Notification, now time.Time) {
+	notification.Attempts++
+
+	var err error
+	notifier, ok := u.notifiers[notification.Channel]
+	if ok {
+		err = notify.Send(notifier, notification.Target, notify.Message{
+			Event:   notification.EventType,
+			Subject: notification.Subject,
+			Body:    notification.Body,
+		})
+	} else {
+		err = fmt.Errorf("%s channel is not configured", notification.Channel)
+	}
+
+	if err == nil {
+		notification.Status = entity.NotificationStatusSent
+		notification.SentAt = &now
+		notification.LastError = ""
+		return
+	}
+
+	notification.LastError = err.Error()
+	if notification.Attempts >= u.maxAttempts {
+		notification.Status = entity.NotificationStatusFailed
+		return
+	}
+	notification.NextAttemptAt = now.Add(helper.Backoff(notification.Attempts, notificationRetryBase, notificationRetryMax))
+}
+
+func (u *notificationUsecase) findSubscription(id, userID int64) (*entity.NotificationSubscription, error) {
+	subscription, err := u.subscriptionRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("subscription not found")
+		}
+		return nil, err
+	}
+	// Subscriptions are personal; hide other users' ones entirely
+	if subscription.UserID != userID {
+		return nil, errors.New("subscription not found")
+	}
+	return subscription, nil
+}
+
+func (u *notificationUsecase) applySubscriptionRequest(subscription *entity.NotificationSubscription, req model.NotificationSubscriptionRequest) error {
+	if _, ok := u.notifiers[req.Channel]; !ok {
+		return fmt.Errorf("%s notifications are not configured", req.Channel)
+	}
+
+	target := strings.TrimSpace(req.Target)
+	switch req.Channel {
+	case notify.ChannelEmail:
+		address, err := mail.ParseAddress(target)
+		if err != nil {
+			return errors.New("target must be a valid email address")
+		}
+		target = address.Address
+	case notify.ChannelWebhook:
+		parsed, err := url.Parse(target)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errors.New("target must be an http or https URL")
+		}
+		if !notify.PublicHost(parsed.Hostname()) {
+			return errors.New("target must not be a private or local address")
+		}
+	}
+
+	subscription.Channel = req.Channel
+	subscription.Target = target
+	subscription.EventTypes = strings.Join(req.EventTypes, ",")
+	subscription.MinSeverity = req.MinSeverity
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	return nil
+}
+
+// notificationEvent is something people may want to hear about. Severity is
+// only set for alerts. A non-empty DedupeKey makes enqueue skip subscriptions
+// that were already sent the same key.
+type notificationEvent struct {
+	Type      string
+	Severity  string
+	Subject   string
+	Body      string
+	DedupeKey string
+}
+
+// alertSeverityRank orders severities for the subscription threshold.
+var alertSeverityRank = map[string]int{
+	entity.AlertSeverityInfo:     0,
+	entity.AlertSeverityWarning:  1,
+	entity.AlertSeverityCritical: 2,
+}
+
+// NotificationOutbox queues an event for every subscription that wants it.
+// It runs inside the caller's transaction, so the notifications are only
+// kept when the change that caused them commits; delivery happens later in
+// Dispatch and cannot fail the caller.
+type NotificationOutbox struct {
+	subscriptionRepo repository.NotificationSubscriptionRepository
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationOutbox(subscriptionRepo repository.NotificationSubscriptionRepository, notificationRepo repository.NotificationRepository) *NotificationOutbox {
+	return &NotificationOutbox{
+		subscriptionRepo: subscriptionRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (o *NotificationOutbox) enqueue(tx *gorm.DB, event notificationEvent) error {
+	subscriptions, err := o.subscriptionRepo.WithTx(tx).FindActiveByEvent(event.Type)
+	if err != nil {
+		return err
+	}
+
+	notificationRepo := o.notificationRepo.WithTx(tx)
+	now := time.Now()
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		if event.Severity != "" && subscription.MinSeverity != "" &&
+			alertSeverityRank[event.Severity] < alertSeverityRank[subscription.MinSeverity] {
+			continue
+		}
+
+		notification := &entity.Notification{
+			SubscriptionID: &subscription.ID,
+			UserID:         subscription.UserID,
+			Channel:        subscription.Channel,
+			Target:         subscription.Target,
+			EventType:      event.Type,
+			Subject:        event.Subject,
+			Body:           event.Body,
+			Status:         entity.NotificationStatusPending,
+			NextAttemptAt:  now,
+		}
+		if event.DedupeKey != "" {
+			key := event.DedupeKey
+			notification.DedupeKey = &key
+		}
+		if _, err := notificationRepo.CreateIfAbsent(notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toNotificationSubscriptionResponse(subscription *entity.NotificationSubscription) model.NotificationSubscriptionResponse {
+	eventTypes := []string{}
+	if subscription.EventTypes != "" {
+		eventTypes = strings.Split(subscription.EventTypes, ",")
+	}
+	return model.NotificationSubscriptionResponse{
+		ID:          subscription.ID,
+		UserID:      subscription.UserID,
+		Channel:     subscription.Channel,
+		Target:      subscription.Target,
+		EventTypes:  eventTypes,
+		MinSeverity: subscription.MinSeverity,
+		Active:      subscription.Active,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+func toNotificationResponse(notification *entity.Notification) model.NotificationResponse {
+	return model.NotificationResponse{
+		ID:             notification.ID,
+		SubscriptionID: notification.SubscriptionID,
+		Channel:        notification.Channel,
+		Target:         notification.Target,
+		EventType:      notification.EventType,
+		Subject:        notification.Subject,
+		Body:           notification.Body,
+		Status:         notification.Status,
+		Attempts:       notification.Attempts,
+		NextAttemptAt:  notification.NextAttemptAt,
+		LastError:      notification.LastError,
+		SentAt:         notification.SentAt,
+		CreatedAt:      notification.CreatedAt,
+	}
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/config"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OdometerUsecase interface {
+	GetAll(params model.OdometerListParams) ([]model.OdometerReadingResponse, int64, error)
+	Record(carID int64, req model.OdometerReadingRequest, userID int64) (*model.OdometerReadingResponse, error)
+	Review(id int64, req model.OdometerReviewRequest, userID int64) (*model.OdometerReadingResponse, error)
+}
+
+type odometerUsecase struct {
+	odometerRepo repository.OdometerRepository
+	carRepo      repository.CarRepository
+	guard        *odometerGuard
+	txManager    helper.TxManager
+}
+
+func NewOdometerUsecase(
+	odometerRepo repository.OdometerRepository,
+	carRepo repository.CarRepository,
+	txManager helper.TxManager,
+	cfg *config.Config,
+) OdometerUsecase {
+	return &odometerUsecase{
+		odometerRepo: odometerRepo,
+		carRepo:      carRepo,
+		guard:        newOdometerGuard(odometerRepo, carRepo, cfg),
+		txManager:    txManager,
+	}
+}
+
+func (u *odometerUsecase) GetAll(params model.OdometerListParams) ([]model.OdometerReadingResponse, int64, error) {
+	if params.CarID > 0 {
+		if _, err := u.carRepo.FindByID(params.CarID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, 0, errors.New("car not found")
+			}
+			return nil, 0, err
+		}
+	}
+
+	readings, total, err := u.odometerRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.OdometerReadingResponse, 0, len(readings))
+	for _, reading := range readings {
+		responses = append(responses, toOdometerReadingResponse(&reading))
+	}
+	return responses, total, nil
+}
+
+func (u *odometerUsecase) Record(carID int64, req model.OdometerReadingRequest, userID int64) (*model.OdometerReadingResponse, error) {
+	reading := &entity.OdometerReading{
+		CarID:  carID,
+		Km:     req.Km,
+		Source: entity.OdometerSourceManual,
+	}
+	if req.RecordedAt != nil {
+		reading.RecordedAt = *req.RecordedAt
+	}
+	if userID > 0 {
+		reading.RecordedBy = &userID
+	}
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		car, err := u.carRepo.WithTx(tx).FindByIDForUpdate(carID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+		return u.guard.record(tx, car, reading, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reading, _ = u.odometerRepo.FindByID(reading.ID)
+	response := toOdometerReadingResponse(reading)
+	return &response, nil
+}
+
+// Review accepts or rejects a flagged reading. An accepted reading becomes
+// the car's current_km when it is the car's latest reading or above the
+// current value; a rejected one never touches the car.
+func (u *odometerUsecase) Review(id int64, req model.OdometerReviewRequest, userID int64) (*model.OdometerReadingResponse, error) {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		odometerRepo := u.odometerRepo.WithTx(tx)
+
+		reading, err := odometerRepo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("odometer reading not found")
+			}
+			return err
+		}
+		// Lock the car before the reading, the same order record uses
+		car, err := u.carRepo.WithTx(tx).FindByIDForUpdate(reading.CarID)
+		if err != nil {
+			return err
+		}
+		reading, err = odometerRepo.FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if !reading.Flagged {
+			return errors.New("odometer reading is not flagged")
+		}
+		if reading.ReviewedAt != nil {
+			return errors.New("odometer reading already reviewed")
+		}
+
+		now := time.Now()
+		reading.ReviewedAt = &now
+		reading.ReviewNote = req.Note
+		reading.Accepted = req.Accept
+		if userID > 0 {
+			reading.ReviewedBy = &userID
+		}
+		if err := odometerRepo.Update(reading); err != nil {
+			return err
+		}
+		if !*req.Accept || reading.Km == car.CurrentKm {
+			return nil
+		}
+
+		latest, err := odometerRepo.FindLatestByCarID(car.ID)
+		if err != nil {
+			return err
+		}
+		if latest.ID != reading.ID && reading.Km < car.CurrentKm {
+			return nil
+		}
+		return u.carRepo.WithTx(tx).UpdateCurrentKm(car.ID, reading.Km)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reading, err := u.odometerRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toOdometerReadingResponse(reading)
+	return &response, nil
+}
+
+// odometerGuard checks a new reading against the car's last known odometer and
+// keeps cars.current_km up to date. It runs inside the caller's transaction;
+// the car must already be locked.
+type odometerGuard struct {
+	odometerRepo repository.OdometerRepository
+	carRepo      repository.CarRepository
+	toleranceKm  int
+	maxJumpKm    int
+	maxTripKm    int
+}
+
+func newOdometerGuard(odometerRepo repository.OdometerRepository, carRepo repository.CarRepository, cfg *config.Config) *odometerGuard {
+	return &odometerGuard{
+		odometerRepo: odometerRepo,
+		carRepo:      carRepo,
+		toleranceKm:  cfg.OdometerToleranceKm,
+		maxJumpKm:    cfg.OdometerMaxJumpKm,
+		maxTripKm:    cfg.OdometerMaxTripKm,
+	}
+}
+
+// record stores the reading, flagging it when it does not fit the car's
+// history. startKm is the trip's start reading and is only used at checkin,
+// where an end_km below it is rejected outright. Manual readings are trusted
+// and always replace current_km; other sources only move it forward, and a
+// flagged reading waits for Review before it moves anything.
+func (g *odometerGuard) record(tx *gorm.DB, car *entity.Car, reading *entity.OdometerReading, startKm *int) error {
+	if reading.RecordedAt.IsZero() {
+		reading.RecordedAt = time.Now()
+	}
+	reading.CarID = car.ID
+	reading.PreviousKm = car.CurrentKm
+
+	if startKm != nil && reading.Km < *startKm {
+		return errors.New("end_km cannot be lower than start_km")
+	}
+
+	if reading.Source != entity.OdometerSourceManual {
+		reading.FlagReason = g.check(car.CurrentKm, reading.Km, startKm)
+		reading.Flagged = reading.FlagReason != ""
+	}
+
+	if err := g.odometerRepo.WithTx(tx).Create(reading); err != nil {
+		return err
+	}
+
+	if reading.Flagged {
+		return nil
+	}
+	if reading.Km > car.CurrentKm || reading.Source == entity.OdometerSourceManual {
+		if err := g.carRepo.WithTx(tx).UpdateCurrentKm(car.ID, reading.Km); err != nil {
+			return err
+		}
+		car.CurrentKm = reading.Km
+	}
+	return nil
+}
+
+// check returns why a reading looks wrong, or an empty string when it is fine.
+func (g *odometerGuard) check(currentKm, km int, startKm *int) string {
+	if km < currentKm-g.toleranceKm {
+		return fmt.Sprintf("reading is %d km below the last known odometer (%d km)", currentKm-km, currentKm)
+	}
+	if startKm != nil {
+		if km-*startKm > g.maxTripKm {
+			return fmt.Sprintf("trip distance of %d km exceeds %d km", km-*startKm, g.maxTripKm)
+		}
+		return ""
+	}
+	// A car without history has nothing to compare the first reading against
+	if currentKm > 0 && km-currentKm > g.maxJumpKm {
+		return fmt.Sprintf("reading is %d km above the last known odometer (%d km)", km-currentKm, currentKm)
+	}
+	return ""
+}
+
+func toOdometerReadingResponse(reading *entity.OdometerReading) model.OdometerReadingResponse {
+	resp := model.OdometerReadingResponse{
+		ID:            reading.ID,
+		CarID:         reading.CarID,
+		Km:            reading.Km,
+		PreviousKm:    reading.PreviousKm,
+		Source:        reading.Source,
+		TripID:        reading.TripID,
+		MaintenanceID: reading.MaintenanceID,
+		FuelLogID:     reading.FuelLogID,
+		RecordedBy:    reading.RecordedBy,
+		RecordedAt:    reading.RecordedAt,
+		Flagged:       reading.Flagged,
+		FlagReason:    reading.FlagReason,
+		ReviewedAt:    reading.ReviewedAt,
+		ReviewedBy:    reading.ReviewedBy,
+		ReviewNote:    reading.ReviewNote,
+		Accepted:      reading.Accepted,
+		CreatedAt:     reading.CreatedAt,
+	}
+	if reading.Car != nil {
+		resp.LicensePlate = reading.Car.LicensePlate
+	}
+	return resp
+}
@@ -0,0 +1,469 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/money"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type PartUsecase interface {
+	GetAll(params model.PartListParams) ([]model.PartResponse, int64, error)
+	GetByID(id int64) (*model.PartResponse, error)
+	Create(req model.PartRequest) (*model.PartResponse, error)
+	Update(id int64, req model.PartRequest) (*model.PartResponse, error)
+	Delete(id int64) error
+	Receive(id int64, req model.StockReceiveRequest, userID int64) (*model.StockMovementResponse, error)
+	Adjust(id int64, req model.StockAdjustRequest, userID int64) (*model.StockMovementResponse, error)
+	GetMovements(params model.StockMovementListParams) ([]model.StockMovementResponse, int64, error)
+	GetLowStock() ([]model.LowStockResponse, error)
+}
+
+type partUsecase struct {
+	partRepo          repository.PartRepository
+	stockMovementRepo repository.StockMovementRepository
+	stock             *stockKeeper
+	txManager         helper.TxManager
+}
+
+func NewPartUsecase(
+	partRepo repository.PartRepository,
+	stockMovementRepo repository.StockMovementRepository,
+	txManager helper.TxManager,
+) PartUsecase {
+	return &partUsecase{
+		partRepo:          partRepo,
+		stockMovementRepo: stockMovementRepo,
+		stock:             newStockKeeper(partRepo, stockMovementRepo),
+		txManager:         txManager,
+	}
+}
+
+func (u *partUsecase) GetAll(params model.PartListParams) ([]model.PartResponse, int64, error) {
+	parts, total, err := u.partRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.PartResponse, 0, len(parts))
+	for _, part := range parts {
+		responses = append(responses, toPartResponse(&part))
+	}
+	return responses, total, nil
+}
+
+func (u *partUsecase) GetByID(id int64) (*model.PartResponse, error) {
+	part, err := u.findPart(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toPartResponse(part)
+	return &response, nil
+}
+
+// Create adds a part to the catalog with no stock; stock arrives through
+// Receive.
+func (u *partUsecase) Create(req model.PartRequest) (*model.PartResponse, error) {
+	part := &entity.Part{}
+	if err := u.applyRequest(part, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.partRepo.Create(part); err != nil {
+		return nil, err
+	}
+
+	response := toPartResponse(part)
+	return &response, nil
+}
+
+func (u *partUsecase) Update(id int64, req model.PartRequest) (*model.PartResponse, error) {
+	part, err := u.findPart(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyRequest(part, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.partRepo.Update(part); err != nil {
+		return nil, err
+	}
+	return u.GetByID(id)
+}
+
+// Delete removes a part that never moved. Parts with history stay so that
+// the movement log keeps adding up.
+func (u *partUsecase) Delete(id int64) error {
+	if _, err := u.findPart(id); err != nil {
+		return err
+	}
+
+	count, err := u.stockMovementRepo.CountByPartID(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("part has stock movements")
+	}
+	return u.partRepo.Delete(id)
+}
+
+func (u *partUsecase) Receive(id int64, req model.StockReceiveRequest, userID int64) (*model.StockMovementResponse, error) {
+	var part *entity.Part
+	movement := &entity.StockMovement{
+		Type:      entity.StockMovementReceive,
+		Quantity:  req.Quantity,
+		Reference: strings.TrimSpace(req.Reference),
+		Note:      req.Note,
+		CreatedBy: &userID,
+	}
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		part, err = u.findPartForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.UnitCost != nil {
+			unitCost, err := money.Cents(*req.UnitCost)
+			if err != nil {
+				return fmt.Errorf("unit_cost: %w", err)
+			}
+			part.UnitCost = money.FromCents(unitCost)
+			if err := u.partRepo.WithTx(tx).Update(part); err != nil {
+				return err
+			}
+		}
+		unitCost := part.UnitCost
+		movement.UnitCost = &unitCost
+
+		return u.stock.move(tx, part, movement)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toStockMovementResponse(movement)
+	response.PartNumber = part.PartNumber
+	return &response, nil
+}
+
+func (u *partUsecase) Adjust(id int64, req model.StockAdjustRequest, userID int64) (*model.StockMovementResponse, error) {
+	if money.RoundQuantity(req.Quantity) == 0 {
+		return nil, errors.New("quantity must not be zero")
+	}
+	var part *entity.Part
+	movement := &entity.StockMovement{
+		Type:      entity.StockMovementAdjust,
+		Quantity:  req.Quantity,
+		Note:      req.Note,
+		CreatedBy: &userID,
+	}
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		part, err = u.findPartForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+		return u.stock.move(tx, part, movement)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toStockMovementResponse(movement)
+	response.PartNumber = part.PartNumber
+	return &response, nil
+}
+
+func (u *partUsecase) GetMovements(params model.StockMovementListParams) ([]model.StockMovementResponse, int64, error) {
+	movements, total, err := u.stockMovementRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.StockMovementResponse, 0, len(movements))
+	for _, movement := range movements {
+		responses = append(responses, toStockMovementResponse(&movement))
+	}
+	return responses, total, nil
+}
+
+// GetLowStock lists what purchasing should order, with the quantity and the
+// cost at the last known unit cost.
+func (u *partUsecase) GetLowStock() ([]model.LowStockResponse, error) {
+	parts, err := u.partRepo.FindLowStock()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.LowStockResponse, 0, len(parts))
+	for _, part := range parts {
+		quantity := part.ReorderQuantity
+		if shortfall := money.RoundQuantity(part.ReorderLevel - part.Stock); shortfall > quantity {
+			quantity = shortfall
+		}
+		cost, err := money.Amount(quantity, part.UnitCost)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, model.LowStockResponse{
+			PartResponse:      toPartResponse(&part),
+			SuggestedQuantity: quantity,
+			SuggestedCost:     money.FromCents(cost),
+		})
+	}
+	return responses, nil
+}
+
+func (u *partUsecase) findPart(id int64) (*entity.Part, error) {
+	part, err := u.partRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("part not found")
+		}
+		return nil, err
+	}
+	return part, nil
+}
+
+func (u *partUsecase) findPartForUpdate(tx *gorm.DB, id int64) (*entity.Part, error) {
+	part, err := u.partRepo.WithTx(tx).FindByIDForUpdate(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("part not found")
+		}
+		return nil, err
+	}
+	return part, nil
+}
+
+func (u *partUsecase) applyRequest(part *entity.Part, req model.PartRequest) error {
+	partNumber := strings.ToUpper(strings.TrimSpace(req.PartNumber))
+	if partNumber == "" {
+		return errors.New("part_number is required")
+	}
+	existing, err := u.partRepo.FindByPartNumber(partNumber)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != part.ID {
+		return errors.New("part number already exists")
+	}
+
+	unitCost, err := money.Cents(req.UnitCost)
+	if err != nil {
+		return fmt.Errorf("unit_cost: %w", err)
+	}
+
+	unit := strings.ToLower(strings.TrimSpace(req.Unit))
+	if unit == "" {
+		unit = "pcs"
+	}
+
+	part.PartNumber = partNumber
+	part.Name = strings.TrimSpace(req.Name)
+	part.Category = strings.ToLower(strings.TrimSpace(req.Category))
+	part.Unit = unit
+	part.UnitCost = money.FromCents(unitCost)
+	part.ReorderLevel = money.RoundQuantity(req.ReorderLevel)
+	part.ReorderQuantity = money.RoundQuantity(req.ReorderQuantity)
+	return nil
+}
+
+// stockKeeper moves stock in and out of parts and records every change as a
+// stock movement. It runs inside the caller's transaction.
+type stockKeeper struct {
+	partRepo          repository.PartRepository
+	stockMovementRepo repository.StockMovementRepository
+}
+
+func newStockKeeper(partRepo repository.PartRepository, stockMovementRepo repository.StockMovementRepository) *stockKeeper {
+	return &stockKeeper{
+		partRepo:          partRepo,
+		stockMovementRepo: stockMovementRepo,
+	}
+}
+
+// resolveItems fills work order items that name a catalog part with the
+// part's number, name and unit cost. The caller's items are left untouched.
+func (k *stockKeeper) resolveItems(tx *gorm.DB, reqItems []model.MaintenanceItemRequest) ([]model.MaintenanceItemRequest, error) {
+	items := make([]model.MaintenanceItemRequest, len(reqItems))
+	copy(items, reqItems)
+
+	partRepo := k.partRepo.WithTx(tx)
+	for i := range items {
+		if items[i].PartID == nil {
+			continue
+		}
+		if items[i].Type != entity.MaintenanceItemTypePart {
+			return nil, errors.New("only PART items can take a part from stock")
+		}
+		part, err := partRepo.FindByID(*items[i].PartID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("part not found")
+			}
+			return nil, err
+		}
+
+		items[i].PartNumber = part.PartNumber
+		if strings.TrimSpace(items[i].Description) == "" {
+			items[i].Description = part.Name
+		}
+		if items[i].UnitPrice == 0 {
+			items[i].UnitPrice = part.UnitCost
+		}
+	}
+	return items, nil
+}
+
+// sync issues or returns stock so that what is out to the work order matches
+// its catalog items. A cancelled work order holds no stock.
+func (k *stockKeeper) sync(tx *gorm.DB, maintenance *entity.Maintenance) error {
+	wanted := map[int64]float64{}
+	if maintenance.Status != entity.MaintenanceStatusCancelled {
+		for _, item := range maintenance.Items {
+			if item.PartID != nil {
+				wanted[*item.PartID] += item.Quantity
+			}
+		}
+	}
+	return k.settle(tx, maintenance.ID, wanted, "")
+}
+
+// returnAll puts everything issued to the work order back into stock, for
+// a work order about to be deleted.
+func (k *stockKeeper) returnAll(tx *gorm.DB, maintenanceID int64, note string) error {
+	return k.settle(tx, maintenanceID, map[int64]float64{}, note)
+}
+
+func (k *stockKeeper) settle(tx *gorm.DB, maintenanceID int64, wanted map[int64]float64, note string) error {
+	issued, err := k.stockMovementRepo.WithTx(tx).IssuedByMaintenance(maintenanceID)
+	if err != nil {
+		return err
+	}
+
+	// Positive means stock goes back to the shelf
+	deltas := map[int64]float64{}
+	for partID, quantity := range issued {
+		deltas[partID] += quantity
+	}
+	for partID, quantity := range wanted {
+		deltas[partID] -= quantity
+	}
+	ids := make([]int64, 0, len(deltas))
+	for partID, delta := range deltas {
+		if money.RoundQuantity(delta) != 0 {
+			ids = append(ids, partID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	parts, err := k.partRepo.WithTx(tx).FindByIDsForUpdate(ids)
+	if err != nil {
+		return err
+	}
+	if len(parts) != len(ids) {
+		return errors.New("part not found")
+	}
+
+	for i := range parts {
+		part := &parts[i]
+		movementType := entity.StockMovementReturn
+		if deltas[part.ID] < 0 {
+			movementType = entity.StockMovementIssue
+		}
+		unitCost := part.UnitCost
+		movement := &entity.StockMovement{
+			Type:          movementType,
+			Quantity:      deltas[part.ID],
+			UnitCost:      &unitCost,
+			MaintenanceID: &maintenanceID,
+			Note:          note,
+		}
+		if err := k.move(tx, part, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// move applies the movement's quantity to the locked part and records it.
+// Stock never goes below zero.
+func (k *stockKeeper) move(tx *gorm.DB, part *entity.Part, movement *entity.StockMovement) error {
+	movement.Quantity = money.RoundQuantity(movement.Quantity)
+	stock := money.RoundQuantity(part.Stock + movement.Quantity)
+	if stock < 0 {
+		return fmt.Errorf("insufficient stock for part %s: %s %s available", part.PartNumber, formatQuantity(part.Stock), part.Unit)
+	}
+
+	if err := k.partRepo.WithTx(tx).UpdateStock(part.ID, stock); err != nil {
+		return err
+	}
+	part.Stock = stock
+
+	movement.PartID = part.ID
+	movement.StockAfter = stock
+	return k.stockMovementRepo.WithTx(tx).Create(movement)
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
+
+func isLowStock(part *entity.Part) bool {
+	return part.ReorderLevel > 0 && part.Stock <= part.ReorderLevel
+}
+
+func toPartResponse(part *entity.Part) model.PartResponse {
+	return model.PartResponse{
+		ID:              part.ID,
+		PartNumber:      part.PartNumber,
+		Name:            part.Name,
+		Category:        part.Category,
+		Unit:            part.Unit,
+		UnitCost:        part.UnitCost,
+		Stock:           part.Stock,
+		ReorderLevel:    part.ReorderLevel,
+		ReorderQuantity: part.ReorderQuantity,
+		LowStock:        isLowStock(part),
+		CreatedAt:       part.CreatedAt,
+		UpdatedAt:       part.UpdatedAt,
+	}
+}
+
+func toStockMovementResponse(movement *entity.StockMovement) model.StockMovementResponse {
+	resp := model.StockMovementResponse{
+		ID:            movement.ID,
+		PartID:        movement.PartID,
+		Type:          movement.Type,
+		Quantity:      movement.Quantity,
+		StockAfter:    movement.StockAfter,
+		UnitCost:      movement.UnitCost,
+		MaintenanceID: movement.MaintenanceID,
+		Reference:     movement.Reference,
+		Note:          movement.Note,
+		CreatedBy:     movement.CreatedBy,
+		CreatedAt:     movement.CreatedAt,
+	}
+	if movement.Part != nil {
+		resp.PartNumber = movement.Part.PartNumber
+	}
+	return resp
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// reservationPickupLead is how early a reserved car may be checked out.
+const reservationPickupLead = 30 * time.Minute
+
+type ReservationUsecase interface {
+	GetAll(params model.ReservationListParams) ([]model.ReservationResponse, error)
+	GetByID(id int64) (*model.ReservationResponse, error)
+	Create(req model.ReservationRequest, requesterID int64) (*model.ReservationResponse, error)
+	Update(id int64, req model.ReservationRequest) (*model.ReservationResponse, error)
+	Cancel(id int64, req model.CancelReservationRequest) (*model.ReservationResponse, error)
+}
+
+type reservationUsecase struct {
+	reservationRepo repository.ReservationRepository
+	carRepo         repository.CarRepository
+	driverRepo      repository.DriverRepository
+	txManager       helper.TxManager
+}
+
+func NewReservationUsecase(
+	reservationRepo repository.ReservationRepository,
+	carRepo repository.CarRepository,
+	driverRepo repository.DriverRepository,
+	txManager helper.TxManager,
+) ReservationUsecase {
+	return &reservationUsecase{
+		reservationRepo: reservationRepo,
+		carRepo:         carRepo,
+		driverRepo:      driverRepo,
+		txManager:       txManager,
+	}
+}
+
+func (u *reservationUsecase) GetAll(params model.ReservationListParams) ([]model.ReservationResponse, error) {
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	reservations, err := u.reservationRepo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []model.ReservationResponse{}
+	for _, reservation := range reservations {
+		responses = append(responses, u.toResponse(&reservation))
+	}
+	return responses, nil
+}
+
+func (u *reservationUsecase) GetByID(id int64) (*model.ReservationResponse, error) {
+	reservation, err := u.reservationRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reservation not found")
+		}
+		return nil, err
+	}
+	response := u.toResponse(reservation)
+	return &response, nil
+}
+
+func (u *reservationUsecase) Create(req model.ReservationRequest, requesterID int64) (*model.ReservationResponse, error) {
+	if !req.EndTime.After(time.Now()) {
+		return nil, errors.New("reservation must end in the future")
+	}
+
+	reservation := &entity.Reservation{
+		CarID:     req.CarID,
+		DriverID:  req.DriverID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Purpose:   req.Purpose,
+		Notes:     req.Notes,
+		Status:    entity.ReservationStatusConfirmed,
+	}
+	if requesterID > 0 {
+		reservation.RequesterID = &requesterID
+	}
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := u.lockAndCheck(tx, reservation); err != nil {
+			return err
+		}
+		return u.reservationRepo.WithTx(tx).Create(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(reservation.ID)
+}
+
+func (u *reservationUsecase) Update(id int64, req model.ReservationRequest) (*model.ReservationResponse, error) {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		reservation, err := u.reservationRepo.WithTx(tx).FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		if reservation.Status != entity.ReservationStatusConfirmed {
+			return fmt.Errorf("cannot change a %s reservation", reservation.Status)
+		}
+
+		reservation.CarID = req.CarID
+		reservation.DriverID = req.DriverID
+		reservation.StartTime = req.StartTime
+		reservation.EndTime = req.EndTime
+		reservation.Purpose = req.Purpose
+		reservation.Notes = req.Notes
+
+		if err := u.lockAndCheck(tx, reservation); err != nil {
+			return err
+		}
+		return u.reservationRepo.WithTx(tx).Update(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(id)
+}
+
+func (u *reservationUsecase) Cancel(id int64, req model.CancelReservationRequest) (*model.ReservationResponse, error) {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		reservation, err := u.reservationRepo.WithTx(tx).FindByIDForUpdate(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		if reservation.Status != entity.ReservationStatusConfirmed {
+			return fmt.Errorf("cannot cancel a %s reservation", reservation.Status)
+		}
+
+		reservation.Status = entity.ReservationStatusCancelled
+		reservation.CancelReason = req.Reason
+		return u.reservationRepo.WithTx(tx).Update(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(id)
+}
+
+// lockAndCheck locks the car and driver rows (same order as checkout) so two
+// bookings for the same slot cannot both pass the overlap check.
+func (u *reservationUsecase) lockAndCheck(tx *gorm.DB, reservation *entity.Reservation) error {
+	if _, err := u.carRepo.WithTx(tx).FindByIDForUpdate(reservation.CarID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("car not found")
+		}
+		return err
+	}
+	if reservation.DriverID != nil {
+		if _, err := u.driverRepo.WithTx(tx).FindByIDForUpdate(*reservation.DriverID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("driver not found")
+			}
+			return err
+		}
+	}
+
+	conflicts, err := u.reservationRepo.WithTx(tx).FindOverlapping(
+		reservation.CarID, reservation.DriverID, reservation.StartTime, reservation.EndTime, reservation.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	conflict := conflicts[0]
+	if conflict.CarID == reservation.CarID {
+		return fmt.Errorf("car is already reserved from %s to %s",
+			conflict.StartTime.Format("2006-01-02 15:04"), conflict.EndTime.Format("2006-01-02 15:04"))
+	}
+	return fmt.Errorf("driver already has a reservation from %s to %s",
+		conflict.StartTime.Format("2006-01-02 15:04"), conflict.EndTime.Format("2006-01-02 15:04"))
+}
+
+func (u *reservationUsecase) toResponse(reservation *entity.Reservation) model.ReservationResponse {
+	resp := model.ReservationResponse{
+		ID:           reservation.ID,
+		CarID:        reservation.CarID,
+		DriverID:     reservation.DriverID,
+		RequesterID:  reservation.RequesterID,
+		StartTime:    reservation.StartTime,
+		EndTime:      reservation.EndTime,
+		Purpose:      reservation.Purpose,
+		Notes:        reservation.Notes,
+		Status:       reservation.Status,
+		TripID:       reservation.TripID,
+		CancelReason: reservation.CancelReason,
+		CreatedAt:    reservation.CreatedAt,
+		UpdatedAt:    reservation.UpdatedAt,
+	}
+	if reservation.Car != nil {
+		resp.Car = &model.CarResponse{
+			ID:           reservation.Car.ID,
+			LicensePlate: reservation.Car.LicensePlate,
+			Brand:        reservation.Car.Brand,
+			Model:        reservation.Car.Model,
+			Status:       reservation.Car.Status,
+		}
+	}
+	if reservation.Driver != nil {
+		resp.Driver = &model.DriverResponse{
+			ID:     reservation.Driver.ID,
+			Name:   reservation.Driver.Name,
+			Status: reservation.Driver.Status,
+		}
+	}
+	if reservation.Requester != nil {
+		resp.Requester = reservation.Requester.Username
+	}
+	return resp
+}
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"fleet-monitor/internal/storage"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const maxInspectionPhotos = 10
+
+var allowedInspectionPhotos = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+	".heic": "image/heic",
+}
+
+type TripInspectionUsecase interface {
+	GetChecklist(stage string) ([]model.ChecklistItemResponse, error)
+	GetChecklistItem(id int64) (*model.ChecklistItemResponse, error)
+	CreateChecklistItem(req model.ChecklistItemRequest) (*model.ChecklistItemResponse, error)
+	UpdateChecklistItem(id int64, req model.ChecklistItemRequest) (*model.ChecklistItemResponse, error)
+	DeleteChecklistItem(id int64) error
+	GetByTripID(tripID int64) (*model.TripInspectionSummary, error)
+	AddPhotos(tripID int64, stage string, files []*model.FileUpload) ([]model.InspectionPhotoResponse, error)
+	GetPhoto(tripID, photoID int64) (*model.FileDownload, error)
+}
+
+type tripInspectionUsecase struct {
+	checklistRepo  repository.InspectionChecklistRepository
+	inspectionRepo repository.TripInspectionRepository
+	tripRepo       repository.TripRepository
+	fileStore      storage.FileStore
+}
+
+func NewTripInspectionUsecase(
+	checklistRepo repository.InspectionChecklistRepository,
+	inspectionRepo repository.TripInspectionRepository,
+	tripRepo repository.TripRepository,
+	fileStore storage.FileStore,
+) TripInspectionUsecase {
+	return &tripInspectionUsecase{
+		checklistRepo:  checklistRepo,
+		inspectionRepo: inspectionRepo,
+		tripRepo:       tripRepo,
+		fileStore:      fileStore,
+	}
+}
+
+// GetChecklist returns every item when stage is empty, otherwise only the
+// active items a driver has to answer for that stage.
+func (u *tripInspectionUsecase) GetChecklist(stage string) ([]model.ChecklistItemResponse, error) {
+	var items []entity.InspectionChecklistItem
+	var err error
+	if stage == "" {
+		items, err = u.checklistRepo.FindAll()
+	} else {
+		stage = strings.ToUpper(stage)
+		if stage != entity.InspectionStageCheckout && stage != entity.InspectionStageCheckin {
+			return nil, errors.New("stage must be CHECKOUT or CHECKIN")
+		}
+		items, err = u.checklistRepo.FindActiveByStage(stage)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []model.ChecklistItemResponse{}
+	for _, item := range items {
+		responses = append(responses, u.toChecklistResponse(&item))
+	}
+	return responses, nil
+}
+
+func (u *tripInspectionUsecase) GetChecklistItem(id int64) (*model.ChecklistItemResponse, error) {
+	item, err := u.findChecklistItem(id)
+	if err != nil {
+		return nil, err
+	}
+	response := u.toChecklistResponse(item)
+	return &response, nil
+}
+
+func (u *tripInspectionUsecase) CreateChecklistItem(req model.ChecklistItemRequest) (*model.ChecklistItemResponse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if _, err := u.checklistRepo.FindByCode(code); err == nil {
+		return nil, errors.New("checklist code already exists")
+	}
+
+	item := &entity.InspectionChecklistItem{Code: code, Active: true}
+	u.applyChecklistRequest(item, req)
+
+	if err := u.checklistRepo.Create(item); err != nil {
+		return nil, err
+	}
+
+	response := u.toChecklistResponse(item)
+	return &response, nil
+}
+
+func (u *tripInspectionUsecase) UpdateChecklistItem(id int64, req model.ChecklistItemRequest) (*model.ChecklistItemResponse, error) {
+	item, err := u.findChecklistItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code != item.Code {
+		if _, err := u.checklistRepo.FindByCode(code); err == nil {
+			return nil, errors.New("checklist code already exists")
+		}
+		item.Code = code
+	}
+	u.applyChecklistRequest(item, req)
+
+	if err := u.checklistRepo.Update(item); err != nil {
+		return nil, err
+	}
+
+	response := u.toChecklistResponse(item)
+	return &response, nil
+}
+
+func (u *tripInspectionUsecase) DeleteChecklistItem(id int64) error {
+	if _, err := u.findChecklistItem(id); err != nil {
+		return err
+	}
+	return u.checklistRepo.Delete(id)
+}
+
+func (u *tripInspectionUsecase) GetByTripID(tripID int64) (*model.TripInspectionSummary, error) {
+	if err := u.ensureTrip(tripID); err != nil {
+		return nil, err
+	}
+
+	inspections, err := u.inspectionRepo.FindByTripID(tripID)
+	if err != nil {
+		return nil, err
+	}
+	photos, err := u.inspectionRepo.FindPhotosByTripID(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.TripInspectionSummary{
+		Inspections: []model.TripInspectionResponse{},
+		Photos:      []model.InspectionPhotoResponse{},
+	}
+	for _, inspection := range inspections {
+		summary.Inspections = append(summary.Inspections, u.toInspectionResponse(&inspection))
+	}
+	for _, photo := range photos {
+		summary.Photos = append(summary.Photos, u.toPhotoResponse(&photo))
+	}
+	return summary, nil
+}
+
+func (u *tripInspectionUsecase) AddPhotos(tripID int64, stage string, files []*model.FileUpload) ([]model.InspectionPhotoResponse, error) {
+	stage = strings.ToUpper(stage)
+	if stage != entity.InspectionStageCheckout && stage != entity.InspectionStageCheckin {
+		return nil, errors.New("stage must be CHECKOUT or CHECKIN")
+	}
+	if len(files) == 0 {
+		return nil, errors.New("at least one photo is required")
+	}
+	if len(files) > maxInspectionPhotos {
+		return nil, fmt.Errorf("at most %d photos can be uploaded at once", maxInspectionPhotos)
+	}
+	if err := u.ensureTrip(tripID); err != nil {
+		return nil, err
+	}
+
+	// Validate every file before anything touches the store
+	contentTypes := make([]string, len(files))
+	for i, file := range files {
+		contentType, ok := allowedInspectionPhotos[strings.ToLower(filepath.Ext(file.Filename))]
+		if !ok {
+			return nil, errors.New("photo must be a JPG, PNG, WEBP or HEIC image")
+		}
+		contentTypes[i] = contentType
+	}
+
+	responses := []model.InspectionPhotoResponse{}
+	for i, file := range files {
+		key, err := u.fileStore.Save(fmt.Sprintf("inspections/%d", tripID), file.Filename, file.Content)
+		if err != nil {
+			return nil, errors.New("failed to store photo")
+		}
+
+		photo := &entity.TripInspectionPhoto{
+			TripID:      tripID,
+			Stage:       stage,
+			FileKey:     key,
+			FileName:    filepath.Base(file.Filename),
+			ContentType: contentTypes[i],
+		}
+		if err := u.inspectionRepo.CreatePhoto(photo); err != nil {
+			u.fileStore.Delete(key)
+			return nil, err
+		}
+		responses = append(responses, u.toPhotoResponse(photo))
+	}
+	return responses, nil
+}
+
+func (u *tripInspectionUsecase) GetPhoto(tripID, photoID int64) (*model.FileDownload, error) {
+	photo, err := u.inspectionRepo.FindPhotoByID(photoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("photo not found")
+		}
+		return nil, err
+	}
+	if photo.TripID != tripID {
+		return nil, errors.New("photo not found")
+	}
+
+	content, err := u.fileStore.Open(photo.FileKey)
+	if err != nil {
+		return nil, errors.New("file not found")
+	}
+
+	return &model.FileDownload{
+		Filename:    photo.FileName,
+		ContentType: photo.ContentType,
+		Content:     content,
+	}, nil
+}
+
+func (u *tripInspectionUsecase) ensureTrip(tripID int64) error {
+	_, err := u.tripRepo.FindByID(tripID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("trip not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *tripInspectionUsecase) findChecklistItem(id int64) (*entity.InspectionChecklistItem, error) {
+	item, err := u.checklistRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("checklist item not found")
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *tripInspectionUsecase) applyChecklistRequest(item *entity.InspectionChecklistItem, req model.ChecklistItemRequest) {
+	item.Label = req.Label
+	item.Stage = req.Stage
+	item.Required = req.Required
+	item.OnFail = req.OnFail
+	if item.OnFail == "" {
+		item.OnFail = entity.InspectionOnFailIgnore
+	}
+	if req.Active != nil {
+		item.Active = *req.Active
+	}
+	item.SortOrder = req.SortOrder
+}
+
+func (u *tripInspectionUsecase) toChecklistResponse(item *entity.InspectionChecklistItem) model.ChecklistItemResponse {
+	return model.ChecklistItemResponse{
+		ID:        item.ID,
+		Code:      item.Code,
+		Label:     item.Label,
+		Stage:     item.Stage,
+		Required:  item.Required,
+		OnFail:    item.OnFail,
+		Active:    item.Active,
+		SortOrder: item.SortOrder,
+	}
+}
+
+func (u *tripInspectionUsecase) toInspectionResponse(inspection *entity.TripInspection) model.TripInspectionResponse {
+	resp := model.TripInspectionResponse{
+		ID:            inspection.ID,
+		TripID:        inspection.TripID,
+		Stage:         inspection.Stage,
+		FuelLevel:     inspection.FuelLevel,
+		DamageNotes:   inspection.DamageNotes,
+		Passed:        inspection.Passed,
+		MaintenanceID: inspection.MaintenanceID,
+		Items:         []model.TripInspectionItemResponse{},
+		CreatedAt:     inspection.CreatedAt,
+	}
+	for _, item := range inspection.Items {
+		resp.Items = append(resp.Items, model.TripInspectionItemResponse{
+			Code:  item.Code,
+			Label: item.Label,
+			OK:    item.OK,
+			Notes: item.Notes,
+		})
+	}
+	return resp
+}
+
+func (u *tripInspectionUsecase) toPhotoResponse(photo *entity.TripInspectionPhoto) model.InspectionPhotoResponse {
+	return model.InspectionPhotoResponse{
+		ID:          photo.ID,
+		TripID:      photo.TripID,
+		Stage:       photo.Stage,
+		FileName:    photo.FileName,
+		ContentType: photo.ContentType,
+		URL:         fmt.Sprintf("/api/trips/%d/photos/%d", photo.TripID, photo.ID),
+		CreatedAt:   photo.CreatedAt,
+	}
+}
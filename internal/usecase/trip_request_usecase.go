@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// tripRequestTransitions lists the states each trip request state may move to.
+// A checked-out request is completed by checkin; Cancel only closes it once
+// its trip is no longer in progress.
+var tripRequestTransitions = map[string][]string{
+	entity.TripRequestStatusRequested:  {entity.TripRequestStatusApproved, entity.TripRequestStatusRejected, entity.TripRequestStatusCancelled},
+	entity.TripRequestStatusApproved:   {entity.TripRequestStatusCheckedOut, entity.TripRequestStatusCancelled},
+	entity.TripRequestStatusCheckedOut: {entity.TripRequestStatusCompleted, entity.TripRequestStatusCancelled},
+}
+
+type TripRequestUsecase interface {
+	GetAll(params model.TripRequestListParams) ([]model.TripRequestResponse, int64, error)
+	GetByID(id int64) (*model.TripRequestResponse, error)
+	Create(req model.TripRequestRequest, requesterID int64) (*model.TripRequestResponse, error)
+	Approve(id, approverID int64, req model.TripRequestDecisionRequest) (*model.TripRequestResponse, error)
+	Reject(id, approverID int64, req model.TripRequestDecisionRequest) (*model.TripRequestResponse, error)
+	Cancel(id int64, req model.TripRequestDecisionRequest) (*model.TripRequestResponse, error)
+}
+
+type tripRequestUsecase struct {
+	requestRepo repository.TripRequestRepository
+	carRepo     repository.CarRepository
+	driverRepo  repository.DriverRepository
+	tripRepo    repository.TripRepository
+	txManager   helper.TxManager
+}
+
+func NewTripRequestUsecase(
+	requestRepo repository.TripRequestRepository,
+	carRepo repository.CarRepository,
+	driverRepo repository.DriverRepository,
+	tripRepo repository.TripRepository,
+	txManager helper.TxManager,
+) TripRequestUsecase {
+	return &tripRequestUsecase{
+		requestRepo: requestRepo,
+		carRepo:     carRepo,
+		driverRepo:  driverRepo,
+		tripRepo:    tripRepo,
+		txManager:   txManager,
+	}
+}
+
+func (u *tripRequestUsecase) GetAll(params model.TripRequestListParams) ([]model.TripRequestResponse, int64, error) {
+	requests, total, err := u.requestRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := []model.TripRequestResponse{}
+	for _, request := range requests {
+		responses = append(responses, u.toResponse(&request))
+	}
+	return responses, total, nil
+}
+
+func (u *tripRequestUsecase) GetByID(id int64) (*model.TripRequestResponse, error) {
+	request, err := u.requestRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("trip request not found")
+		}
+		return nil, err
+	}
+	response := u.toResponse(request)
+	return &response, nil
+}
+
+func (u *tripRequestUsecase) Create(req model.TripRequestRequest, requesterID int64) (*model.TripRequestResponse, error) {
+	if req.PlannedStart != nil && req.PlannedEnd != nil && !req.PlannedEnd.After(*req.PlannedStart) {
+		return nil, errors.New("planned_end must be after planned_start")
+	}
+
+	if _, err := u.carRepo.FindByID(req.CarID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("car not found")
+		}
+		return nil, err
+	}
+	if _, err := u.driverRepo.FindByID(req.DriverID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("driver not found")
+		}
+		return nil, err
+	}
+
+	request := &entity.TripRequest{
+		CarID:        req.CarID,
+		DriverID:     req.DriverID,
+		Purpose:      req.Purpose,
+		Destination:  req.Destination,
+		PlannedStart: req.PlannedStart,
+		PlannedEnd:   req.PlannedEnd,
+		Status:       entity.TripRequestStatusRequested,
+	}
+	if requesterID > 0 {
+		request.RequesterID = &requesterID
+	}
+
+	if err := u.requestRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(request.ID)
+}
+
+func (u *tripRequestUsecase) Approve(id, approverID int64, req model.TripRequestDecisionRequest) (*model.TripRequestResponse, error) {
+	return u.decide(id, approverID, entity.TripRequestStatusApproved, func(request *entity.TripRequest, now time.Time) {
+		request.ApprovedAt = &now
+		request.ApprovalNote = req.Reason
+	})
+}
+
+func (u *tripRequestUsecase) Reject(id, approverID int64, req model.TripRequestDecisionRequest) (*model.TripRequestResponse, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.New("a reason is required to reject a trip request")
+	}
+	return u.decide(id, approverID, entity.TripRequestStatusRejected, func(request *entity.TripRequest, now time.Time) {
+		request.RejectedAt = &now
+		request.RejectReason = req.Reason
+	})
+}
+
+func (u *tripRequestUsecase) Cancel(id int64, req model.TripRequestDecisionRequest) (*model.TripRequestResponse, error) {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		request, err := u.lock(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkTripRequestTransition(request, entity.TripRequestStatusCancelled); err != nil {
+			return err
+		}
+		if request.Status == entity.TripRequestStatusCheckedOut && request.TripID != nil {
+			trip, err := u.tripRepo.WithTx(tx).FindByID(*request.TripID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if trip != nil && trip.Status == entity.TripStatusInProgress {
+				return errors.New("trip request has a trip in progress; it is completed at checkin")
+			}
+		}
+
+		now := time.Now()
+		request.Status = entity.TripRequestStatusCancelled
+		request.CancelledAt = &now
+		request.CancelReason = req.Reason
+		return u.requestRepo.WithTx(tx).Update(request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(id)
+}
+
+// decide records an approver's decision. Requesters may not decide on their own request.
+func (u *tripRequestUsecase) decide(id, approverID int64, status string, apply func(*entity.TripRequest, time.Time)) (*model.TripRequestResponse, error) {
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		request, err := u.lock(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkTripRequestTransition(request, status); err != nil {
+			return err
+		}
+		if request.RequesterID != nil && *request.RequesterID == approverID {
+			return errors.New("you cannot decide on your own trip request")
+		}
+
+		request.Status = status
+		request.ApproverID = &approverID
+		apply(request, time.Now())
+		return u.requestRepo.WithTx(tx).Update(request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(id)
+}
+
+func (u *tripRequestUsecase) lock(tx *gorm.DB, id int64) (*entity.TripRequest, error) {
+	request, err := u.requestRepo.WithTx(tx).FindByIDForUpdate(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("trip request not found")
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+func (u *tripRequestUsecase) toResponse(request *entity.TripRequest) model.TripRequestResponse {
+	resp := model.TripRequestResponse{
+		ID:           request.ID,
+		CarID:        request.CarID,
+		DriverID:     request.DriverID,
+		RequesterID:  request.RequesterID,
+		Purpose:      request.Purpose,
+		Destination:  request.Destination,
+		PlannedStart: request.PlannedStart,
+		PlannedEnd:   request.PlannedEnd,
+		Status:       request.Status,
+		ApproverID:   request.ApproverID,
+		ApprovedAt:   request.ApprovedAt,
+		ApprovalNote: request.ApprovalNote,
+		RejectedAt:   request.RejectedAt,
+		RejectReason: request.RejectReason,
+		CancelledAt:  request.CancelledAt,
+		CancelReason: request.CancelReason,
+		TripID:       request.TripID,
+		CreatedAt:    request.CreatedAt,
+		UpdatedAt:    request.UpdatedAt,
+	}
+	if request.Car != nil {
+		resp.Car = &model.CarResponse{
+			ID:               request.Car.ID,
+			LicensePlate:     request.Car.LicensePlate,
+			Brand:            request.Car.Brand,
+			Model:            request.Car.Model,
+			Status:           request.Car.Status,
+			RequiresApproval: request.Car.RequiresApproval,
+		}
+	}
+	if request.Driver != nil {
+		resp.Driver = &model.DriverResponse{
+			ID:     request.Driver.ID,
+			Name:   request.Driver.Name,
+			Status: request.Driver.Status,
+		}
+	}
+	if request.Requester != nil {
+		resp.Requester = request.Requester.Username
+	}
+	if request.Approver != nil {
+		resp.Approver = request.Approver.Username
+	}
+	return resp
+}
+
+func checkTripRequestTransition(request *entity.TripRequest, to string) error {
+	for _, next := range tripRequestTransitions[request.Status] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot move trip request from %s to %s", request.Status, to)
+}
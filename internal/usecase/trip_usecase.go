@@ -2,35 +2,91 @@ package usecase
 
 import (
 	"errors"
+	"fleet-monitor/internal/config"
 	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/geo"
+	"fleet-monitor/internal/helper"
 	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/realtime"
 	"fleet-monitor/internal/repository"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// Segments of a GPS track slower than tripIdleSpeedKmh count as idling;
+// faster than tripMaxSpeedKmh they are treated as bad fixes.
+const (
+	tripIdleSpeedKmh = 5.0
+	tripMaxSpeedKmh  = 250.0
+)
+
 type TripUsecase interface {
 	GetAll(params model.TripListParams) ([]model.TripResponse, int64, error)
 	GetByID(id int64) (*model.TripResponse, error)
+	GetActiveByDriverID(driverID int64) (*model.TripResponse, error)
 	Checkout(req model.CheckoutRequest) (*model.TripResponse, error)
 	Checkin(req model.CheckinRequest) (*model.TripResponse, error)
+	GetRoute(id int64) (*model.TripRouteFeature, error)
 }
 
 type tripUsecase struct {
-	tripRepo   repository.TripRepository
-	carRepo    repository.CarRepository
-	driverRepo repository.DriverRepository
+	tripRepo        repository.TripRepository
+	carRepo         repository.CarRepository
+	locationRepo    repository.CarLocationRepository
+	driverRepo      repository.DriverRepository
+	checklistRepo   repository.InspectionChecklistRepository
+	inspectionRepo  repository.TripInspectionRepository
+	maintenanceRepo repository.MaintenanceRepository
+	reservationRepo repository.ReservationRepository
+	requestRepo     repository.TripRequestRepository
+	odometer        *odometerGuard
+	alerts          *AlertEngine
+	outbox          *NotificationOutbox
+	webhooks        *WebhookEmitter
+	txManager       helper.TxManager
+	hub             realtime.Hub
+	config          *config.Config
 }
 
 func NewTripUsecase(
 	tripRepo repository.TripRepository,
 	carRepo repository.CarRepository,
+	locationRepo repository.CarLocationRepository,
 	driverRepo repository.DriverRepository,
+	checklistRepo repository.InspectionChecklistRepository,
+	inspectionRepo repository.TripInspectionRepository,
+	maintenanceRepo repository.MaintenanceRepository,
+	reservationRepo repository.ReservationRepository,
+	requestRepo repository.TripRequestRepository,
+	odometerRepo repository.OdometerRepository,
+	alerts *AlertEngine,
+	outbox *NotificationOutbox,
+	webhooks *WebhookEmitter,
+	txManager helper.TxManager,
+	hub realtime.Hub,
+	cfg *config.Config,
 ) TripUsecase {
 	return &tripUsecase{
-		tripRepo:   tripRepo,
-		carRepo:    carRepo,
-		driverRepo: driverRepo,
+		tripRepo:        tripRepo,
+		carRepo:         carRepo,
+		locationRepo:    locationRepo,
+		driverRepo:      driverRepo,
+		checklistRepo:   checklistRepo,
+		inspectionRepo:  inspectionRepo,
+		maintenanceRepo: maintenanceRepo,
+		reservationRepo: reservationRepo,
+		requestRepo:     requestRepo,
+		odometer:        newOdometerGuard(odometerRepo, carRepo, cfg),
+		alerts:          alerts,
+		outbox:          outbox,
+		webhooks:        webhooks,
+		txManager:       txManager,
+		hub:             hub,
+		config:          cfg,
 	}
 }
 
@@ -59,59 +115,148 @@ func (u *tripUsecase) GetByID(id int64) (*model.TripResponse, error) {
 	return &response, nil
 }
 
-func (u *tripUsecase) Checkout(req model.CheckoutRequest) (*model.TripResponse, error) {
-	// Check car exists and is available
-	car, err := u.carRepo.FindByID(req.CarID)
+func (u *tripUsecase) GetActiveByDriverID(driverID int64) (*model.TripResponse, error) {
+	trip, err := u.tripRepo.FindActiveByDriverID(driverID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("car not found")
+			return nil, errors.New("no active trip")
 		}
 		return nil, err
 	}
-	if car.Status != entity.CarStatusAvailable {
-		return nil, errors.New("car is not available")
-	}
-
-	// Check driver exists and is off duty
-	driver, err := u.driverRepo.FindByID(req.DriverID)
+	trip, err = u.tripRepo.FindByID(trip.ID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("driver not found")
-		}
 		return nil, err
 	}
-	if driver.Status != entity.DriverStatusOffDuty {
-		return nil, errors.New("driver is already on duty")
-	}
-
-	// Check driver doesn't have active trip
-	_, err = u.tripRepo.FindActiveByDriverID(req.DriverID)
-	if err == nil {
-		return nil, errors.New("driver already has an active trip")
-	}
+	response := u.toResponse(trip)
+	return &response, nil
+}
 
-	// Create trip
+func (u *tripUsecase) Checkout(req model.CheckoutRequest) (*model.TripResponse, error) {
+	var car *entity.Car
 	trip := &entity.TripLog{
 		CarID:    req.CarID,
 		DriverID: req.DriverID,
 		StartKm:  req.StartKm,
 		Notes:    req.Notes,
+		Status:   entity.TripStatusInProgress,
 	}
 
-	if err := u.tripRepo.Create(trip); err != nil {
-		return nil, err
-	}
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		carRepo := u.carRepo.WithTx(tx)
+		driverRepo := u.driverRepo.WithTx(tx)
+		tripRepo := u.tripRepo.WithTx(tx)
 
-	// Update car status
-	if err := u.carRepo.UpdateStatus(req.CarID, entity.CarStatusInUse, &req.DriverID); err != nil {
-		return nil, err
-	}
+		// Lock car then driver; Checkin uses the same order to avoid deadlocks
+		var err error
+		car, err = carRepo.FindByIDForUpdate(req.CarID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("car not found")
+			}
+			return err
+		}
+		if car.Status != entity.CarStatusAvailable {
+			return errors.New("car is not available")
+		}
+		// The car status can be edited by hand; an open work order still wins
+		inProgress, err := u.maintenanceRepo.WithTx(tx).CountInProgressByCarID(req.CarID)
+		if err != nil {
+			return err
+		}
+		if inProgress > 0 {
+			return errors.New("car has a maintenance work order in progress")
+		}
+
+		driver, err := driverRepo.FindByIDForUpdate(req.DriverID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("driver not found")
+			}
+			return err
+		}
+		if driver.Status != entity.DriverStatusOffDuty {
+			return errors.New("driver is already on duty")
+		}
+
+		// Check driver doesn't have active trip
+		if _, err := tripRepo.FindActiveByDriverID(req.DriverID); err == nil {
+			return errors.New("driver already has an active trip")
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		request, err := u.approvedRequest(tx, car, req)
+		if err != nil {
+			return err
+		}
 
-	// Update driver status
-	if err := u.driverRepo.UpdateStatus(req.DriverID, entity.DriverStatusActive); err != nil {
+		// A car booked for this slot only goes to the booked driver
+		reservationRepo := u.reservationRepo.WithTx(tx)
+		reservation, err := reservationRepo.FindPickupForCar(req.CarID, time.Now(), reservationPickupLead)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if reservation != nil && !reservationAllows(reservation, req, driver, request) {
+			if reservation.DriverID != nil {
+				return fmt.Errorf("car is reserved for another driver until %s", reservation.EndTime.Format("2006-01-02 15:04"))
+			}
+			return fmt.Errorf("car is reserved by someone else until %s", reservation.EndTime.Format("2006-01-02 15:04"))
+		}
+
+		if err := tripRepo.Create(trip); err != nil {
+			return err
+		}
+		reading := &entity.OdometerReading{
+			Km:     req.StartKm,
+			Source: entity.OdometerSourceCheckout,
+			TripID: &trip.ID,
+		}
+		if err := u.odometer.record(tx, car, reading, nil); err != nil {
+			return err
+		}
+		if request != nil {
+			request.Status = entity.TripRequestStatusCheckedOut
+			request.TripID = &trip.ID
+			if err := u.requestRepo.WithTx(tx).Update(request); err != nil {
+				return err
+			}
+		}
+		if reservation != nil {
+			reservation.Driver = nil
+			reservation.DriverID = &req.DriverID
+			reservation.Status = entity.ReservationStatusFulfilled
+			reservation.TripID = &trip.ID
+			if err := reservationRepo.Update(reservation); err != nil {
+				return err
+			}
+		}
+		if _, err := u.recordInspection(tx, trip, entity.InspectionStageCheckout, req.Inspection); err != nil {
+			return err
+		}
+		if err := carRepo.UpdateStatus(req.CarID, entity.CarStatusInUse, &req.DriverID); err != nil {
+			return err
+		}
+		if err := driverRepo.UpdateStatus(req.DriverID, entity.DriverStatusActive); err != nil {
+			return err
+		}
+		if err := u.webhooks.emit(tx, entity.WebhookEventTripCheckedOut, newWebhookTripData(trip, car, driver)); err != nil {
+			return err
+		}
+		if err := u.webhooks.carStatusChanged(tx, car, car.Status, entity.CarStatusInUse, carStatusReasonCheckout); err != nil {
+			return err
+		}
+		return u.outbox.enqueue(tx, notificationEvent{
+			Type:    entity.NotificationEventTripCheckout,
+			Subject: fmt.Sprintf("%s checked out by %s", car.LicensePlate, driver.Name),
+			Body:    fmt.Sprintf("%s (%s %s) was checked out by %s at %s with %d km.", car.LicensePlate, car.Brand, car.Model, driver.Name, trip.StartTime.Format("2006-01-02 15:04"), trip.StartKm),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	u.hub.Publish(newStatusEvent(car, car.Status, entity.CarStatusInUse))
+
 	// Reload trip with relations
 	trip, _ = u.tripRepo.FindByID(trip.ID)
 	response := u.toResponse(trip)
@@ -119,8 +264,118 @@ func (u *tripUsecase) Checkout(req model.CheckoutRequest) (*model.TripResponse,
 }
 
 func (u *tripUsecase) Checkin(req model.CheckinRequest) (*model.TripResponse, error) {
-	// Find trip
-	trip, err := u.tripRepo.FindByID(req.TripID)
+	var car *entity.Car
+	var raised []entity.Alert
+	carStatus := entity.CarStatusAvailable
+
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		carRepo := u.carRepo.WithTx(tx)
+		driverRepo := u.driverRepo.WithTx(tx)
+		tripRepo := u.tripRepo.WithTx(tx)
+
+		// Lock the trip first so two checkins cannot both end it
+		trip, err := tripRepo.FindByIDForUpdate(req.TripID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("trip not found")
+			}
+			return err
+		}
+		if trip.Status != entity.TripStatusInProgress {
+			return errors.New("trip already ended")
+		}
+
+		car, err = carRepo.FindByIDForUpdate(trip.CarID)
+		if err != nil {
+			return err
+		}
+		driver, err := driverRepo.FindByIDForUpdate(trip.DriverID)
+		if err != nil {
+			return err
+		}
+
+		reading := &entity.OdometerReading{
+			Km:     req.EndKm,
+			Source: entity.OdometerSourceCheckin,
+			TripID: &trip.ID,
+		}
+		if err := u.odometer.record(tx, car, reading, &trip.StartKm); err != nil {
+			return err
+		}
+
+		blocked, err := u.recordInspection(tx, trip, entity.InspectionStageCheckin, req.Inspection)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			carStatus = entity.CarStatusMaintenance
+		}
+
+		endTime := time.Now()
+		if err := tripRepo.EndTrip(req.TripID, req.EndKm, req.Notes, endTime); err != nil {
+			return err
+		}
+		if err := u.summarizeTrack(tx, trip, req.EndKm, endTime); err != nil {
+			return err
+		}
+
+		// Catch long trips that ended between two scheduled checks
+		trip.EndTime = &endTime
+		trip.Status = entity.TripStatusCompleted
+		raised, err = u.alerts.checkTrip(tx, car, trip, endTime)
+		if err != nil {
+			return err
+		}
+
+		request, err := u.requestRepo.WithTx(tx).FindByTripID(trip.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if request != nil {
+			if err := checkTripRequestTransition(request, entity.TripRequestStatusCompleted); err != nil {
+				return err
+			}
+			request.Status = entity.TripRequestStatusCompleted
+			if err := u.requestRepo.WithTx(tx).Update(request); err != nil {
+				return err
+			}
+		}
+		if err := carRepo.UpdateStatus(trip.CarID, carStatus, nil); err != nil {
+			return err
+		}
+		if err := driverRepo.UpdateStatus(trip.DriverID, entity.DriverStatusOffDuty); err != nil {
+			return err
+		}
+		trip.EndKm = &req.EndKm
+		if err := u.webhooks.emit(tx, entity.WebhookEventTripCheckedIn, newWebhookTripData(trip, car, driver)); err != nil {
+			return err
+		}
+		if err := u.webhooks.carStatusChanged(tx, car, car.Status, carStatus, carStatusReasonCheckin); err != nil {
+			return err
+		}
+		return u.outbox.enqueue(tx, notificationEvent{
+			Type:    entity.NotificationEventTripCheckin,
+			Subject: fmt.Sprintf("%s checked in by %s", car.LicensePlate, driver.Name),
+			Body:    fmt.Sprintf("%s was returned by %s at %s after %d km; car is now %s.", car.LicensePlate, driver.Name, endTime.Format("2006-01-02 15:04"), req.EndKm-trip.StartKm, carStatus),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.hub.Publish(newStatusEvent(car, car.Status, carStatus))
+	for i := range raised {
+		u.hub.Publish(newAlertEvent(car, &raised[i]))
+	}
+
+	// Reload trip
+	trip, _ := u.tripRepo.FindByID(req.TripID)
+	response := u.toResponse(trip)
+	return &response, nil
+}
+
+func (u *tripUsecase) GetRoute(id int64) (*model.TripRouteFeature, error) {
+	trip, err := u.tripRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("trip not found")
@@ -128,29 +383,262 @@ func (u *tripUsecase) Checkin(req model.CheckinRequest) (*model.TripResponse, er
 		return nil, err
 	}
 
+	// An ongoing trip shows the track so far
+	to := time.Now()
 	if trip.EndTime != nil {
-		return nil, errors.New("trip already ended")
+		to = *trip.EndTime
 	}
-
-	// End trip
-	if err := u.tripRepo.EndTrip(req.TripID, req.EndKm, req.Notes); err != nil {
+	locations, err := u.locationRepo.FindTrack(trip.CarID, trip.StartTime, to)
+	if err != nil {
 		return nil, err
 	}
 
-	// Update car status
-	if err := u.carRepo.UpdateStatus(trip.CarID, entity.CarStatusAvailable, nil); err != nil {
-		return nil, err
+	points := make([]geo.Point, 0, len(locations))
+	for _, loc := range locations {
+		points = append(points, geo.Point{Lat: loc.Lat, Lng: loc.Lng})
+	}
+
+	properties := model.TripRouteProperties{
+		TripID:    trip.ID,
+		CarID:     trip.CarID,
+		StartTime: trip.StartTime,
+		EndTime:   trip.EndTime,
+		Points:    len(points),
+	}
+	if trip.GpsDistanceKm != nil {
+		properties.GpsDistanceKm = *trip.GpsDistanceKm
+	} else {
+		properties.GpsDistanceKm = roundTo(trackSummary(locations).DistanceM/1000, 2)
+	}
+
+	return &model.TripRouteFeature{
+		Type:       "Feature",
+		Geometry:   geo.LineString(points),
+		Properties: properties,
+	}, nil
+}
+
+// summarizeTrack computes distance, moving and idle time and top speed from
+// the car's GPS points during the trip and stores them on the trip. The trip
+// is flagged when the GPS distance strays from the odometer distance by more
+// than TRIP_DISTANCE_TOLERANCE_PCT, with the odometer tolerance as a floor
+// for short trips. Trips with fewer than two points are left without a summary.
+func (u *tripUsecase) summarizeTrack(tx *gorm.DB, trip *entity.TripLog, endKm int, endTime time.Time) error {
+	locations, err := u.locationRepo.WithTx(tx).FindTrack(trip.CarID, trip.StartTime, endTime)
+	if err != nil {
+		return err
+	}
+	if len(locations) < 2 {
+		return nil
 	}
 
-	// Update driver status
-	if err := u.driverRepo.UpdateStatus(trip.DriverID, entity.DriverStatusOffDuty); err != nil {
+	summary := trackSummary(locations)
+	gpsKm := roundTo(summary.DistanceM/1000, 2)
+	moving := int(summary.MovingTime.Seconds())
+	idle := int(summary.IdleTime.Seconds())
+	maxSpeed := roundTo(summary.MaxSpeedKmh, 2)
+
+	trip.GpsDistanceKm = &gpsKm
+	trip.MovingSeconds = &moving
+	trip.IdleSeconds = &idle
+	trip.MaxSpeedKmh = &maxSpeed
+
+	odometerKm := float64(endKm - trip.StartKm)
+	allowed := odometerKm * float64(u.config.TripDistanceTolerancePct) / 100
+	if floor := float64(u.config.OdometerToleranceKm); allowed < floor {
+		allowed = floor
+	}
+	trip.DistanceMismatch = math.Abs(gpsKm-odometerKm) > allowed
+
+	return u.tripRepo.WithTx(tx).UpdateTrackSummary(trip)
+}
+
+func trackSummary(locations []entity.CarLocation) geo.TrackSummary {
+	points := make([]geo.TrackPoint, 0, len(locations))
+	for _, loc := range locations {
+		points = append(points, geo.TrackPoint{
+			Point: geo.Point{Lat: loc.Lat, Lng: loc.Lng},
+			At:    loc.RecordedAt,
+		})
+	}
+	return geo.SummarizeTrack(points, tripIdleSpeedKmh, tripMaxSpeedKmh)
+}
+
+func roundTo(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
+
+// approvedRequest returns the approved trip request this checkout fulfils, locked
+// for the rest of the transaction. Cars that require approval cannot be
+// checked out without one; other cars use a request only when one exists.
+func (u *tripUsecase) approvedRequest(tx *gorm.DB, car *entity.Car, req model.CheckoutRequest) (*entity.TripRequest, error) {
+	requestRepo := u.requestRepo.WithTx(tx)
+
+	if req.TripRequestID != nil {
+		request, err := requestRepo.FindByIDForUpdate(*req.TripRequestID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("trip request not found")
+			}
+			return nil, err
+		}
+		if request.CarID != car.ID || request.DriverID != req.DriverID {
+			return nil, errors.New("trip request is for another car or driver")
+		}
+		if err := checkTripRequestTransition(request, entity.TripRequestStatusCheckedOut); err != nil {
+			return nil, err
+		}
+		return request, nil
+	}
+
+	request, err := requestRepo.FindApprovedForUpdate(car.ID, req.DriverID)
+	if err == nil {
+		return request, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
+	if car.RequiresApproval {
+		return nil, errors.New("car requires an approved trip request")
+	}
+	return nil, nil
+}
+
+// reservationAllows reports whether a checkout may fulfil the reservation.
+// A booking with a driver is for that driver only. Without one it is for
+// its requester: the requester checks the car out, drives it, or asked for
+// the trip request being checked out.
+func reservationAllows(reservation *entity.Reservation, req model.CheckoutRequest, driver *entity.Driver, request *entity.TripRequest) bool {
+	if reservation.DriverID != nil {
+		return *reservation.DriverID == req.DriverID
+	}
+	if reservation.RequesterID == nil {
+		return false
+	}
+	requesterID := *reservation.RequesterID
+	switch {
+	case req.UserID == requesterID:
+		return true
+	case driver.UserID != nil && *driver.UserID == requesterID:
+		return true
+	case request != nil && request.RequesterID != nil && *request.RequesterID == requesterID:
+		return true
+	}
+	return false
+}
 
-	// Reload trip
-	trip, _ = u.tripRepo.FindByID(req.TripID)
-	response := u.toResponse(trip)
-	return &response, nil
+// recordInspection validates the driver's answers against the active checklist
+// and stores them with the trip. Failed BLOCK items reject a checkout; on
+// checkin they send the car to the workshop, which is reported through the
+// returned flag. Failed MAINTENANCE items and checkin damage notes open a
+// maintenance ticket without touching the car status.
+func (u *tripUsecase) recordInspection(tx *gorm.DB, trip *entity.TripLog, stage string, req *model.InspectionRequest) (bool, error) {
+	checklist, err := u.checklistRepo.WithTx(tx).FindActiveByStage(stage)
+	if err != nil {
+		return false, err
+	}
+	if req == nil {
+		if len(checklist) > 0 {
+			return false, errors.New("inspection is required")
+		}
+		return false, nil
+	}
+
+	answers := make(map[string]model.InspectionItemResult, len(req.Items))
+	for _, item := range req.Items {
+		answers[strings.ToUpper(item.Code)] = item
+	}
+
+	inspection := &entity.TripInspection{
+		TripID:      trip.ID,
+		Stage:       stage,
+		FuelLevel:   req.FuelLevel,
+		DamageNotes: req.DamageNotes,
+		Passed:      true,
+	}
+
+	var blocking, maintenance []string
+	for _, item := range checklist {
+		answer, ok := answers[item.Code]
+		if !ok {
+			if item.Required {
+				return false, fmt.Errorf("inspection item %s is required", item.Code)
+			}
+			continue
+		}
+		delete(answers, item.Code)
+
+		inspection.Items = append(inspection.Items, entity.TripInspectionItem{
+			ChecklistItemID: item.ID,
+			Code:            item.Code,
+			Label:           item.Label,
+			OK:              answer.OK,
+			Notes:           answer.Notes,
+		})
+		if answer.OK {
+			continue
+		}
+		inspection.Passed = false
+		switch item.OnFail {
+		case entity.InspectionOnFailBlock:
+			blocking = append(blocking, item.Label)
+		case entity.InspectionOnFailMaintenance:
+			maintenance = append(maintenance, item.Label)
+		}
+	}
+	for code := range answers {
+		return false, fmt.Errorf("unknown inspection item %s", code)
+	}
+
+	if stage == entity.InspectionStageCheckout && len(blocking) > 0 {
+		return false, fmt.Errorf("inspection failed: %s", strings.Join(blocking, ", "))
+	}
+
+	// All findings (including damage notes at checkin) go into one work order
+	findings := append(blocking, maintenance...)
+	if stage == entity.InspectionStageCheckin && strings.TrimSpace(req.DamageNotes) != "" {
+		findings = append(findings, req.DamageNotes)
+	}
+	if len(findings) > 0 {
+		// Blocking findings hold the car in the workshop straight away
+		now := time.Now()
+		ticket := &entity.Maintenance{
+			CarID:       trip.CarID,
+			ServiceDate: now,
+			Description: fmt.Sprintf("Temuan inspeksi %s trip #%d: %s", strings.ToLower(stage), trip.ID, strings.Join(findings, "; ")),
+			Status:      entity.MaintenanceStatusScheduled,
+		}
+		if len(blocking) > 0 {
+			ticket.Status = entity.MaintenanceStatusInProgress
+			ticket.StartedAt = &now
+		}
+		if err := u.maintenanceRepo.WithTx(tx).Create(ticket); err != nil {
+			return false, err
+		}
+		inspection.MaintenanceID = &ticket.ID
+	}
+
+	if err := u.inspectionRepo.WithTx(tx).Create(inspection); err != nil {
+		return false, err
+	}
+	return len(blocking) > 0, nil
+}
+
+// newWebhookTripData builds the payload of trip.checked_out and trip.checked_in.
+func newWebhookTripData(trip *entity.TripLog, car *entity.Car, driver *entity.Driver) model.WebhookTripData {
+	return model.WebhookTripData{
+		TripID:       trip.ID,
+		CarID:        car.ID,
+		LicensePlate: car.LicensePlate,
+		DriverID:     driver.ID,
+		DriverName:   driver.Name,
+		StartTime:    trip.StartTime,
+		EndTime:      trip.EndTime,
+		StartKm:      trip.StartKm,
+		EndKm:        trip.EndKm,
+		Status:       trip.Status,
+	}
 }
 
 func (u *tripUsecase) toResponse(trip *entity.TripLog) model.TripResponse {
@@ -163,7 +651,18 @@ func (u *tripUsecase) toResponse(trip *entity.TripLog) model.TripResponse {
 		StartKm:   trip.StartKm,
 		EndKm:     trip.EndKm,
 		Notes:     trip.Notes,
+		Status:    trip.Status,
 		CreatedAt: trip.CreatedAt,
+
+		GpsDistanceKm:    trip.GpsDistanceKm,
+		MovingSeconds:    trip.MovingSeconds,
+		IdleSeconds:      trip.IdleSeconds,
+		MaxSpeedKmh:      trip.MaxSpeedKmh,
+		DistanceMismatch: trip.DistanceMismatch,
+	}
+	if trip.EndTime != nil {
+		duration := int(trip.EndTime.Sub(trip.StartTime).Seconds())
+		resp.DurationSeconds = &duration
 	}
 	if trip.Car != nil {
 		resp.Car = &model.CarResponse{
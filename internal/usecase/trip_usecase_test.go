@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"fleet-monitor/internal/config"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/realtime"
+	"fleet-monitor/internal/repository"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to the database in TEST_DATABASE_URL, which must be
+// migrated with make migrate-up. Tests that need it are skipped without it.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	return db
+}
+
+func newTestTripUsecase(db *gorm.DB) TripUsecase {
+	cfg := &config.Config{}
+	carRepo := repository.NewCarRepository(db)
+	outbox := NewNotificationOutbox(repository.NewNotificationSubscriptionRepository(db), repository.NewNotificationRepository(db))
+	return NewTripUsecase(
+		repository.NewTripRepository(db),
+		carRepo,
+		repository.NewCarLocationRepository(db),
+		repository.NewDriverRepository(db),
+		repository.NewInspectionChecklistRepository(db),
+		repository.NewTripInspectionRepository(db),
+		repository.NewMaintenanceRepository(db),
+		repository.NewReservationRepository(db),
+		repository.NewTripRequestRepository(db),
+		repository.NewOdometerRepository(db),
+		NewAlertEngine(repository.NewAlertRuleRepository(db), repository.NewAlertRepository(db), outbox),
+		outbox,
+		NewWebhookEmitter(repository.NewWebhookRepository(db), repository.NewWebhookDeliveryRepository(db)),
+		helper.NewTxManager(db),
+		realtime.NewHub(),
+		cfg,
+	)
+}
+
+// passedInspection answers every active checklist item of the stage as OK.
+func passedInspection(t *testing.T, db *gorm.DB, stage string) *model.InspectionRequest {
+	t.Helper()
+	items, err := repository.NewInspectionChecklistRepository(db).FindActiveByStage(stage)
+	if err != nil {
+		t.Fatalf("load checklist: %v", err)
+	}
+	inspection := &model.InspectionRequest{}
+	for _, item := range items {
+		inspection.Items = append(inspection.Items, model.InspectionItemResult{Code: item.Code, OK: true})
+	}
+	return inspection
+}
+
+func TestCheckoutSameCarConcurrently(t *testing.T) {
+	db := openTestDB(t)
+	u := newTestTripUsecase(db)
+
+	suffix := time.Now().UnixNano() % 1e9
+	car := &entity.Car{
+		LicensePlate: fmt.Sprintf("T %d", suffix),
+		Brand:        "Toyota",
+		Model:        "Avanza",
+		Year:         2024,
+		Status:       entity.CarStatusAvailable,
+	}
+	if err := db.Create(car).Error; err != nil {
+		t.Fatalf("create car: %v", err)
+	}
+	drivers := make([]*entity.Driver, 2)
+	for i := range drivers {
+		drivers[i] = &entity.Driver{
+			Name:          fmt.Sprintf("Test Driver %d", i+1),
+			LicenseNumber: fmt.Sprintf("T-%d-%d", suffix, i+1),
+			Status:        entity.DriverStatusOffDuty,
+		}
+		if err := db.Create(drivers[i]).Error; err != nil {
+			t.Fatalf("create driver: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		// Trips, inspections and odometer readings cascade with the car
+		db.Delete(&entity.Car{}, car.ID)
+		for _, driver := range drivers {
+			db.Delete(&entity.Driver{}, driver.ID)
+		}
+	})
+
+	inspection := passedInspection(t, db, entity.InspectionStageCheckout)
+	start := make(chan struct{})
+	errs := make([]error, len(drivers))
+	var wg sync.WaitGroup
+	for i, driver := range drivers {
+		wg.Add(1)
+		go func(i int, driverID int64) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = u.Checkout(model.CheckoutRequest{
+				CarID:      car.ID,
+				DriverID:   driverID,
+				Inspection: inspection,
+			})
+		}(i, driver.ID)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case err.Error() != "car is not available":
+			t.Errorf("Checkout error = %q, want car is not available", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("%d checkouts succeeded, want 1 (errors: %v)", succeeded, errs)
+	}
+
+	var trips int64
+	if err := db.Model(&entity.TripLog{}).Where("car_id = ?", car.ID).Count(&trips).Error; err != nil {
+		t.Fatalf("count trips: %v", err)
+	}
+	if trips != 1 {
+		t.Fatalf("%d trips created, want 1", trips)
+	}
+}
@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fleet-monitor/internal/config"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/helper"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"fleet-monitor/internal/webhook"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Delivery tuning, as for notifications: a batch is leased for webhookLease
+// while it is being sent and failed attempts back off from webhookRetryBase
+// up to webhookRetryMax.
+const (
+	webhookBatchSize = 50
+	webhookLease     = 5 * time.Minute
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
+)
+
+// Webhook status change reasons reported in car.status_changed events.
+const (
+	carStatusReasonManual      = "manual"
+	carStatusReasonCheckout    = "trip_checkout"
+	carStatusReasonCheckin     = "trip_checkin"
+	carStatusReasonMaintenance = "maintenance"
+)
+
+type WebhookUsecase interface {
+	GetAll() ([]model.WebhookResponse, error)
+	GetByID(id int64) (*model.WebhookResponse, error)
+	Create(req model.WebhookRequest, userID int64) (*model.WebhookResponse, error)
+	Update(id int64, req model.WebhookRequest) (*model.WebhookResponse, error)
+	Delete(id int64) error
+	GetDeliveries(params model.WebhookDeliveryListParams) ([]model.WebhookDeliveryResponse, int64, error)
+	Redeliver(webhookID, deliveryID int64) (*model.WebhookDeliveryResponse, error)
+	Dispatch(now time.Time) error
+}
+
+type webhookUsecase struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	sender       webhook.Sender
+	txManager    helper.TxManager
+	maxAttempts  int
+}
+
+func NewWebhookUsecase(
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	sender webhook.Sender,
+	txManager helper.TxManager,
+	cfg *config.Config,
+) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		txManager:    txManager,
+		maxAttempts:  cfg.WebhookMaxAttempts,
+	}
+}
+
+func (u *webhookUsecase) GetAll() ([]model.WebhookResponse, error) {
+	webhooks, err := u.webhookRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.WebhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		responses = append(responses, toWebhookResponse(&w))
+	}
+	return responses, nil
+}
+
+func (u *webhookUsecase) GetByID(id int64) (*model.WebhookResponse, error) {
+	w, err := u.findWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toWebhookResponse(w)
+	return &response, nil
+}
+
+// Create registers a webhook. Without a secret one is generated; either way
+// it is only returned here.
+func (u *webhookUsecase) Create(req model.WebhookRequest, userID int64) (*model.WebhookResponse, error) {
+	w := &entity.Webhook{Active: true, CreatedBy: &userID}
+	if err := applyWebhookRequest(w, req); err != nil {
+		return nil, err
+	}
+	if w.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		w.Secret = secret
+	}
+
+	if err := u.webhookRepo.Create(w); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookResponse(w)
+	response.Secret = w.Secret
+	return &response, nil
+}
+
+// Update changes a webhook. An empty secret keeps the current one.
+func (u *webhookUsecase) Update(id int64, req model.WebhookRequest) (*model.WebhookResponse, error) {
+	w, err := u.findWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyWebhookRequest(w, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.webhookRepo.Update(w); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookResponse(w)
+	return &response, nil
+}
+
+func (u *webhookUsecase) Delete(id int64) error {
+	if _, err := u.findWebhook(id); err != nil {
+		return err
+	}
+	return u.webhookRepo.Delete(id)
+}
+
+func (u *webhookUsecase) GetDeliveries(params model.WebhookDeliveryListParams) ([]model.WebhookDeliveryResponse, int64, error) {
+	if _, err := u.findWebhook(params.WebhookID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := u.deliveryRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, toWebhookDeliveryResponse(&delivery))
+	}
+	return responses, total, nil
+}
+
+// Redeliver sends a logged event again, right away and with the webhook's
+// current URL and secret. The attempt is logged as a new delivery; if it
+// fails it is retried by Dispatch like any other.
+func (u *webhookUsecase) Redeliver(webhookID, deliveryID int64) (*model.WebhookDeliveryResponse, error) {
+	w, err := u.findWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := u.deliveryRepo.FindByID(deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("delivery not found")
+		}
+		return nil, err
+	}
+	if original.WebhookID != w.ID {
+		return nil, errors.New("delivery not found")
+	}
+
+	now := time.Now()
+	delivery := &entity.WebhookDelivery{
+		WebhookID:     w.ID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entity.WebhookDeliveryStatusPending,
+		NextAttemptAt: now.Add(webhookLease),
+		RedeliveryOf:  &original.ID,
+	}
+	if err := u.deliveryRepo.Create(delivery); err != nil {
+		return nil, err
+	}
+
+	u.deliver(delivery, w, time.Now())
+	if err := u.deliveryRepo.Update(delivery); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookDeliveryResponse(delivery)
+	return &response, nil
+}
+
+// Dispatch sends the deliveries that are due. Failures are logged on the
+// delivery and retried with backoff until maxAttempts is reached.
+func (u *webhookUsecase) Dispatch(now time.Time) error {
+	var due []entity.WebhookDelivery
+	err := u.txManager.WithTransaction(func(tx *gorm.DB) error {
+		deliveryRepo := u.deliveryRepo.WithTx(tx)
+
+		var err error
+		due, err = deliveryRepo.FindDueForUpdate(now, webhookBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range due {
+			due[i].NextAttemptAt = now.Add(webhookLease)
+			if err := deliveryRepo.Update(&due[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	webhooks := make(map[int64]*entity.Webhook)
+	for i := range due {
+		delivery := &due[i]
+		w, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			w, err = u.webhookRepo.FindByID(delivery.WebhookID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			webhooks[delivery.WebhookID] = w
+		}
+
+		switch {
+		case w == nil:
+			// Deleted after the batch was leased; don't hold up the rest of it
+			delivery.Status = entity.WebhookDeliveryStatusFailed
+			delivery.LastError = "webhook was deleted"
+		case w.Active:
+			u.deliver(delivery, w, time.Now())
+		default:
+			// Queued before the webhook was disabled; give up instead of holding it
+			delivery.Status = entity.WebhookDeliveryStatusFailed
+			delivery.LastError = "webhook is disabled"
+		}
+		if err := u.deliveryRepo.Update(delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *webhookUsecase) deliver(delivery *entity.WebhookDelivery, w *entity.Webhook, now time.Time) {
+	delivery.Attempts++
+
+	result, err := u.sender.Deliver(context.Background(), webhook.Request{
+		URL:        w.URL,
+		Secret:     w.Secret,
+		Event:      delivery.EventType,
+		DeliveryID: strconv.FormatInt(delivery.ID, 10),
+		Body:       []byte(delivery.Payload),
+	})
+
+	durationMs := result.Duration.Milliseconds()
+	delivery.DurationMs = &durationMs
+	delivery.ResponseStatus = nil
+	if result.StatusCode != 0 {
+		statusCode := result.StatusCode
+		delivery.ResponseStatus = &statusCode
+	}
+	delivery.ResponseBody = result.Body
+
+	if err == nil {
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= u.maxAttempts {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(helper.Backoff(delivery.Attempts, webhookRetryBase, webhookRetryMax))
+}
+
+func (u *webhookUsecase) findWebhook(id int64) (*entity.Webhook, error) {
+	w, err := u.webhookRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+func applyWebhookRequest(w *entity.Webhook, req model.WebhookRequest) error {
+	target := strings.TrimSpace(req.URL)
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be an http or https URL")
+	}
+
+	w.Name = req.Name
+	w.URL = target
+	w.EventTypes = strings.Join(req.EventTypes, ",")
+	if req.Secret != "" {
+		w.Secret = req.Secret
+	}
+	if req.Active != nil {
+		w.Active = *req.Active
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secretBytes), nil
+}
+
+// WebhookEmitter queues a domain event for every webhook that wants it. Like
+// NotificationOutbox it runs inside the caller's transaction, so events are
+// only sent for changes that commit, and sending happens later in Dispatch.
+type WebhookEmitter struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+}
+
+func NewWebhookEmitter(webhookRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository) *WebhookEmitter {
+	return &WebhookEmitter{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (e *WebhookEmitter) emit(tx *gorm.DB, eventType string, data interface{}) error {
+	webhooks, err := e.webhookRepo.WithTx(tx).FindActiveByEvent(eventType)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return err
+	}
+	now := time.Now()
+	event := model.WebhookEvent{
+		ID:        "evt_" + hex.EncodeToString(idBytes),
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	deliveryRepo := e.deliveryRepo.WithTx(tx)
+	for i := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			WebhookID:     webhooks[i].ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        entity.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+		}
+		if err := deliveryRepo.Create(delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// carStatusChanged emits car.status_changed unless the status stayed the same.
+func (e *WebhookEmitter) carStatusChanged(tx *gorm.DB, car *entity.Car, previousStatus, status, reason string) error {
+	if previousStatus == status {
+		return nil
+	}
+	return e.emit(tx, entity.WebhookEventCarStatusChanged, model.WebhookCarStatusData{
+		CarID:          car.ID,
+		LicensePlate:   car.LicensePlate,
+		PreviousStatus: previousStatus,
+		Status:         status,
+		Reason:         reason,
+	})
+}
+
+func toWebhookResponse(w *entity.Webhook) model.WebhookResponse {
+	eventTypes := []string{}
+	if w.EventTypes != "" {
+		eventTypes = strings.Split(w.EventTypes, ",")
+	}
+	return model.WebhookResponse{
+		ID:         w.ID,
+		Name:       w.Name,
+		URL:        w.URL,
+		EventTypes: eventTypes,
+		Active:     w.Active,
+		CreatedBy:  w.CreatedBy,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *entity.WebhookDelivery) model.WebhookDeliveryResponse {
+	return model.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		DurationMs:     delivery.DurationMs,
+		DeliveredAt:    delivery.DeliveredAt,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
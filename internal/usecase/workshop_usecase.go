@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"errors"
+	"fleet-monitor/internal/entity"
+	"fleet-monitor/internal/model"
+	"fleet-monitor/internal/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type WorkshopUsecase interface {
+	GetAll(params model.WorkshopListParams) ([]model.WorkshopResponse, int64, error)
+	GetByID(id int64) (*model.WorkshopResponse, error)
+	Create(req model.WorkshopRequest) (*model.WorkshopResponse, error)
+	Update(id int64, req model.WorkshopRequest) (*model.WorkshopResponse, error)
+	Delete(id int64) error
+	Report(params model.WorkshopReportParams) ([]model.WorkshopReportItem, error)
+}
+
+type workshopUsecase struct {
+	workshopRepo repository.WorkshopRepository
+}
+
+func NewWorkshopUsecase(workshopRepo repository.WorkshopRepository) WorkshopUsecase {
+	return &workshopUsecase{workshopRepo: workshopRepo}
+}
+
+func (u *workshopUsecase) GetAll(params model.WorkshopListParams) ([]model.WorkshopResponse, int64, error) {
+	workshops, total, err := u.workshopRepo.FindAll(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]model.WorkshopResponse, 0, len(workshops))
+	for _, workshop := range workshops {
+		responses = append(responses, toWorkshopResponse(&workshop))
+	}
+	return responses, total, nil
+}
+
+func (u *workshopUsecase) GetByID(id int64) (*model.WorkshopResponse, error) {
+	workshop, err := u.findWorkshop(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toWorkshopResponse(workshop)
+	return &response, nil
+}
+
+func (u *workshopUsecase) Create(req model.WorkshopRequest) (*model.WorkshopResponse, error) {
+	workshop := &entity.Workshop{}
+	if err := u.applyRequest(workshop, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.workshopRepo.Create(workshop); err != nil {
+		return nil, err
+	}
+
+	response := toWorkshopResponse(workshop)
+	return &response, nil
+}
+
+func (u *workshopUsecase) Update(id int64, req model.WorkshopRequest) (*model.WorkshopResponse, error) {
+	workshop, err := u.findWorkshop(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyRequest(workshop, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.workshopRepo.Update(workshop); err != nil {
+		return nil, err
+	}
+
+	response := toWorkshopResponse(workshop)
+	return &response, nil
+}
+
+// Delete removes a workshop that has never serviced a car. Workshops with
+// history stay so that reports keep adding up.
+func (u *workshopUsecase) Delete(id int64) error {
+	if _, err := u.findWorkshop(id); err != nil {
+		return err
+	}
+
+	count, err := u.workshopRepo.CountMaintenances(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("workshop has maintenance history")
+	}
+	return u.workshopRepo.Delete(id)
+}
+
+func (u *workshopUsecase) Report(params model.WorkshopReportParams) ([]model.WorkshopReportItem, error) {
+	items, err := u.workshopRepo.Report(params)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.WorkshopReportItem{}
+	}
+	for i := range items {
+		if items[i].AvgTurnaroundHours != nil {
+			hours := roundTo(*items[i].AvgTurnaroundHours, 1)
+			items[i].AvgTurnaroundHours = &hours
+		}
+	}
+	return items, nil
+}
+
+func (u *workshopUsecase) findWorkshop(id int64) (*entity.Workshop, error) {
+	workshop, err := u.workshopRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("workshop not found")
+		}
+		return nil, err
+	}
+	return workshop, nil
+}
+
+func (u *workshopUsecase) applyRequest(workshop *entity.Workshop, req model.WorkshopRequest) error {
+	name := normalizeWorkshopName(req.Name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	existing, err := u.workshopRepo.FindByName(name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != workshop.ID {
+		return errors.New("workshop already exists")
+	}
+
+	specialties := make([]string, 0, len(req.Specialties))
+	seen := make(map[string]bool, len(req.Specialties))
+	for _, specialty := range req.Specialties {
+		specialty = strings.ToLower(normalizeWorkshopName(specialty))
+		if specialty == "" || seen[specialty] {
+			continue
+		}
+		seen[specialty] = true
+		specialties = append(specialties, specialty)
+	}
+
+	workshop.Name = name
+	workshop.Address = strings.TrimSpace(req.Address)
+	workshop.Lat = req.Lat
+	workshop.Lng = req.Lng
+	workshop.ContactName = strings.TrimSpace(req.ContactName)
+	workshop.Phone = strings.TrimSpace(req.Phone)
+	workshop.Email = strings.TrimSpace(req.Email)
+	workshop.Specialties = strings.Join(specialties, ",")
+	workshop.Rating = nil
+	if req.Rating != nil {
+		rating := roundTo(*req.Rating, 1)
+		workshop.Rating = &rating
+	}
+	return nil
+}
+
+// normalizeWorkshopName trims a name and collapses inner whitespace, so that
+// "Bengkel  Jaya " and "Bengkel Jaya" are the same workshop. Case is
+// ignored when names are compared.
+func normalizeWorkshopName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func toWorkshopResponse(workshop *entity.Workshop) model.WorkshopResponse {
+	specialties := []string{}
+	if workshop.Specialties != "" {
+		specialties = strings.Split(workshop.Specialties, ",")
+	}
+	return model.WorkshopResponse{
+		ID:          workshop.ID,
+		Name:        workshop.Name,
+		Address:     workshop.Address,
+		Lat:         workshop.Lat,
+		Lng:         workshop.Lng,
+		ContactName: workshop.ContactName,
+		Phone:       workshop.Phone,
+		Email:       workshop.Email,
+		Specialties: specialties,
+		Rating:      workshop.Rating,
+		CreatedAt:   workshop.CreatedAt,
+		UpdatedAt:   workshop.UpdatedAt,
+	}
+}
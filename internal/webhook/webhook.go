@@ -0,0 +1,108 @@
+// Package webhook signs and delivers event payloads to subscriber URLs.
+//
+// Each request carries the event type, the delivery ID, a Unix timestamp and
+// an HMAC-SHA256 signature of "<timestamp>.<body>" keyed with the
+// subscriber's secret. Receivers should recompute the signature and reject
+// stale timestamps to guard against replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Fleet-Event"
+	HeaderDelivery  = "X-Fleet-Delivery"
+	HeaderTimestamp = "X-Fleet-Timestamp"
+	HeaderSignature = "X-Fleet-Signature"
+)
+
+// maxResponseBody is how much of the subscriber's response is kept for the
+// delivery log.
+const maxResponseBody = 1024
+
+// Sign returns the signature header value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// Result describes the subscriber's answer. StatusCode is 0 when no response
+// was received.
+type Result struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+}
+
+// Sender delivers one signed request. A non-2xx answer is returned as an
+// error together with its Result.
+type Sender interface {
+	Deliver(ctx context.Context, req Request) (Result, error)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+func NewSender(timeout time.Duration) Sender {
+	return &httpSender{client: &http.Client{Timeout: timeout}}
+}
+
+func (s *httpSender) Deliver(ctx context.Context, req Request) (Result, error) {
+	var result Result
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return result, err
+	}
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "fleet-monitor-webhook")
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	start := time.Now()
+	resp, err := s.client.Do(httpReq)
+	result.Duration = time.Since(start)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return result, urlErr.Err
+		}
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result.StatusCode = resp.StatusCode
+	result.Body = string(body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("responded %d", resp.StatusCode)
+	}
+	return result, nil
+}